@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/internal/transport/grpc"
+	kcptransport "github.com/Richard-inter/game/internal/transport/kcp"
+	"github.com/Richard-inter/game/pkg/logger"
+)
+
+const (
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	Version   = "dev"
+	BuildTime = "unknown"
+	GoVersion = "unknown"
+)
+
+func main() {
+	// Initialize logger
+	logger.InitLogger()
+	log := logger.GetSugar()
+
+	log.Infow("Starting KCP Service",
+		"version", Version,
+		"build_time", BuildTime,
+		"go_version", GoVersion,
+		"service", "kcp-service",
+	)
+
+	// Load service-specific configuration
+	configFile := os.Getenv("CONFIG_PATH")
+	if configFile == "" {
+		configFile = "config/kcp-service.yaml" // fallback
+	}
+
+	cfg, err := config.LoadServiceConfigFromPath(configFile)
+	if err != nil {
+		log.Fatalw("Failed to load configuration", "error", err)
+	}
+
+	// Create gRPC client manager with service discovery
+	var etcdEndpoints []string
+	runtimeAddr := "localhost:9092" // Clawmachine runtime service direct address
+
+	if cfg.Discovery.Enabled && len(cfg.Discovery.Etcd.Endpoints) > 0 {
+		etcdEndpoints = cfg.Discovery.Etcd.Endpoints
+		log.Infow("Using etcd endpoints from config", "endpoints", etcdEndpoints)
+	} else {
+		log.Infow("Service discovery disabled, using direct gRPC connections")
+	}
+
+	grpcManager, err := grpc.NewClientManager(&grpc.ClientManagerConfig{
+		EtcdEndpoints: etcdEndpoints,
+		RuntimeAddr:   runtimeAddr,
+	})
+	if err != nil {
+		log.Fatalw("Failed to create gRPC client manager", "error", err)
+	}
+	defer grpcManager.Close()
+
+	// Players authenticate with a JWT in their HelloReq
+	authenticator, err := auth.NewAuthenticator(cfg.JWT)
+	if err != nil {
+		log.Fatalw("Failed to initialize authenticator", "error", err)
+	}
+
+	server := kcptransport.NewServer(cfg, log, grpcManager, authenticator)
+	server.SetBuild(Version)
+
+	// Start server in a goroutine
+	go func() {
+		if err := server.Start(); err != nil {
+			log.Fatalw("Failed to start KCP service", "error", err)
+		}
+	}()
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Infow("Shutting down KCP Service...")
+
+	// Graceful shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Errorw("KCP service shutdown error", "error", err)
+	}
+
+	log.Infow("KCP Service stopped")
+}
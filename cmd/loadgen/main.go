@@ -0,0 +1,116 @@
+// Command loadgen runs a scenario of virtual players against the
+// api-service and websocket-service and reports latency percentiles, error
+// rates and throughput for every message type and HTTP route.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/pkg/logger"
+)
+
+func main() {
+	var (
+		scenarioPath = flag.String("scenario", "config/loadgen-scenario.yaml", "scenario file")
+		apiURL       = flag.String("api", "http://localhost:8080", "api-service base URL")
+		wsURL        = flag.String("ws", "ws://localhost:8081/ws", "websocket-service endpoint")
+		configPath   = flag.String("config", "config/websocket-service.yaml", "gateway config holding the JWT secret players authenticate with")
+		players      = flag.Int("players", 0, "override the scenario's number of players")
+		duration     = flag.Duration("duration", 0, "override the scenario's duration")
+		interval     = flag.Duration("interval", 10*time.Second, "how often to print progress, 0 disables")
+		jsonPath     = flag.String("json", "", "also write the final report as JSON to this file")
+	)
+	flag.Parse()
+
+	logger.InitLogger()
+	log := logger.GetSugar()
+
+	scenario, err := LoadScenario(*scenarioPath)
+	if err != nil {
+		fail(err)
+	}
+	if *players > 0 {
+		scenario.Players = *players
+	}
+	if *duration > 0 {
+		scenario.Duration = *duration
+	}
+	if err := scenario.Validate(); err != nil {
+		fail(fmt.Errorf("invalid scenario: %w", err))
+	}
+
+	cfg, err := config.LoadServiceConfigFromPath(*configPath)
+	if err != nil {
+		fail(fmt.Errorf("failed to load config: %w", err))
+	}
+	authenticator, err := auth.NewAuthenticator(cfg.JWT)
+	if err != nil {
+		fail(fmt.Errorf("failed to initialize authenticator: %w", err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	stats := NewStats()
+	runner := NewRunner(scenario, *apiURL, *wsURL, authenticator, stats, log)
+
+	fmt.Fprintf(os.Stderr, "running %q with %d players, ramp up %s\n", scenario.Name, scenario.Players, scenario.RampUp)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runner.Run(ctx)
+	}()
+
+	if *interval > 0 {
+		ticker := time.NewTicker(*interval)
+		defer ticker.Stop()
+	progress:
+		for {
+			select {
+			case <-ticker.C:
+				printProgress(stats.Report())
+			case <-done:
+				break progress
+			}
+		}
+	}
+	<-done
+
+	report := stats.Report()
+	report.Print(os.Stdout)
+
+	if *jsonPath != "" {
+		file, err := os.Create(*jsonPath)
+		if err != nil {
+			fail(fmt.Errorf("failed to create report: %w", err))
+		}
+		defer file.Close()
+		if err := report.WriteJSON(file); err != nil {
+			fail(fmt.Errorf("failed to write report: %w", err))
+		}
+	}
+}
+
+func printProgress(report Report) {
+	var count, errors int
+	for _, op := range report.Operations {
+		count += op.Count
+		errors += op.Errors
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d operations, %d errors, %.1f/s\n",
+		round(report.Elapsed), count, errors, float64(count)/report.Elapsed.Seconds())
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "loadgen:", err)
+	os.Exit(2)
+}
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/pkg/client"
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// error codes recorded for failures that carry no catalogue code
+const (
+	codeTimeout      = "TIMEOUT"
+	codeDisconnected = "DISCONNECTED"
+	codeTransport    = "TRANSPORT"
+)
+
+// Runner plays a scenario with many virtual players at once
+type Runner struct {
+	scenario *Scenario
+	apiURL   string
+	wsURL    string
+	auth     *auth.Authenticator
+	http     *http.Client
+	stats    *Stats
+	logger   *zap.SugaredLogger
+	runID    string
+}
+
+func NewRunner(scenario *Scenario, apiURL, wsURL string, authenticator *auth.Authenticator, stats *Stats, logger *zap.SugaredLogger) *Runner {
+	return &Runner{
+		scenario: scenario,
+		apiURL:   apiURL,
+		wsURL:    wsURL,
+		auth:     authenticator,
+		http: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				Proxy:               http.ProxyFromEnvironment,
+				MaxIdleConnsPerHost: scenario.Players,
+			},
+		},
+		stats:  stats,
+		logger: logger,
+		runID:  strconv.FormatInt(time.Now().Unix(), 36),
+	}
+}
+
+// Run starts the players, spread evenly over the ramp up, and returns once
+// all of them are done or ctx is cancelled
+func (r *Runner) Run(ctx context.Context) {
+	if r.scenario.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.scenario.Duration)
+		defer cancel()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.scenario.Players; i++ {
+		delay := r.scenario.RampUp * time.Duration(i) / time.Duration(r.scenario.Players)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !sleep(ctx, delay) {
+				return
+			}
+			p := &player{runner: r, index: i}
+			p.run(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// player is one virtual player working through the scenario
+type player struct {
+	runner *Runner
+	index  int
+	id     int64
+	name   string
+	client *client.Client
+
+	// the game in progress
+	gameID uint64
+	board  *fbs.ClawBoardT
+}
+
+func (p *player) run(ctx context.Context) {
+	s := p.runner.scenario
+	p.name = fmt.Sprintf("loadgen-%s-%d", p.runner.runID, p.index)
+	if s.FirstPlayerID > 0 {
+		p.id = s.FirstPlayerID + int64(p.index)
+	}
+
+	defer func() {
+		if p.client != nil {
+			p.client.Close()
+		}
+	}()
+
+	for _, step := range s.Setup {
+		if err := p.step(ctx, step); err != nil {
+			if ctx.Err() == nil {
+				p.runner.logger.Warnw("Player setup failed", "player", p.index, "action", step.Action, "error", err)
+			}
+			return
+		}
+		if !p.think(ctx) {
+			return
+		}
+	}
+
+	for iteration := 0; s.Iterations == 0 || iteration < s.Iterations; iteration++ {
+		for _, step := range s.Loop {
+			err := p.step(ctx, step)
+			if !p.think(ctx) {
+				return
+			}
+			// later steps of the iteration depend on this one
+			if err != nil {
+				break
+			}
+		}
+	}
+}
+
+func (p *player) think(ctx context.Context) bool {
+	thinkTime := p.runner.scenario.ThinkTime
+	if thinkTime <= 0 {
+		return ctx.Err() == nil
+	}
+	return sleep(ctx, thinkTime/2+rand.N(thinkTime))
+}
+
+func (p *player) step(ctx context.Context, step Step) error {
+	switch step.Action {
+	case actionCreatePlayer:
+		var created struct {
+			PlayerID int64 `json:"playerID"`
+		}
+		if err := p.post(ctx, "/api/v1/player/create", map[string]any{"userName": p.name}, &created); err != nil {
+			return err
+		}
+		p.id = created.PlayerID
+		return nil
+
+	case actionCreateClawPlayer:
+		return p.post(ctx, "/api/v1/clawMachine/createClawPlayer", map[string]any{
+			"playerID": p.id,
+			"userName": p.name,
+			"coin":     step.Amount,
+			"diamond":  1,
+		}, nil)
+
+	case actionFund:
+		return p.post(ctx, "/api/v1/clawMachine/adjustPlayerCoin", map[string]any{
+			"playerID": p.id,
+			"amount":   step.Amount,
+			"type":     "plus",
+		}, nil)
+
+	case actionConnect:
+		return p.connect(ctx)
+
+	case actionStartGame:
+		return p.startGame(ctx, step.MachineID)
+
+	case actionMove:
+		for i := 0; i < step.Times; i++ {
+			dx, dy := step.Dx, step.Dy
+			if step.Random && p.board != nil && p.board.MaxStep > 0 {
+				dx = rand.Int32N(2*p.board.MaxStep+1) - p.board.MaxStep
+				dy = rand.Int32N(2*p.board.MaxStep+1) - p.board.MaxStep
+			}
+			if err := p.clawInput(ctx, fbs.ClawActionMove, dx, dy); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case actionDrop:
+		err := p.clawInput(ctx, fbs.ClawActionDrop, 0, 0)
+		p.gameID, p.board = 0, nil
+		return err
+
+	case actionPlayerInfo:
+		if step.Via == viaHTTP {
+			return p.get(ctx, "/api/v1/clawMachine/getClawPlayerInfo/", strconv.FormatInt(p.id, 10))
+		}
+		return p.record(ctx, "GetPlayerInfoWsReq", func(ctx context.Context) error {
+			_, err := p.client.GetPlayerInfo(ctx)
+			return err
+		})
+
+	case actionMachineInfo:
+		return p.record(ctx, "GetMachineInfoWsReq", func(ctx context.Context) error {
+			_, err := p.client.GetMachineInfo(ctx, step.MachineID)
+			return err
+		})
+
+	case actionSleep:
+		if !sleep(ctx, step.Duration) {
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown action %q", step.Action)
+}
+
+func (p *player) connect(ctx context.Context) error {
+	token, err := p.runner.auth.IssueToken(p.id)
+	if err != nil {
+		return fmt.Errorf("failed to issue token: %w", err)
+	}
+
+	return p.record(ctx, "connect", func(ctx context.Context) error {
+		c, err := client.Dial(ctx, client.Options{
+			URL:       p.runner.wsURL,
+			Token:     token,
+			Build:     "loadgen",
+			Reconnect: true,
+			Logger:    p.runner.logger.With("player", p.index),
+		})
+		if err != nil {
+			return err
+		}
+		p.client = c
+		return nil
+	})
+}
+
+func (p *player) startGame(ctx context.Context, machineID uint64) error {
+	return p.record(ctx, "StartClawGameReq", func(ctx context.Context) error {
+		resp, err := p.client.StartClawGame(ctx, machineID)
+		if err != nil {
+			return err
+		}
+		p.gameID, p.board = resp.GameId, resp.Board
+		return nil
+	})
+}
+
+func (p *player) clawInput(ctx context.Context, action fbs.ClawAction, dx, dy int32) error {
+	if p.gameID == 0 {
+		return errors.New("no game in progress")
+	}
+	return p.record(ctx, "ClawInputReq", func(ctx context.Context) error {
+		_, err := p.client.ClawInput(ctx, p.gameID, action, dx, dy)
+		return err
+	})
+}
+
+// record times call and records its outcome under op: the message type for
+// WebSocket requests, the route for HTTP requests. Calls cut short by the
+// end of the run are not recorded.
+func (p *player) record(ctx context.Context, op string, call func(ctx context.Context) error) error {
+	start := time.Now()
+	err := call(ctx)
+	latency := time.Since(start)
+
+	if ended(ctx) {
+		return context.Cause(ctx)
+	}
+	p.runner.stats.Record(op, latency, errorCode(err))
+	return err
+}
+
+func (p *player) post(ctx context.Context, path string, body any, data any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return p.record(ctx, "POST "+path, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.runner.apiURL+path, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return p.do(req, data)
+	})
+}
+
+// get requests route with param appended, recording it under the route
+func (p *player) get(ctx context.Context, route, param string) error {
+	return p.record(ctx, "GET "+route+":id", func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.runner.apiURL+route+param, nil)
+		if err != nil {
+			return err
+		}
+		return p.do(req, nil)
+	})
+}
+
+// apiResponse is the envelope of every api-service response
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Error   string          `json:"error"`
+	Code    string          `json:"code"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (p *player) do(req *http.Request, data any) error {
+	resp, err := p.runner.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var body apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return errcode.Newf(errcode.FromHTTPStatus(resp.StatusCode), "HTTP %d", resp.StatusCode)
+		}
+		return fmt.Errorf("invalid response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if !body.Success {
+		code := errcode.Code(body.Code)
+		if code == "" {
+			code = errcode.FromHTTPStatus(resp.StatusCode)
+		}
+		return errcode.New(code, body.Error)
+	}
+
+	if data != nil && len(body.Data) > 0 {
+		if err := json.Unmarshal(body.Data, data); err != nil {
+			return fmt.Errorf("invalid response data: %w", err)
+		}
+	}
+	return nil
+}
+
+// errorCode classifies err for the report
+func errorCode(err error) string {
+	var e *errcode.Error
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return codeOK
+	case errors.As(err, &e):
+		return string(e.Code)
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return codeTimeout
+	case errors.Is(err, client.ErrDisconnected), errors.Is(err, client.ErrClosed):
+		return codeDisconnected
+	default:
+		return codeTransport
+	}
+}
+
+// ended reports whether the run is over. The deadline is checked as well as
+// the context's error, which is set a moment after the deadline passes.
+func ended(ctx context.Context) bool {
+	if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+		return true
+	}
+	return ctx.Err() != nil
+}
+
+// sleep waits for d, reporting false when ctx ends first
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Step actions. HTTP steps call the api-service, the others run over the
+// player's WebSocket session, which the connect step opens.
+const (
+	actionCreatePlayer     = "create_player"      // HTTP: create the player and take its ID
+	actionCreateClawPlayer = "create_claw_player" // HTTP: open the player's claw account with amount coins
+	actionFund             = "fund"               // HTTP: add amount coins
+	actionConnect          = "connect"            // WebSocket: connect as the player
+	actionStartGame        = "start_game"         // WebSocket: start a game on machine_id
+	actionMove             = "move"               // WebSocket: move the claw times times
+	actionDrop             = "drop"               // WebSocket: drop the claw, settling the game
+	actionPlayerInfo       = "player_info"        // read the player's balances, over via
+	actionMachineInfo      = "machine_info"       // WebSocket: read machine_id
+	actionSleep            = "sleep"              // pause for duration
+
+	viaHTTP      = "http"
+	viaWebSocket = "websocket"
+)
+
+// Scenario is what every virtual player does: Setup once, then Loop until
+// the run ends or the player has done Iterations loops
+type Scenario struct {
+	Name string `mapstructure:"name"`
+	// Players run concurrently; they start evenly spread over RampUp
+	Players    int           `mapstructure:"players"`
+	RampUp     time.Duration `mapstructure:"ramp_up"`
+	Duration   time.Duration `mapstructure:"duration"`
+	Iterations int           `mapstructure:"iterations"`
+	// ThinkTime is the pause between steps, randomized by half either way
+	ThinkTime time.Duration `mapstructure:"think_time"`
+	// FirstPlayerID numbers existing players from it when Setup does not
+	// create them: the first virtual player plays as FirstPlayerID, the
+	// second as FirstPlayerID+1 and so on
+	FirstPlayerID int64  `mapstructure:"first_player_id"`
+	Setup         []Step `mapstructure:"setup"`
+	Loop          []Step `mapstructure:"loop"`
+}
+
+// Step is one action of a scenario; the fields an action does not use are
+// ignored
+type Step struct {
+	Action    string        `mapstructure:"action"`
+	Via       string        `mapstructure:"via"`
+	MachineID uint64        `mapstructure:"machine_id"`
+	Amount    int64         `mapstructure:"amount"`
+	Times     int           `mapstructure:"times"`
+	Dx        int32         `mapstructure:"dx"`
+	Dy        int32         `mapstructure:"dy"`
+	Random    bool          `mapstructure:"random"`
+	Duration  time.Duration `mapstructure:"duration"`
+}
+
+// LoadScenario reads a scenario file
+func LoadScenario(path string) (*Scenario, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	var s Scenario
+	if err := v.Unmarshal(&s); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+	return &s, nil
+}
+
+// Validate checks the scenario and fills in defaults
+func (s *Scenario) Validate() error {
+	if s.Players <= 0 {
+		return fmt.Errorf("scenario needs at least one player")
+	}
+	if s.Duration <= 0 && s.Iterations <= 0 {
+		return fmt.Errorf("scenario needs a duration or a number of iterations")
+	}
+	if s.RampUp < 0 || s.ThinkTime < 0 {
+		return fmt.Errorf("scenario ramp up and think time cannot be negative")
+	}
+	if len(s.Loop) == 0 {
+		return fmt.Errorf("scenario needs at least one loop step")
+	}
+
+	creates := slices.ContainsFunc(s.Setup, func(st Step) bool { return st.Action == actionCreatePlayer })
+	if !creates && s.FirstPlayerID <= 0 {
+		return fmt.Errorf("scenario needs a create_player setup step or a first_player_id")
+	}
+
+	connected := false
+	for i := range s.Setup {
+		if err := s.Setup[i].validate(connected); err != nil {
+			return fmt.Errorf("setup step %d: %w", i+1, err)
+		}
+		connected = connected || s.Setup[i].Action == actionConnect
+	}
+	for i := range s.Loop {
+		if s.Loop[i].Action == actionConnect || s.Loop[i].Action == actionCreatePlayer {
+			return fmt.Errorf("loop step %d: %s belongs in setup", i+1, s.Loop[i].Action)
+		}
+		if err := s.Loop[i].validate(connected); err != nil {
+			return fmt.Errorf("loop step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (st *Step) validate(connected bool) error {
+	switch st.Action {
+	case actionCreatePlayer, actionConnect:
+	case actionCreateClawPlayer:
+		// the API requires a starting balance
+		if st.Amount <= 0 {
+			st.Amount = 1
+		}
+	case actionFund:
+		if st.Amount <= 0 {
+			return fmt.Errorf("fund needs a positive amount")
+		}
+	case actionPlayerInfo:
+		if st.Via == "" {
+			st.Via = viaWebSocket
+		}
+		if st.Via != viaHTTP && st.Via != viaWebSocket {
+			return fmt.Errorf("%s via must be http or websocket", st.Action)
+		}
+	case actionStartGame:
+		if st.MachineID == 0 {
+			return fmt.Errorf("start_game needs a machine_id")
+		}
+	case actionMove:
+		if st.Times <= 0 {
+			st.Times = 1
+		}
+	case actionDrop, actionMachineInfo:
+	case actionSleep:
+		if st.Duration <= 0 {
+			return fmt.Errorf("sleep needs a duration")
+		}
+	default:
+		return fmt.Errorf("unknown action %q", st.Action)
+	}
+
+	if st.overWebSocket() && !connected {
+		return fmt.Errorf("%s runs over WebSocket and needs a connect step in setup", st.Action)
+	}
+	return nil
+}
+
+func (st *Step) overWebSocket() bool {
+	switch st.Action {
+	case actionStartGame, actionMove, actionDrop, actionMachineInfo:
+		return true
+	case actionPlayerInfo:
+		return st.Via == viaWebSocket
+	default:
+		return false
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadScenario(t *testing.T) {
+	s, err := LoadScenario("../../config/loadgen-scenario.yaml")
+	if err != nil {
+		t.Fatalf("LoadScenario() error = %v", err)
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if s.Players != 100 || s.RampUp != 30*time.Second || s.Duration != 5*time.Minute {
+		t.Errorf("scenario = %d players over %s for %s, want 100 over 30s for 5m", s.Players, s.RampUp, s.Duration)
+	}
+	if len(s.Setup) != 4 || len(s.Loop) != 5 {
+		t.Fatalf("scenario has %d setup and %d loop steps, want 4 and 5", len(s.Setup), len(s.Loop))
+	}
+	if start := s.Loop[1]; start.Action != actionStartGame || start.MachineID != 1 {
+		t.Errorf("loop step 2 = %+v, want start_game on machine 1", start)
+	}
+	// filled in by Validate
+	if via := s.Loop[0].Via; via != viaWebSocket {
+		t.Errorf("player_info via = %q, want %q", via, viaWebSocket)
+	}
+	if sleep := s.Loop[4]; sleep.Duration != time.Second {
+		t.Errorf("sleep duration = %s, want 1s", sleep.Duration)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	connect := []Step{{Action: actionCreatePlayer}, {Action: actionConnect}}
+
+	tests := []struct {
+		name     string
+		scenario Scenario
+		wantErr  string // "" when valid
+	}{
+		{
+			name:     "valid",
+			scenario: Scenario{Players: 1, Iterations: 1, Setup: connect, Loop: []Step{{Action: actionDrop}}},
+		},
+		{
+			name:     "existing players",
+			scenario: Scenario{Players: 1, Iterations: 1, FirstPlayerID: 10, Setup: []Step{{Action: actionConnect}}, Loop: []Step{{Action: actionDrop}}},
+		},
+		{
+			name:     "no players",
+			scenario: Scenario{Iterations: 1, Setup: connect, Loop: []Step{{Action: actionDrop}}},
+			wantErr:  "at least one player",
+		},
+		{
+			name:     "endless",
+			scenario: Scenario{Players: 1, Setup: connect, Loop: []Step{{Action: actionDrop}}},
+			wantErr:  "duration or a number of iterations",
+		},
+		{
+			name:     "negative think time",
+			scenario: Scenario{Players: 1, Iterations: 1, ThinkTime: -time.Second, Setup: connect, Loop: []Step{{Action: actionDrop}}},
+			wantErr:  "cannot be negative",
+		},
+		{
+			name:     "no loop",
+			scenario: Scenario{Players: 1, Iterations: 1, Setup: connect},
+			wantErr:  "at least one loop step",
+		},
+		{
+			name:     "no player to play as",
+			scenario: Scenario{Players: 1, Iterations: 1, Setup: []Step{{Action: actionConnect}}, Loop: []Step{{Action: actionDrop}}},
+			wantErr:  "first_player_id",
+		},
+		{
+			name:     "WebSocket step without connect",
+			scenario: Scenario{Players: 1, Iterations: 1, Setup: []Step{{Action: actionCreatePlayer}}, Loop: []Step{{Action: actionDrop}}},
+			wantErr:  "loop step 1: drop runs over WebSocket",
+		},
+		{
+			name:     "HTTP step without connect",
+			scenario: Scenario{Players: 1, Iterations: 1, Setup: []Step{{Action: actionCreatePlayer}}, Loop: []Step{{Action: actionPlayerInfo, Via: viaHTTP}}},
+		},
+		{
+			name:     "connect in loop",
+			scenario: Scenario{Players: 1, Iterations: 1, Setup: connect, Loop: []Step{{Action: actionConnect}}},
+			wantErr:  "belongs in setup",
+		},
+		{
+			name:     "start game without machine",
+			scenario: Scenario{Players: 1, Iterations: 1, Setup: connect, Loop: []Step{{Action: actionStartGame}}},
+			wantErr:  "needs a machine_id",
+		},
+		{
+			name:     "fund without amount",
+			scenario: Scenario{Players: 1, Iterations: 1, Setup: append(connect, Step{Action: actionFund}), Loop: []Step{{Action: actionDrop}}},
+			wantErr:  "setup step 3: fund needs a positive amount",
+		},
+		{
+			name:     "unknown via",
+			scenario: Scenario{Players: 1, Iterations: 1, Setup: connect, Loop: []Step{{Action: actionPlayerInfo, Via: "grpc"}}},
+			wantErr:  "via must be http or websocket",
+		},
+		{
+			name:     "unknown action",
+			scenario: Scenario{Players: 1, Iterations: 1, Setup: connect, Loop: []Step{{Action: "dance"}}},
+			wantErr:  `unknown action "dance"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.scenario.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStatsReport(t *testing.T) {
+	stats := NewStats()
+	for i := 1; i <= 100; i++ {
+		code := codeOK
+		if i%10 == 0 {
+			code = "RATE_LIMITED"
+		}
+		stats.Record("StartClawGameReq", time.Duration(i)*time.Millisecond, code)
+	}
+	stats.Record("GET /api/v1/player/info", time.Millisecond, codeOK)
+
+	report := stats.Report()
+	if len(report.Operations) != 2 {
+		t.Fatalf("report has %d operations, want 2", len(report.Operations))
+	}
+
+	// sorted by operation
+	httpOp, wsOp := report.Operations[0], report.Operations[1]
+	if httpOp.Operation != "GET /api/v1/player/info" || httpOp.Errors != 0 || httpOp.Codes != nil {
+		t.Errorf("HTTP operation = %+v, want one success", httpOp)
+	}
+	if wsOp.Count != 100 || wsOp.Errors != 10 || wsOp.ErrorRate != 0.1 || wsOp.Codes["RATE_LIMITED"] != 10 {
+		t.Errorf("WebSocket operation = %d ops, %d errors (%v), codes %v, want 100, 10 (0.1) RATE_LIMITED",
+			wsOp.Count, wsOp.Errors, wsOp.ErrorRate, wsOp.Codes)
+	}
+	if wsOp.P50 != 50*time.Millisecond || wsOp.P90 != 90*time.Millisecond || wsOp.P99 != 99*time.Millisecond || wsOp.Max != 100*time.Millisecond {
+		t.Errorf("latencies p50 %s p90 %s p99 %s max %s, want 50ms 90ms 99ms 100ms", wsOp.P50, wsOp.P90, wsOp.P99, wsOp.Max)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// codeOK is recorded for operations that succeeded
+const codeOK = "OK"
+
+// Stats collects the outcome and latency of every operation, keyed by
+// message type for WebSocket requests and by route for HTTP requests
+type Stats struct {
+	mu      sync.Mutex
+	started time.Time
+	ops     map[string]*opStats
+}
+
+type opStats struct {
+	latencies []time.Duration
+	codes     map[string]int
+}
+
+func NewStats() *Stats {
+	return &Stats{
+		started: time.Now(),
+		ops:     make(map[string]*opStats),
+	}
+}
+
+// Record adds one operation; code is codeOK or the error code it failed with
+func (s *Stats) Record(op string, latency time.Duration, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.ops[op]
+	if !ok {
+		o = &opStats{codes: make(map[string]int)}
+		s.ops[op] = o
+	}
+	o.latencies = append(o.latencies, latency)
+	o.codes[code]++
+}
+
+// OpReport summarizes one operation
+type OpReport struct {
+	Operation  string         `json:"operation"`
+	Count      int            `json:"count"`
+	Errors     int            `json:"errors"`
+	ErrorRate  float64        `json:"error_rate"`
+	Throughput float64        `json:"throughput"` // per second
+	P50        time.Duration  `json:"-"`
+	P90        time.Duration  `json:"-"`
+	P99        time.Duration  `json:"-"`
+	Max        time.Duration  `json:"-"`
+	Codes      map[string]int `json:"codes,omitempty"`
+}
+
+// Report summarizes everything recorded since the stats were created
+type Report struct {
+	Elapsed    time.Duration
+	Operations []OpReport
+}
+
+func (s *Stats) Report() Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := time.Since(s.started)
+	report := Report{Elapsed: elapsed}
+
+	for _, op := range slices.Sorted(maps.Keys(s.ops)) {
+		o := s.ops[op]
+		latencies := slices.Clone(o.latencies)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+		count := len(latencies)
+		errors := count - o.codes[codeOK]
+		r := OpReport{
+			Operation:  op,
+			Count:      count,
+			Errors:     errors,
+			ErrorRate:  float64(errors) / float64(count),
+			Throughput: float64(count) / elapsed.Seconds(),
+			P50:        percentile(latencies, 0.50),
+			P90:        percentile(latencies, 0.90),
+			P99:        percentile(latencies, 0.99),
+			Max:        latencies[count-1],
+		}
+		if errors > 0 {
+			r.Codes = make(map[string]int)
+			for code, n := range o.codes {
+				if code != codeOK {
+					r.Codes[code] = n
+				}
+			}
+		}
+		report.Operations = append(report.Operations, r)
+	}
+
+	return report
+}
+
+// percentile of sorted, by the nearest-rank method
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(float64(len(sorted))*p+0.5) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// Print writes the report as a table
+func (r Report) Print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "operation\tcount\terrors\terror %\trate/s\tp50\tp90\tp99\tmax\t")
+	for _, op := range r.Operations {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f\t%.1f\t%s\t%s\t%s\t%s\t\n",
+			op.Operation, op.Count, op.Errors, op.ErrorRate*100, op.Throughput,
+			round(op.P50), round(op.P90), round(op.P99), round(op.Max))
+	}
+	tw.Flush()
+
+	for _, op := range r.Operations {
+		for _, code := range slices.Sorted(maps.Keys(op.Codes)) {
+			fmt.Fprintf(w, "  %s: %d x %s\n", op.Operation, op.Codes[code], code)
+		}
+	}
+	fmt.Fprintf(w, "elapsed %s\n", round(r.Elapsed))
+}
+
+// WriteJSON writes the report as JSON with latencies in milliseconds
+func (r Report) WriteJSON(w io.Writer) error {
+	type opJSON struct {
+		OpReport
+		P50Ms float64 `json:"p50_ms"`
+		P90Ms float64 `json:"p90_ms"`
+		P99Ms float64 `json:"p99_ms"`
+		MaxMs float64 `json:"max_ms"`
+	}
+	out := struct {
+		ElapsedSeconds float64  `json:"elapsed_seconds"`
+		Operations     []opJSON `json:"operations"`
+	}{ElapsedSeconds: r.Elapsed.Seconds()}
+
+	for _, op := range r.Operations {
+		out.Operations = append(out.Operations, opJSON{
+			OpReport: op,
+			P50Ms:    ms(op.P50),
+			P90Ms:    ms(op.P90),
+			P99Ms:    ms(op.P99),
+			MaxMs:    ms(op.Max),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
@@ -0,0 +1,47 @@
+// Command openapi-check compares the OpenAPI document of the api-service with
+// its gin routes, request DTOs and protobuf messages, and exits with status
+// 1 when they have drifted apart. make build runs it before compiling.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	httptransport "github.com/Richard-inter/game/internal/transport/http"
+	dto "github.com/Richard-inter/game/internal/transport/http/DTO"
+	"github.com/Richard-inter/game/internal/transport/http/openapi"
+)
+
+// requests lists every request body DTO of the hand-written handlers; each
+// must be documented as the request body schema of the same name. Routes
+// served by the gateway document their protobuf request message instead.
+var requests = []any{
+	dto.CreatePlayerRequest{},
+	dto.CreateClawPlayerRequest{},
+	dto.CreatePromotionRequest{},
+	dto.CreateVouchersRequest{},
+}
+
+func main() {
+	gin.SetMode(gin.ReleaseMode)
+
+	doc, err := openapi.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "openapi-check:", err)
+		os.Exit(2)
+	}
+
+	problems := openapi.Check(doc, httptransport.Routes(), requests)
+	if len(problems) == 0 {
+		fmt.Println("openapi-check: the OpenAPI document matches the api-service")
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "openapi-check: the OpenAPI document has drifted from the api-service (%d problems):\n", len(problems))
+	for _, problem := range problems {
+		fmt.Fprintln(os.Stderr, "  "+problem)
+	}
+	os.Exit(1)
+}
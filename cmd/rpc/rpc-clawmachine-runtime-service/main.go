@@ -11,11 +11,15 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/Richard-inter/game/internal/auth"
 	"github.com/Richard-inter/game/internal/cache"
 	"github.com/Richard-inter/game/internal/config"
 	"github.com/Richard-inter/game/internal/db"
+	"github.com/Richard-inter/game/internal/entitlement"
+	"github.com/Richard-inter/game/internal/events"
 	"github.com/Richard-inter/game/internal/repository"
 	c "github.com/Richard-inter/game/internal/service/rpc/clawMachine_runtime"
+	"github.com/Richard-inter/game/pkg/errcode"
 	"github.com/Richard-inter/game/pkg/logger"
 	pb "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket"
 )
@@ -65,7 +69,11 @@ func main() {
 		log.Fatalw("Failed to listen", "error", err)
 	}
 
-	s := grpc.NewServer()
+	// Every runtime call acts for the player authenticated by the gateway
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		errcode.UnaryServerInterceptor(log),
+		auth.UnaryServerInterceptor(),
+	))
 
 	// Initialize and register claw machine runtime service
 	clawMachineRepo := repository.NewClawMachineRepository(database)
@@ -73,9 +81,30 @@ func main() {
 	// Initialize Redis client
 	redisClient := cache.NewRedisClient(cfg.GetRedisAddr(), cfg.GetRedisPassword())
 
-	runtimeService := c.NewClawMachineWebsocketService(clawMachineRepo, redisClient)
+	// Initialize free-play entitlement policy
+	entitlementPolicy, err := entitlement.NewPolicy(cfg.Entitlement)
+	if err != nil {
+		log.Fatalw("Failed to initialize entitlement policy", "error", err)
+	}
+
+	runtimeService := c.NewClawMachineWebsocketService(clawMachineRepo, redisClient, entitlementPolicy, log)
 	pb.RegisterClawMachineRuntimeServiceServer(s, runtimeService)
 
+	// Relay domain events from the outbox to the event stream
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+
+	if cfg.Events.RelayEnabled {
+		relay := events.NewRelay(repository.NewOutboxRepository(database), redisClient, log, events.RelayConfig{
+			Stream:    cfg.Events.Stream,
+			MaxLen:    cfg.Events.StreamMaxLen,
+			Interval:  time.Duration(cfg.Events.RelayIntervalMs) * time.Millisecond,
+			BatchSize: cfg.Events.RelayBatchSize,
+			Retention: time.Duration(cfg.Events.RetentionHours) * time.Hour,
+		})
+		go relay.Run(relayCtx)
+	}
+
 	// Enable reflection for development
 	reflection.Register(s)
 
@@ -94,6 +123,7 @@ func main() {
 	<-quit
 
 	log.Infow("Shutting down ClawMachine Runtime service...")
+	stopRelay()
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
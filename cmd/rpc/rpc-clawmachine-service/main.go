@@ -14,8 +14,11 @@ import (
 	"github.com/Richard-inter/game/internal/cache"
 	"github.com/Richard-inter/game/internal/config"
 	"github.com/Richard-inter/game/internal/db"
+	"github.com/Richard-inter/game/internal/entitlement"
+	"github.com/Richard-inter/game/internal/events"
 	"github.com/Richard-inter/game/internal/repository"
 	c "github.com/Richard-inter/game/internal/service/rpc/clawMachine"
+	"github.com/Richard-inter/game/pkg/errcode"
 	"github.com/Richard-inter/game/pkg/logger"
 	clawMachine "github.com/Richard-inter/game/pkg/protocol/clawMachine"
 )
@@ -65,7 +68,7 @@ func main() {
 		log.Fatalw("Failed to listen", "error", err)
 	}
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(grpc.UnaryInterceptor(errcode.UnaryServerInterceptor(log)))
 
 	// Initialize and register claw machine service
 	clawMachineRepo := repository.NewClawMachineRepository(database)
@@ -73,9 +76,30 @@ func main() {
 	// Initialize Redis client
 	redisClient := cache.NewRedisClient(cfg.GetRedisAddr(), cfg.GetRedisPassword())
 
-	clawMachineService := c.NewClawMachineGRPCService(clawMachineRepo, redisClient)
+	// Initialize free-play entitlement policy
+	entitlementPolicy, err := entitlement.NewPolicy(cfg.Entitlement)
+	if err != nil {
+		log.Fatalw("Failed to initialize entitlement policy", "error", err)
+	}
+
+	clawMachineService := c.NewClawMachineGRPCService(clawMachineRepo, redisClient, entitlementPolicy)
 	clawMachine.RegisterClawMachineServiceServer(s, clawMachineService)
 
+	// Relay domain events from the outbox to the event stream
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+
+	if cfg.Events.RelayEnabled {
+		relay := events.NewRelay(repository.NewOutboxRepository(database), redisClient, log, events.RelayConfig{
+			Stream:    cfg.Events.Stream,
+			MaxLen:    cfg.Events.StreamMaxLen,
+			Interval:  time.Duration(cfg.Events.RelayIntervalMs) * time.Millisecond,
+			BatchSize: cfg.Events.RelayBatchSize,
+			Retention: time.Duration(cfg.Events.RetentionHours) * time.Hour,
+		})
+		go relay.Run(relayCtx)
+	}
+
 	// Enable reflection for development
 	reflection.Register(s)
 
@@ -94,6 +118,7 @@ func main() {
 	<-quit
 
 	log.Infow("Shutting down ClawMachine service...")
+	stopRelay()
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
@@ -11,12 +11,14 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/Richard-inter/game/internal/auth"
 	"github.com/Richard-inter/game/internal/config"
 	"github.com/Richard-inter/game/internal/db"
 	"github.com/Richard-inter/game/internal/discovery"
 	"github.com/Richard-inter/game/internal/registry"
 	"github.com/Richard-inter/game/internal/repository"
 	p "github.com/Richard-inter/game/internal/service/rpc/player"
+	"github.com/Richard-inter/game/pkg/errcode"
 	"github.com/Richard-inter/game/pkg/logger"
 	player "github.com/Richard-inter/game/pkg/protocol/player"
 )
@@ -66,11 +68,25 @@ func main() {
 		log.Fatalw("Failed to listen", "error", err)
 	}
 
-	s := grpc.NewServer()
+	authenticator, err := auth.NewAuthenticator(cfg.JWT)
+	if err != nil {
+		log.Fatalw("Failed to create authenticator", "error", err)
+	}
+
+	// IssueToken mints tokens for any player, so only callers holding the
+	// service token may use it; without one it is refused to everyone
+	if cfg.GRPC.ServiceToken == "" {
+		log.Warnw("grpc.service_token is not set, IssueToken is disabled")
+	}
+
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		errcode.UnaryServerInterceptor(log),
+		auth.ServiceMethodsUnaryServerInterceptor(cfg.GRPC.ServiceToken, player.PlayerService_IssueToken_FullMethodName),
+	))
 
 	// Initialize and register player service
 	playerRepo := repository.NewPlayerRepository(database)
-	playerService := p.NewPlayerGRPCService(playerRepo)
+	playerService := p.NewPlayerGRPCService(playerRepo, authenticator)
 	player.RegisterPlayerServiceServer(s, playerService)
 
 	// Enable reflection for development
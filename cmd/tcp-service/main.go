@@ -1,18 +1,21 @@
 package main
 
 import (
-	"bufio"
 	"context"
-	"fmt"
-	"net"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/Richard-inter/game/internal/auth"
 	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/internal/transport/grpc"
+	tcptransport "github.com/Richard-inter/game/internal/transport/tcp"
 	"github.com/Richard-inter/game/pkg/logger"
-	"go.uber.org/zap"
+)
+
+const (
+	shutdownTimeout = 5 * time.Second
 )
 
 var (
@@ -44,104 +47,56 @@ func main() {
 		log.Fatalw("Failed to load configuration", "error", err)
 	}
 
-	// Create listener
-	lc := net.ListenConfig{}
-	listener, err := lc.Listen(context.Background(), "tcp", cfg.GetServiceAddr())
+	// Create gRPC client manager with service discovery
+	var etcdEndpoints []string
+	runtimeAddr := "localhost:9092" // Clawmachine runtime service direct address
+
+	if cfg.Discovery.Enabled && len(cfg.Discovery.Etcd.Endpoints) > 0 {
+		etcdEndpoints = cfg.Discovery.Etcd.Endpoints
+		log.Infow("Using etcd endpoints from config", "endpoints", etcdEndpoints)
+	} else {
+		log.Infow("Service discovery disabled, using direct gRPC connections")
+	}
+
+	grpcManager, err := grpc.NewClientManager(&grpc.ClientManagerConfig{
+		EtcdEndpoints: etcdEndpoints,
+		RuntimeAddr:   runtimeAddr,
+	})
 	if err != nil {
-		log.Fatalw("Failed to listen on TCP port", "error", err)
+		log.Fatalw("Failed to create gRPC client manager", "error", err)
 	}
-	defer listener.Close()
+	defer grpcManager.Close()
 
-	log.Infow("TCP Service starting", "address", listener.Addr().String())
+	// Players authenticate with a JWT in their HelloReq
+	authenticator, err := auth.NewAuthenticator(cfg.JWT)
+	if err != nil {
+		log.Fatalw("Failed to initialize authenticator", "error", err)
+	}
 
-	// Channel to signal shutdown
-	quit := make(chan struct{})
+	server := tcptransport.NewServer(cfg, log, grpcManager, authenticator)
+	server.SetBuild(Version)
 
-	// Start connection handler
+	// Start server in a goroutine
 	go func() {
-		for {
-			conn, err := listener.Accept()
-			if err != nil {
-				select {
-				case <-quit:
-					return // Shutdown in progress
-				default:
-					log.Errorw("TCP accept error", "error", err)
-					continue
-				}
-			}
-
-			go handleConnection(conn, cfg, log)
+		if err := server.Start(); err != nil {
+			log.Fatalw("Failed to start TCP service", "error", err)
 		}
 	}()
 
 	// Wait for interrupt signal
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	<-sigChan
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
 
 	log.Infow("Shutting down TCP Service...")
 
-	// Signal shutdown
-	close(quit)
-	listener.Close()
-
-	// Give connections time to close gracefully
-	time.Sleep(2 * time.Second)
+	// Graceful shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
-	log.Infow("TCP Service stopped")
-}
-
-func handleConnection(conn net.Conn, cfg *config.ServiceConfig, log *zap.SugaredLogger) {
-	defer conn.Close()
-
-	clientAddr := conn.RemoteAddr().String()
-	log.Infow("TCP client connected", "client", clientAddr)
-
-	// Set read/write timeouts
-	if cfg.TCP.ReadTimeout > 0 {
-		if err := conn.SetReadDeadline(time.Now().Add(time.Duration(cfg.TCP.ReadTimeout) * time.Second)); err != nil {
-			log.Errorw("Failed to set read deadline", "error", err)
-		}
-	}
-	if cfg.TCP.WriteTimeout > 0 {
-		if err := conn.SetWriteDeadline(time.Now().Add(time.Duration(cfg.TCP.WriteTimeout) * time.Second)); err != nil {
-			log.Errorw("Failed to set write deadline", "error", err)
-		}
-	}
-
-	scanner := bufio.NewScanner(conn)
-	for scanner.Scan() {
-		message := scanner.Bytes()
-
-		log.Debugw("Received TCP message", "client", clientAddr, "message", string(message))
-
-		// Process message (placeholder)
-		response := processMessage(message, clientAddr, log)
-
-		// Send response
-		if _, err := conn.Write(response); err != nil {
-			log.Errorw("Failed to send TCP response", "error", err)
-			break
-		}
-
-		// Reset read deadline
-		if cfg.TCP.ReadTimeout > 0 {
-			if err := conn.SetReadDeadline(time.Now().Add(time.Duration(cfg.TCP.ReadTimeout) * time.Second)); err != nil {
-				log.Errorw("Failed to reset read deadline", "error", err)
-			}
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("TCP client error", "error", err, "client", clientAddr)
+	if err := server.Shutdown(ctx); err != nil {
+		log.Errorw("TCP service shutdown error", "error", err)
 	}
 
-	log.Infow("TCP client disconnected", "client", clientAddr)
-}
-
-func processMessage(message []byte, clientAddr string, _ *zap.SugaredLogger) []byte {
-	// Simple echo server with prefix
-	response := fmt.Sprintf("TCP Service Echo [%s]: %s", clientAddr, string(message))
-	return []byte(response)
+	log.Infow("TCP Service stopped")
 }
@@ -2,19 +2,23 @@ package main
 
 import (
 	"context"
-	"fmt"
-	"net/http"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	grpcserver "google.golang.org/grpc"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/internal/cache"
 	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/internal/events"
+	"github.com/Richard-inter/game/internal/gateway"
 	"github.com/Richard-inter/game/internal/transport/grpc"
-	wshandler "github.com/Richard-inter/game/internal/transport/websocket"
+	wstransport "github.com/Richard-inter/game/internal/transport/websocket"
 	"github.com/Richard-inter/game/pkg/logger"
-	"github.com/gorilla/websocket"
-	"go.uber.org/zap"
+	gatewaypb "github.com/Richard-inter/game/pkg/protocol/gateway"
 )
 
 const (
@@ -50,40 +54,82 @@ func main() {
 		log.Fatalw("Failed to load configuration", "error", err)
 	}
 
-	// WebSocket upgrader
-	upgrader := websocket.Upgrader{
-		ReadBufferSize:  cfg.WebSocket.ReadBufferSize,
-		WriteBufferSize: cfg.WebSocket.WriteBufferSize,
-		CheckOrigin: func(_ *http.Request) bool {
-			return true // Allow all origins for now
-		},
+	// Create gRPC client manager with service discovery
+	var etcdEndpoints []string
+	playerAddr := "localhost:9094"      // Player service direct address
+	clawmachineAddr := "localhost:9091" // Clawmachine service direct address
+	runtimeAddr := "localhost:9092"     // Clawmachine runtime service direct address
+
+	if cfg.Discovery.Enabled && len(cfg.Discovery.Etcd.Endpoints) > 0 {
+		etcdEndpoints = cfg.Discovery.Etcd.Endpoints
+		log.Infow("Using etcd endpoints from config", "endpoints", etcdEndpoints)
+	} else {
+		log.Infow("Service discovery disabled, using direct gRPC connections")
 	}
 
-	// Create HTTP server
-	mux := http.NewServeMux()
-	mux.HandleFunc(cfg.Service.Path, func(w http.ResponseWriter, r *http.Request) {
-		handleWebSocket(upgrader, w, r, log)
+	grpcManager, err := grpc.NewClientManager(&grpc.ClientManagerConfig{
+		EtcdEndpoints:   etcdEndpoints,
+		PlayerAddr:      playerAddr,
+		ClawmachineAddr: clawmachineAddr,
+		RuntimeAddr:     runtimeAddr,
 	})
+	if err != nil {
+		log.Fatalw("Failed to create gRPC client manager", "error", err)
+	}
+	defer grpcManager.Close()
 
-	// Add health check
-	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"status":"healthy","service":"websocket-service"}`)
-	})
+	// Players authenticate with a JWT on upgrade
+	authenticator, err := auth.NewAuthenticator(cfg.JWT)
+	if err != nil {
+		log.Fatalw("Failed to initialize authenticator", "error", err)
+	}
+
+	redisClient := cache.NewRedisClient(cfg.GetRedisAddr(), cfg.GetRedisPassword())
+
+	server := wstransport.NewServer(cfg, log, grpcManager, authenticator, redisClient)
+	server.SetBuild(Version)
+	pushService := gateway.NewPushService(server.Sessions(), log)
 
-	server := &http.Server{
-		Addr:         cfg.GetServiceAddr(),
-		Handler:      mux,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+	// Push API for other services, which can reach any player, so only
+	// callers holding the service token may use it
+	if cfg.GRPC.ServiceToken == "" {
+		log.Fatalw("Gateway push API needs grpc.service_token to be set")
 	}
 
-	// Start server in a goroutine
+	lc := net.ListenConfig{}
+	lis, err := lc.Listen(context.Background(), "tcp", cfg.GetGRPCAddr())
+	if err != nil {
+		log.Fatalw("Failed to listen", "error", err)
+	}
+
+	grpcServer := grpcserver.NewServer(grpcserver.UnaryInterceptor(auth.ServiceUnaryServerInterceptor(cfg.GRPC.ServiceToken)))
+	gatewaypb.RegisterGatewayServiceServer(grpcServer, pushService)
+
 	go func() {
-		log.Infow("WebSocket Service starting", "address", server.Addr, "path", cfg.WebSocket.Path)
+		log.Infow("Gateway push gRPC server starting", "address", lis.Addr().String())
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalw("Failed to serve gateway push API", "error", err)
+		}
+	}()
 
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	// Push domain events to connected players
+	subscriberCtx, stopSubscriber := context.WithCancel(context.Background())
+	defer stopSubscriber()
+
+	// every gateway needs every event, since players may be on any of them
+	subscriber := events.NewSubscriber(redisClient, log, events.SubscriberConfig{
+		Stream: cfg.Events.Stream,
+	}, pushService.HandleEvent)
+
+	go func() {
+		if err := subscriber.Run(subscriberCtx); err != nil {
+			log.Errorw("Event subscriber stopped", "error", err)
+		}
+	}()
+
+	// Start server in a goroutine
+	go func() {
+		if err := server.Start(); err != nil {
 			log.Fatalw("Failed to start WebSocket service", "error", err)
 		}
 	}()
@@ -94,6 +140,8 @@ func main() {
 	<-quit
 
 	log.Infow("Shutting down WebSocket Service...")
+	stopSubscriber()
+	grpcServer.GracefulStop()
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
@@ -105,38 +153,3 @@ func main() {
 
 	log.Infow("WebSocket Service stopped")
 }
-
-func handleWebSocket(upgrader websocket.Upgrader, w http.ResponseWriter, r *http.Request, log *zap.SugaredLogger) {
-	// Upgrade HTTP connection to WebSocket
-	conn, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Errorw("Failed to upgrade WebSocket connection", "error", err)
-		return
-	}
-
-	// Initialize gRPC client manager
-	grpcConfig := &grpc.ClientManagerConfig{
-		EtcdEndpoints:   []string{}, // Empty to disable etcd
-		PlayerAddr:      "localhost:9094",
-		ClawmachineAddr: "localhost:9091",
-		RuntimeAddr:     "localhost:9092",
-	}
-
-	grpcManager, err := grpc.NewClientManager(grpcConfig)
-	if err != nil {
-		log.Errorw("Failed to create gRPC client manager", "error", err)
-		return
-	}
-
-	log.Infow("gRPC client manager created successfully")
-
-	// Create WebSocket handler
-	handler, err := wshandler.NewWebSocketHandler(log, grpcManager)
-	if err != nil {
-		log.Errorw("Failed to create WebSocket handler", "error", err)
-		return
-	}
-
-	// Handle the connection
-	handler.HandleConnection(conn)
-}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/Richard-inter/game/internal/gateway"
+	wstransport "github.com/Richard-inter/game/internal/transport/websocket"
+)
+
+const maxDescribeLength = 200
+
+// jsonCodec renders Envelopes through the gateway's JSON codec, so payloads
+// are compared field by field under their schema names
+var jsonCodec, _ = wstransport.CodecByName(wstransport.CodecJSON)
+
+// differ compares captured and replayed responses. Ignored fields are left
+// out everywhere they appear. Bound fields hold IDs the server assigns, such
+// as game IDs: the first captured value is mapped to the replayed one, and
+// later requests carrying the captured value are rewritten to the new one.
+type differ struct {
+	ignore   []string
+	bind     []string
+	bindings map[string]map[string]any
+}
+
+func newDiffer(ignore, bind []string) *differ {
+	return &differ{
+		ignore:   ignore,
+		bind:     bind,
+		bindings: make(map[string]map[string]any),
+	}
+}
+
+// compare returns one line per difference between want and got
+func (d *differ) compare(want, got []byte) []string {
+	wantValue, err := toJSON(want)
+	if err != nil {
+		return []string{fmt.Sprintf("cannot decode captured response: %v", err)}
+	}
+	gotValue, err := toJSON(got)
+	if err != nil {
+		return []string{fmt.Sprintf("cannot decode response: %v", err)}
+	}
+
+	var diffs []string
+	d.walk("", "", wantValue, gotValue, &diffs)
+	return diffs
+}
+
+func (d *differ) walk(path, field string, want, got any, diffs *[]string) {
+	if slices.Contains(d.ignore, field) {
+		return
+	}
+
+	if slices.Contains(d.bind, field) && want != nil && got != nil {
+		key := fmt.Sprint(want)
+		bound, ok := d.bindings[field][key]
+		if !ok {
+			if d.bindings[field] == nil {
+				d.bindings[field] = make(map[string]any)
+			}
+			d.bindings[field][key] = got
+			return
+		}
+		want = bound
+	}
+
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(w)+len(g))
+		for k := range w {
+			keys = append(keys, k)
+		}
+		for k := range g {
+			if _, ok := w[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			d.walk(join(path, k), k, w[k], g[k], diffs)
+		}
+		return
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			break
+		}
+		if len(w) != len(g) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: want %d elements, got %d", path, len(w), len(g)))
+			return
+		}
+		for i := range w {
+			d.walk(fmt.Sprintf("%s[%d]", path, i), field, w[i], g[i], diffs)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: want %s, got %s", path, render(want), render(got)))
+	}
+}
+
+// rewrite replaces captured values of bound fields in a request with the
+// values the replay was given. Requests without such values are sent as
+// captured.
+func (d *differ) rewrite(envelope []byte) ([]byte, error) {
+	// malformed frames are replayed exactly as they were captured
+	if len(d.bindings) == 0 || gateway.ValidateEnvelope(envelope) != nil {
+		return envelope, nil
+	}
+
+	value, err := toJSON(envelope)
+	if err != nil {
+		return nil, err
+	}
+	if !d.substitute(value) {
+		return envelope, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return jsonCodec.Decode(websocket.TextMessage, data)
+}
+
+func (d *differ) substitute(value any) bool {
+	changed := false
+	switch v := value.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if bound, ok := d.bindings[k][fmt.Sprint(child)]; ok && slices.Contains(d.bind, k) {
+				v[k] = bound
+				changed = true
+				continue
+			}
+			changed = d.substitute(child) || changed
+		}
+	case []any:
+		for _, child := range v {
+			changed = d.substitute(child) || changed
+		}
+	}
+	return changed
+}
+
+func toJSON(envelope []byte) (any, error) {
+	_, data, err := jsonCodec.Encode(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// describe renders an Envelope for messages
+func describe(envelope []byte) string {
+	_, data, err := jsonCodec.Encode(envelope)
+	if err != nil {
+		return fmt.Sprintf("undecodable envelope (%v)", err)
+	}
+	if len(data) > maxDescribeLength {
+		return string(data[:maxDescribeLength]) + "..."
+	}
+	return string(data)
+}
+
+func render(v any) string {
+	if v == nil {
+		return "nothing"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// report collects the differences found by a replay
+type report struct {
+	requests    int
+	unsolicited int
+	diffs       []string
+}
+
+func (r *report) add(requestID uint64, msgType, diff string) {
+	r.diffs = append(r.diffs, fmt.Sprintf("request %d (%s): %s", requestID, msgType, diff))
+}
+
+func (r *report) ok() bool {
+	return len(r.diffs) == 0
+}
+
+func (r *report) print(w io.Writer) {
+	for _, diff := range r.diffs {
+		fmt.Fprintln(w, diff)
+	}
+	fmt.Fprintf(w, "replayed %d requests: %d differences, %d pushes skipped\n", r.requests, len(r.diffs), r.unsolicited)
+}
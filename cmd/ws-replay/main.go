@@ -0,0 +1,174 @@
+// Command ws-replay replays a gateway session capture against a WebSocket
+// gateway and reports where the responses differ from the captured ones, so
+// captures double as regression fixtures. It exits with status 1 when they
+// differ.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/internal/gateway"
+	"github.com/Richard-inter/game/internal/transport/grpc"
+	wstransport "github.com/Richard-inter/game/internal/transport/websocket"
+	"github.com/Richard-inter/game/pkg/logger"
+)
+
+func main() {
+	var (
+		capturePath = flag.String("capture", "", "capture file to replay")
+		url         = flag.String("url", "ws://localhost:8081/ws", "WebSocket gateway to replay against")
+		configPath  = flag.String("config", "config/websocket-service.yaml", "gateway config, used to mint tokens and for -inprocess")
+		token       = flag.String("token", "", "JWT to connect with; minted for the captured player when empty")
+		inProcess   = flag.Bool("inprocess", false, "start the WebSocket gateway from -config in this process instead of dialing -url")
+		runtimeAddr = flag.String("runtime", "localhost:9092", "clawmachine runtime service used by -inprocess")
+		ignore      = flag.String("ignore", "retry_after_ms", "comma-separated payload fields left out of the comparison")
+		bind        = flag.String("bind", "game_id", "comma-separated fields holding server-assigned IDs, mapped from the capture to the replay")
+		timeout     = flag.Duration("timeout", 5*time.Second, "how long to wait for each response")
+		pace        = flag.Bool("pace", false, "keep the captured gaps between requests")
+	)
+	flag.Parse()
+
+	if *capturePath == "" {
+		fmt.Fprintln(os.Stderr, "ws-replay: -capture is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	capture, err := gateway.ReadCapture(*capturePath)
+	if err != nil {
+		fail(err)
+	}
+
+	cfg, err := config.LoadServiceConfigFromPath(*configPath)
+	if err != nil {
+		fail(fmt.Errorf("failed to load config: %w", err))
+	}
+
+	if *token == "" {
+		authenticator, err := auth.NewAuthenticator(cfg.JWT)
+		if err != nil {
+			fail(fmt.Errorf("failed to initialize authenticator: %w", err))
+		}
+		*token, err = authenticator.IssueToken(capture.Header.PlayerID)
+		if err != nil {
+			fail(fmt.Errorf("failed to issue token: %w", err))
+		}
+	}
+
+	if *inProcess {
+		stop, addr, err := startGateway(cfg, *runtimeAddr)
+		if err != nil {
+			fail(err)
+		}
+		defer stop()
+		*url = "ws://" + addr + cfg.WebSocket.Path
+	}
+
+	r := &replayer{
+		url:     *url,
+		token:   *token,
+		timeout: *timeout,
+		pace:    *pace,
+		differ:  newDiffer(splitList(*ignore), splitList(*bind)),
+	}
+
+	report, err := r.run(capture)
+	if err != nil {
+		fail(err)
+	}
+
+	report.print(os.Stdout)
+	if !report.ok() {
+		os.Exit(1)
+	}
+}
+
+// startGateway serves the WebSocket gateway on a free loopback port. It runs
+// without Redis, so resume and shared rate limits are off and replays do not
+// depend on state left by earlier runs.
+func startGateway(cfg *config.ServiceConfig, runtimeAddr string) (func(), string, error) {
+	logger.InitLogger()
+	log := logger.GetSugar()
+
+	lc := net.ListenConfig{}
+	lis, err := lc.Listen(context.Background(), "tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find a free port: %w", err)
+	}
+	addr := lis.Addr().(*net.TCPAddr)
+	lis.Close()
+
+	cfg.WebSocket.Host = "127.0.0.1"
+	cfg.WebSocket.Port = addr.Port
+	cfg.WebSocket.ResumeGrace = 0
+	cfg.WebSocket.Capture.Enabled = false
+
+	grpcManager, err := grpc.NewClientManager(&grpc.ClientManagerConfig{RuntimeAddr: runtimeAddr})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create gRPC client manager: %w", err)
+	}
+
+	authenticator, err := auth.NewAuthenticator(cfg.JWT)
+	if err != nil {
+		grpcManager.Close()
+		return nil, "", fmt.Errorf("failed to initialize authenticator: %w", err)
+	}
+
+	server := wstransport.NewServer(cfg, log, grpcManager, authenticator, nil)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Start()
+	}()
+
+	// wait until the gateway accepts connections
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", addr.String(), 100*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		select {
+		case err := <-errCh:
+			grpcManager.Close()
+			return nil, "", fmt.Errorf("gateway failed to start: %w", err)
+		default:
+		}
+		if time.Now().After(deadline) {
+			grpcManager.Close()
+			return nil, "", fmt.Errorf("gateway did not start: %w", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	stop := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+		grpcManager.Close()
+	}
+	return stop, addr.String(), nil
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "ws-replay:", err)
+	os.Exit(2)
+}
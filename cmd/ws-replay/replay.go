@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/gorilla/websocket"
+
+	"github.com/Richard-inter/game/internal/gateway"
+	wstransport "github.com/Richard-inter/game/internal/transport/websocket"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// replayer sends the client side of a capture over a fresh WebSocket
+// session, one request at a time, and compares each response with the one
+// the capture holds for the same request ID
+type replayer struct {
+	url     string
+	token   string
+	timeout time.Duration
+	pace    bool
+	differ  *differ
+}
+
+// frame is one Envelope read from the gateway
+type frame struct {
+	envelope []byte
+	err      error
+}
+
+func (r *replayer) run(capture *gateway.Capture) (*report, error) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+r.token)
+	dialer := websocket.Dialer{
+		HandshakeTimeout: r.timeout,
+		Subprotocols:     []string{wstransport.CodecFlatBuffers},
+	}
+
+	conn, resp, err := dialer.Dial(r.url, header)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", r.url, err)
+	}
+	defer conn.Close()
+
+	if err := r.hello(conn, capture.Header); err != nil {
+		return nil, err
+	}
+
+	frames := make(chan frame, 64)
+	go read(conn, frames)
+
+	expected := make(map[uint64][]gateway.CaptureRecord)
+	for _, record := range capture.Records {
+		if record.Direction == gateway.CaptureOutbound && record.RequestID != 0 {
+			expected[record.RequestID] = append(expected[record.RequestID], record)
+		}
+	}
+
+	rep := &report{}
+	var last time.Time
+	for _, record := range capture.Records {
+		if record.Direction != gateway.CaptureInbound {
+			continue
+		}
+
+		if r.pace && !last.IsZero() {
+			time.Sleep(record.Time.Sub(last))
+		}
+		last = record.Time
+
+		request, err := r.differ.rewrite(record.Envelope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewrite request %d: %w", record.RequestID, err)
+		}
+		if err := conn.WriteMessage(websocket.BinaryMessage, request); err != nil {
+			return nil, fmt.Errorf("failed to send request %d: %w", record.RequestID, err)
+		}
+		rep.requests++
+
+		for _, want := range expected[record.RequestID] {
+			got, err := r.await(frames, record.RequestID, rep)
+			if err != nil {
+				rep.add(record.RequestID, want.Type, err.Error())
+				continue
+			}
+			for _, diff := range r.differ.compare(want.Envelope, got) {
+				rep.add(record.RequestID, want.Type, diff)
+			}
+		}
+	}
+
+	return rep, nil
+}
+
+// hello opens the session with the protocol version and features the
+// captured client used
+func (r *replayer) hello(conn *websocket.Conn, header gateway.CaptureHeader) error {
+	builder := flatbuffers.NewBuilder(256)
+	features := make([]flatbuffers.UOffsetT, len(header.Features))
+	for i, feature := range header.Features {
+		features[i] = builder.CreateString(feature)
+	}
+	fbs.HelloReqStartFeaturesVector(builder, len(features))
+	for i := len(features) - 1; i >= 0; i-- {
+		builder.PrependUOffsetT(features[i])
+	}
+	featureVector := builder.EndVector(len(features))
+	build := builder.CreateString(header.ClientBuild)
+	token := builder.CreateString(r.token)
+
+	fbs.HelloReqStart(builder)
+	fbs.HelloReqAddProtocolVersion(builder, header.ProtocolVersion)
+	fbs.HelloReqAddClientBuild(builder, build)
+	fbs.HelloReqAddFeatures(builder, featureVector)
+	fbs.HelloReqAddToken(builder, token)
+	builder.Finish(fbs.HelloReqEnd(builder))
+
+	hello := gateway.BuildEnvelope(fbs.EnvelopeKindRequest, fbs.MessageTypeHelloReq, 0, builder.FinishedBytes())
+	if err := conn.WriteMessage(websocket.BinaryMessage, hello); err != nil {
+		return fmt.Errorf("failed to send hello: %w", err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(r.timeout))
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return fmt.Errorf("failed to read welcome: %w", err)
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+
+	if len(data) < flatbuffers.SizeUOffsetT {
+		return errors.New("gateway sent an empty welcome")
+	}
+	envelope := fbs.GetRootAsEnvelope(data, 0)
+	if envelope.Type() != fbs.MessageTypeWelcomeResp {
+		return fmt.Errorf("gateway rejected the session: %s", describe(data))
+	}
+	return nil
+}
+
+// await returns the next response to requestID. Frames the gateway sent on
+// its own, such as pushes, are counted and skipped; responses to other
+// requests are reported as unexpected.
+func (r *replayer) await(frames <-chan frame, requestID uint64, rep *report) ([]byte, error) {
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case f, ok := <-frames:
+			if !ok {
+				return nil, errors.New("connection closed")
+			}
+			if f.err != nil {
+				return nil, fmt.Errorf("connection lost: %w", f.err)
+			}
+			envelope := fbs.GetRootAsEnvelope(f.envelope, 0)
+			switch id := envelope.RequestId(); {
+			case id == requestID:
+				return f.envelope, nil
+			case id == 0:
+				rep.unsolicited++
+			default:
+				rep.add(id, envelope.Type().String(), "unexpected response: "+describe(f.envelope))
+			}
+		case <-timer.C:
+			return nil, fmt.Errorf("no response within %s", r.timeout)
+		}
+	}
+}
+
+func read(conn *websocket.Conn, frames chan<- frame) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err == nil && len(data) < flatbuffers.SizeUOffsetT {
+			err = errors.New("frame too short")
+		}
+		if err != nil {
+			frames <- frame{err: err}
+			close(frames)
+			return
+		}
+		frames <- frame{envelope: data}
+	}
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/Richard-inter/game/internal/config"
+)
+
+// TokenQueryParam lets browser clients, which cannot set headers on a
+// WebSocket upgrade, pass their token in the query string
+const TokenQueryParam = "token"
+
+var ErrMissingToken = errors.New("missing token")
+
+// Claims identifies the player a token was issued to. The player ID is stored
+// in the standard subject claim.
+type Claims struct {
+	jwt.RegisteredClaims
+}
+
+// PlayerID returns the player the token was issued to
+func (c *Claims) PlayerID() (int64, error) {
+	playerID, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil || playerID <= 0 {
+		return 0, fmt.Errorf("invalid token subject: %q", c.Subject)
+	}
+	return playerID, nil
+}
+
+// Authenticator issues and verifies HMAC signed player tokens
+type Authenticator struct {
+	secret     []byte
+	expiration time.Duration
+}
+
+func NewAuthenticator(cfg config.JWTConfig) (*Authenticator, error) {
+	if cfg.Secret == "" {
+		return nil, fmt.Errorf("jwt secret cannot be empty")
+	}
+
+	return &Authenticator{
+		secret:     []byte(cfg.Secret),
+		expiration: time.Duration(cfg.ExpirationTime) * time.Second,
+	}, nil
+}
+
+// IssueToken signs a token for playerID that expires after the configured
+// expiration time
+func (a *Authenticator) IssueToken(playerID int64) (string, error) {
+	token, _, err := a.IssueTokenWithExpiry(playerID)
+	return token, err
+}
+
+// IssueTokenWithExpiry is IssueToken that also returns when the token
+// expires, so clients know when to ask for a new one
+func (a *Authenticator) IssueTokenWithExpiry(playerID int64) (string, time.Time, error) {
+	now := time.Now()
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(playerID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(a.expiration)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(a.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, claims.ExpiresAt.Time, nil
+}
+
+// Authenticate verifies the token and returns the player it belongs to
+func (a *Authenticator) Authenticate(tokenString string) (int64, error) {
+	if tokenString == "" {
+		return 0, ErrMissingToken
+	}
+
+	var claims Claims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(_ *jwt.Token) (any, error) {
+		return a.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("invalid token: %w", err)
+	}
+
+	return claims.PlayerID()
+}
+
+// TokenFromRequest reads a bearer token from the Authorization header, falling
+// back to the token query parameter
+func TokenFromRequest(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return r.URL.Query().Get(TokenQueryParam)
+}
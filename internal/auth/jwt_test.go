@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/Richard-inter/game/internal/config"
+)
+
+const testSecret = "test-secret"
+
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	a, err := NewAuthenticator(config.JWTConfig{Secret: testSecret, ExpirationTime: 3600})
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	return a
+}
+
+// sign signs claims the way an external issuer would
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestAuthenticate(t *testing.T) {
+	now := time.Now()
+	valid := jwt.RegisteredClaims{
+		Subject:   "42",
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+	with := func(change func(*jwt.RegisteredClaims)) jwt.RegisteredClaims {
+		claims := valid
+		change(&claims)
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantID  int64 // 0 when the token is rejected
+		wantErr error // nil when any error will do
+	}{
+		{"valid", sign(t, jwt.SigningMethodHS256, []byte(testSecret), valid), 42, nil},
+		{"missing", "", 0, ErrMissingToken},
+		{"expired", sign(t, jwt.SigningMethodHS256, []byte(testSecret), with(func(c *jwt.RegisteredClaims) {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Second))
+		})), 0, jwt.ErrTokenExpired},
+		// a token that never expires is not accepted
+		{"no expiry", sign(t, jwt.SigningMethodHS256, []byte(testSecret), with(func(c *jwt.RegisteredClaims) {
+			c.ExpiresAt = nil
+		})), 0, jwt.ErrTokenRequiredClaimMissing},
+		{"not valid yet", sign(t, jwt.SigningMethodHS256, []byte(testSecret), with(func(c *jwt.RegisteredClaims) {
+			c.NotBefore = jwt.NewNumericDate(now.Add(time.Hour))
+		})), 0, jwt.ErrTokenNotValidYet},
+		{"wrong secret", sign(t, jwt.SigningMethodHS256, []byte("other-secret"), valid), 0, jwt.ErrTokenSignatureInvalid},
+		// only HS256 is accepted, whatever the token header claims
+		{"other algorithm", sign(t, jwt.SigningMethodHS512, []byte(testSecret), valid), 0, jwt.ErrTokenSignatureInvalid},
+		{"unsigned", sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid), 0, nil},
+		{"malformed", "not.a.token", 0, jwt.ErrTokenMalformed},
+		{"non numeric subject", sign(t, jwt.SigningMethodHS256, []byte(testSecret), with(func(c *jwt.RegisteredClaims) {
+			c.Subject = "alice"
+		})), 0, nil},
+		{"zero subject", sign(t, jwt.SigningMethodHS256, []byte(testSecret), with(func(c *jwt.RegisteredClaims) {
+			c.Subject = "0"
+		})), 0, nil},
+	}
+
+	a := newTestAuthenticator(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playerID, err := a.Authenticate(tt.token)
+			if tt.wantID != 0 {
+				if err != nil {
+					t.Fatalf("Authenticate() error = %v", err)
+				}
+				if playerID != tt.wantID {
+					t.Errorf("player ID = %d, want %d", playerID, tt.wantID)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Authenticate() accepted the token for player %d", playerID)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIssueToken(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	token, err := a.IssueToken(42)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+
+	playerID, err := a.Authenticate(token)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if playerID != 42 {
+		t.Errorf("player ID = %d, want 42", playerID)
+	}
+
+	var claims Claims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+	if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime != time.Hour {
+		t.Errorf("token lifetime = %s, want 1h", lifetime)
+	}
+}
+
+func TestIssueTokenWithExpiry(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	token, expiresAt, err := a.IssueTokenWithExpiry(42)
+	if err != nil {
+		t.Fatalf("IssueTokenWithExpiry() error = %v", err)
+	}
+
+	var claims Claims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+	if !expiresAt.Equal(claims.ExpiresAt.Time) {
+		t.Errorf("expiry = %s, want the token's exp %s", expiresAt, claims.ExpiresAt.Time)
+	}
+}
+
+func TestNewAuthenticatorRequiresSecret(t *testing.T) {
+	if _, err := NewAuthenticator(config.JWTConfig{}); err == nil {
+		t.Error("NewAuthenticator() accepted an empty secret")
+	}
+}
+
+func TestTokenFromRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		url    string
+		want   string
+	}{
+		{"bearer header", "Bearer abc", "/ws", "abc"},
+		{"query parameter", "", "/ws?token=abc", "abc"},
+		{"header wins", "Bearer abc", "/ws?token=def", "abc"},
+		{"other scheme", "Basic abc", "/ws?token=def", "def"},
+		{"none", "", "/ws", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.url, nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			if got := TokenFromRequest(r); got != tt.want {
+				t.Errorf("TokenFromRequest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// PlayerIDMetadataKey carries the authenticated player between the gateway and
+// the services behind it
+const PlayerIDMetadataKey = "x-player-id"
+
+type playerIDKey struct{}
+
+// NewOutgoingContext attaches the authenticated player to outgoing gRPC calls
+func NewOutgoingContext(ctx context.Context, playerID int64) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, PlayerIDMetadataKey, strconv.FormatInt(playerID, 10))
+}
+
+// WithPlayerID stores the authenticated player in ctx
+func WithPlayerID(ctx context.Context, playerID int64) context.Context {
+	return context.WithValue(ctx, playerIDKey{}, playerID)
+}
+
+// PlayerIDFromContext returns the authenticated player stored in ctx
+func PlayerIDFromContext(ctx context.Context) (int64, bool) {
+	playerID, ok := ctx.Value(playerIDKey{}).(int64)
+	return playerID, ok
+}
+
+// UnaryServerInterceptor rejects calls that do not carry an authenticated
+// player and makes the player available through PlayerIDFromContext
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		playerID, err := playerIDFromIncomingContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(WithPlayerID(ctx, playerID), req)
+	}
+}
+
+func playerIDFromIncomingContext(ctx context.Context) (int64, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get(PlayerIDMetadataKey)
+	if len(values) != 1 {
+		return 0, status.Error(codes.Unauthenticated, "missing player identity")
+	}
+
+	playerID, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil || playerID <= 0 {
+		return 0, status.Error(codes.Unauthenticated, "invalid player identity")
+	}
+
+	return playerID, nil
+}
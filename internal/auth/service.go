@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceTokenMetadataKey carries the shared secret internal services
+// present to each other's gRPC APIs
+const ServiceTokenMetadataKey = "x-service-token"
+
+// ServiceUnaryServerInterceptor rejects calls that do not carry token. It
+// guards internal APIs, such as the gateway push API, that act for any
+// player.
+func ServiceUnaryServerInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !validServiceToken(ctx, token) {
+			return nil, status.Error(codes.Unauthenticated, "invalid service token")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ServiceMethodsUnaryServerInterceptor guards only fullMethods, such as
+// /player.PlayerService/IssueToken, with token, for services that serve
+// players and internal callers alike
+func ServiceMethodsUnaryServerInterceptor(token string, fullMethods ...string) grpc.UnaryServerInterceptor {
+	guard := ServiceUnaryServerInterceptor(token)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !slices.Contains(fullMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+		return guard(ctx, req, info, handler)
+	}
+}
+
+// ServiceUnaryClientInterceptor attaches token to every outgoing call
+func ServiceUnaryClientInterceptor(token string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, ServiceTokenMetadataKey, token)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func validServiceToken(ctx context.Context, token string) bool {
+	if token == "" {
+		return false
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	values := md.Get(ServiceTokenMetadataKey)
+	return len(values) == 1 && subtle.ConstantTimeCompare([]byte(values[0]), []byte(token)) == 1
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestServiceUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		sent       []string
+		wantCode   codes.Code
+	}{
+		{"valid token", "secret", []string{"secret"}, codes.OK},
+		{"wrong token", "secret", []string{"secreT"}, codes.Unauthenticated},
+		{"token prefix", "secret", []string{"secre"}, codes.Unauthenticated},
+		{"no token", "secret", nil, codes.Unauthenticated},
+		{"two tokens", "secret", []string{"secret", "secret"}, codes.Unauthenticated},
+		// an unset token never lets anyone in
+		{"nothing configured", "", []string{""}, codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.sent != nil {
+				md := metadata.MD{}
+				md.Append(ServiceTokenMetadataKey, tt.sent...)
+				ctx = metadata.NewIncomingContext(ctx, md)
+			}
+
+			called := false
+			_, err := ServiceUnaryServerInterceptor(tt.configured)(ctx, nil, &grpc.UnaryServerInfo{}, func(context.Context, any) (any, error) {
+				called = true
+				return nil, nil
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %s, want %s", code, tt.wantCode)
+			}
+			if called != (tt.wantCode == codes.OK) {
+				t.Errorf("handler called = %v", called)
+			}
+		})
+	}
+}
+
+func TestServiceUnaryClientInterceptor(t *testing.T) {
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		// what the client sends is what the server interceptor checks
+		ctx = metadata.NewIncomingContext(ctx, md)
+		_, err := ServiceUnaryServerInterceptor("secret")(ctx, nil, &grpc.UnaryServerInfo{}, func(context.Context, any) (any, error) {
+			return nil, nil
+		})
+		return err
+	}
+
+	if err := ServiceUnaryClientInterceptor("secret")(context.Background(), "/gateway.GatewayService/PushToPlayer", nil, nil, nil, invoker); err != nil {
+		t.Errorf("call with the client token: %v", err)
+	}
+}
+
+func TestServiceMethodsUnaryServerInterceptor(t *testing.T) {
+	const guarded = "/player.PlayerService/IssueToken"
+	interceptor := ServiceMethodsUnaryServerInterceptor("secret", guarded)
+
+	tests := []struct {
+		name     string
+		method   string
+		sent     string
+		wantCode codes.Code
+	}{
+		{"guarded with token", guarded, "secret", codes.OK},
+		{"guarded without token", guarded, "", codes.Unauthenticated},
+		{"guarded with wrong token", guarded, "secreT", codes.Unauthenticated},
+		{"other method without token", "/player.PlayerService/GetPlayerInfo", "", codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.sent != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(ServiceTokenMetadataKey, tt.sent))
+			}
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(context.Context, any) (any, error) {
+				return nil, nil
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("code = %s, want %s", code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+)
+
+const (
+	// ClawGameKeyPrefix is the prefix for claw simulation state in Redis
+	ClawGameKeyPrefix = "claw_game"
+
+	clawGameUpdateRetries = 5
+)
+
+// StoreClawGame stores the simulation state of a new game until ttl elapses
+func (r *RedisClient) StoreClawGame(ctx context.Context, gameID int64, state any, ttl time.Duration) error {
+	key := fmt.Sprintf("%s:%d", ClawGameKeyPrefix, gameID)
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal claw game: %w", err)
+	}
+
+	return r.client.Set(ctx, key, data, ttl).Err()
+}
+
+// UpdateClawGame loads the state of a game into dest, applies update and
+// writes the result back, keeping the expiry. Concurrent updates of the same
+// game are retried against the fresh state so none of them is lost.
+func (r *RedisClient) UpdateClawGame(ctx context.Context, gameID int64, dest any, update func() error) error {
+	key := fmt.Sprintf("%s:%d", ClawGameKeyPrefix, gameID)
+
+	txf := func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return errcode.Newf(errcode.GameExpired, "game %d has expired or already ended", gameID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get claw game: %w", err)
+		}
+		if err := json.Unmarshal(data, dest); err != nil {
+			return fmt.Errorf("failed to unmarshal claw game: %w", err)
+		}
+
+		if err := update(); err != nil {
+			return err
+		}
+
+		data, err = json.Marshal(dest)
+		if err != nil {
+			return fmt.Errorf("failed to marshal claw game: %w", err)
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.SetArgs(ctx, key, data, redis.SetArgs{KeepTTL: true})
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < clawGameUpdateRetries; i++ {
+		err := r.client.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		return err
+	}
+	return errcode.Newf(errcode.Unavailable, "game %d is busy, try again", gameID)
+}
+
+// DeleteClawGame removes the simulation state of a game
+func (r *RedisClient) DeleteClawGame(ctx context.Context, gameID int64) error {
+	key := fmt.Sprintf("%s:%d", ClawGameKeyPrefix, gameID)
+	return r.client.Del(ctx, key).Err()
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimitKeyPrefix is the prefix for token bucket keys in Redis
+const RateLimitKeyPrefix = "rate_limit"
+
+// tokenBucketScript refills the bucket for the time elapsed since its last
+// use and takes one token if available. It returns whether the token was
+// granted and, if not, how many milliseconds until one is.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil then
+  tokens = burst
+  ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry_after = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tokens, "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+
+return {allowed, retry_after}
+`)
+
+// TakeToken takes one token from the bucket identified by key, which refills
+// at rate tokens per second up to burst. Buckets live in Redis so every
+// gateway instance shares them.
+func (r *RedisClient) TakeToken(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	if rate <= 0 || burst <= 0 || math.IsInf(rate, 0) {
+		return false, 0, fmt.Errorf("invalid token bucket rate %v or burst %d", rate, burst)
+	}
+
+	res, err := tokenBucketScript.Run(ctx, r.client,
+		[]string{fmt.Sprintf("%s:%s", RateLimitKeyPrefix, key)},
+		rate, burst, time.Now().UnixMilli(),
+	).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to take token: %w", err)
+	}
+
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
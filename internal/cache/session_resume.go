@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SessionResumeKeyPrefix is the prefix for detached WebSocket session state
+const SessionResumeKeyPrefix = "ws_resume"
+
+// StoreResumeState keeps the state of a detached session and the frames it
+// could not deliver until ttl elapses
+func (r *RedisClient) StoreResumeState(ctx context.Context, token string, state any, pending [][]byte, ttl time.Duration) error {
+	key := fmt.Sprintf("%s:%s", SessionResumeKeyPrefix, token)
+	pendingKey := key + ":pending"
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resume state: %w", err)
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, data, ttl)
+		pipe.Del(ctx, pendingKey)
+		if len(pending) > 0 {
+			values := make([]any, 0, len(pending))
+			for _, frame := range pending {
+				values = append(values, frame)
+			}
+			pipe.RPush(ctx, pendingKey, values...)
+			pipe.Expire(ctx, pendingKey, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store resume state: %w", err)
+	}
+	return nil
+}
+
+// TakeResumeState loads and deletes the state stored for token, so a
+// session can be resumed only once. It reports false when nothing is stored,
+// for example because the grace window elapsed.
+func (r *RedisClient) TakeResumeState(ctx context.Context, token string, dest any) ([][]byte, bool, error) {
+	key := fmt.Sprintf("%s:%s", SessionResumeKeyPrefix, token)
+	pendingKey := key + ":pending"
+
+	var stateCmd *redis.StringCmd
+	var pendingCmd *redis.StringSliceCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		stateCmd = pipe.GetDel(ctx, key)
+		pendingCmd = pipe.LRange(ctx, pendingKey, 0, -1)
+		pipe.Del(ctx, pendingKey)
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, false, fmt.Errorf("failed to load resume state: %w", err)
+	}
+
+	data, err := stateCmd.Result()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load resume state: %w", err)
+	}
+	if err := json.Unmarshal([]byte(data), dest); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal resume state: %w", err)
+	}
+
+	frames := pendingCmd.Val()
+	pending := make([][]byte, 0, len(frames))
+	for _, frame := range frames {
+		pending = append(pending, []byte(frame))
+	}
+
+	return pending, true, nil
+}
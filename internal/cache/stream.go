@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AppendToStream adds an entry to a Redis stream, trimming it approximately
+// to maxLen entries when maxLen is positive
+func (r *RedisClient) AppendToStream(ctx context.Context, stream string, maxLen int64, values map[string]any) (string, error) {
+	args := &redis.XAddArgs{
+		Stream: stream,
+		Values: values,
+	}
+	if maxLen > 0 {
+		args.MaxLen = maxLen
+		args.Approx = true
+	}
+
+	id, err := r.client.XAdd(ctx, args).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to append to stream %s: %w", stream, err)
+	}
+	return id, nil
+}
+
+// EnsureConsumerGroup creates the consumer group, and the stream if needed.
+// An already existing group is not an error.
+func (r *RedisClient) EnsureConsumerGroup(ctx context.Context, stream, group string) error {
+	err := r.client.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s on %s: %w", group, stream, err)
+	}
+	return nil
+}
+
+// ReadGroup reads entries never delivered to the group, blocking up to block
+func (r *RedisClient) ReadGroup(
+	ctx context.Context,
+	stream, group, consumer string,
+	count int64,
+	block time.Duration,
+) ([]redis.XMessage, error) {
+	res, err := r.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{stream, ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var messages []redis.XMessage
+	for _, s := range res {
+		messages = append(messages, s.Messages...)
+	}
+	return messages, nil
+}
+
+// ClaimStale takes over entries that another consumer of the group read but
+// did not acknowledge within minIdle. It returns the cursor to continue from.
+func (r *RedisClient) ClaimStale(
+	ctx context.Context,
+	stream, group, consumer string,
+	minIdle time.Duration,
+	start string,
+	count int64,
+) ([]redis.XMessage, string, error) {
+	messages, next, err := r.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Start:    start,
+		Count:    count,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, start, err
+	}
+	return messages, next, nil
+}
+
+// Ack acknowledges processed stream entries for the group
+func (r *RedisClient) Ack(ctx context.Context, stream, group string, ids ...string) error {
+	return r.client.XAck(ctx, stream, group, ids...).Err()
+}
+
+// LastStreamID returns the ID of the newest entry of the stream, "0-0" when
+// the stream is empty or does not exist yet
+func (r *RedisClient) LastStreamID(ctx context.Context, stream string) (string, error) {
+	messages, err := r.client.XRevRangeN(ctx, stream, "+", "-", 1).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the last entry of stream %s: %w", stream, err)
+	}
+	if len(messages) == 0 {
+		return "0-0", nil
+	}
+	return messages[0].ID, nil
+}
+
+// ReadStream reads entries appended after the entry with ID after, blocking
+// up to block, without a consumer group
+func (r *RedisClient) ReadStream(
+	ctx context.Context,
+	stream, after string,
+	count int64,
+	block time.Duration,
+) ([]redis.XMessage, error) {
+	res, err := r.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{stream, after},
+		Count:   count,
+		Block:   block,
+	}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var messages []redis.XMessage
+	for _, s := range res {
+		messages = append(messages, s.Messages...)
+	}
+	return messages, nil
+}
@@ -0,0 +1,166 @@
+// Package clawsim simulates the claw of a machine on a 2D board. The server
+// owns the board: clients only send move and drop inputs, and the item the
+// claw reaches when it drops, along with whether it is caught, is decided
+// here from state the client never sees.
+package clawsim
+
+import (
+	"errors"
+	"math/rand/v2"
+)
+
+// Board dimensions in simulation units. The claw starts at the top centre of
+// the board and items lie below it.
+const (
+	BoardWidth  int32 = 1000
+	BoardHeight int32 = 1000
+
+	// MaxStep bounds how far one move input carries the claw on each axis
+	MaxStep int32 = 50
+	// GrabRadius is how close to an item the claw must drop to reach it
+	GrabRadius int32 = 60
+	// MaxMoves bounds the move inputs of one game
+	MaxMoves = 200
+
+	// itemMargin keeps items off the edges and the claw's starting row
+	itemMargin int32 = 100
+	// itemSpacing is the minimum distance between two items on the board
+	itemSpacing  int32 = 2 * GrabRadius
+	placeRetries       = 50
+)
+
+type Phase string
+
+const (
+	PhaseAiming  Phase = "aiming"
+	PhaseSettled Phase = "settled"
+)
+
+var (
+	ErrSettled      = errors.New("game already settled")
+	ErrMovesUsed    = errors.New("no moves left, drop the claw")
+	ErrInvalidInput = errors.New("invalid claw input")
+)
+
+// Item is an item lying on the board. Catch is rolled when the game starts
+// and decides whether the claw holds the item once it reaches it.
+type Item struct {
+	ID    int64 `json:"id"`
+	X     int32 `json:"x"`
+	Y     int32 `json:"y"`
+	Catch bool  `json:"catch"`
+}
+
+// Result is the outcome of a drop; ItemID is 0 when the claw reached nothing
+type Result struct {
+	ItemID  int64 `json:"item_id"`
+	Catched bool  `json:"catched"`
+}
+
+// Game is the simulation state of one game, kept in Redis between inputs
+type Game struct {
+	GameID    int64   `json:"game_id"`
+	PlayerID  int64   `json:"player_id"`
+	MachineID int64   `json:"machine_id"`
+	X         int32   `json:"x"`
+	Y         int32   `json:"y"`
+	Moves     int     `json:"moves"`
+	Phase     Phase   `json:"phase"`
+	Items     []Item  `json:"items"`
+	Result    *Result `json:"result,omitempty"`
+}
+
+// NewGame places the items on the board and puts the claw at its start
+func NewGame(gameID, playerID, machineID int64, items []Item) *Game {
+	return &Game{
+		GameID:    gameID,
+		PlayerID:  playerID,
+		MachineID: machineID,
+		X:         BoardWidth / 2,
+		Y:         0,
+		Phase:     PhaseAiming,
+		Items:     Place(items),
+	}
+}
+
+// Place gives each item a random position, keeping items apart so a drop
+// reaches at most one of them whenever the board has room
+func Place(items []Item) []Item {
+	placed := make([]Item, 0, len(items))
+	for _, item := range items {
+		for try := 0; try < placeRetries; try++ {
+			item.X = itemMargin + rand.Int32N(BoardWidth-2*itemMargin)
+			item.Y = itemMargin + rand.Int32N(BoardHeight-2*itemMargin)
+			if !crowded(placed, item.X, item.Y) {
+				break
+			}
+		}
+		placed = append(placed, item)
+	}
+	return placed
+}
+
+func crowded(items []Item, x, y int32) bool {
+	for _, other := range items {
+		if distanceSquared(other.X, other.Y, x, y) < int64(itemSpacing)*int64(itemSpacing) {
+			return true
+		}
+	}
+	return false
+}
+
+// MovesLeft is how many move inputs the game still accepts
+func (g *Game) MovesLeft() int {
+	return max(MaxMoves-g.Moves, 0)
+}
+
+// Move shifts the claw by dx, dy, each capped at MaxStep, and keeps it on
+// the board
+func (g *Game) Move(dx, dy int32) error {
+	if g.Phase == PhaseSettled {
+		return ErrSettled
+	}
+	if g.Moves >= MaxMoves {
+		return ErrMovesUsed
+	}
+	if dx == 0 && dy == 0 {
+		return ErrInvalidInput
+	}
+
+	g.X = clamp(g.X+clamp(dx, -MaxStep, MaxStep), 0, BoardWidth)
+	g.Y = clamp(g.Y+clamp(dy, -MaxStep, MaxStep), 0, BoardHeight)
+	g.Moves++
+	return nil
+}
+
+// Drop lowers the claw where it stands and settles the game. The claw
+// reaches the nearest item within GrabRadius, if any, and holds it when the
+// item's catch roll succeeded.
+func (g *Game) Drop() (Result, error) {
+	if g.Phase == PhaseSettled {
+		return Result{}, ErrSettled
+	}
+
+	var result Result
+	best := int64(GrabRadius) * int64(GrabRadius)
+	for _, item := range g.Items {
+		if d := distanceSquared(item.X, item.Y, g.X, g.Y); d <= best {
+			best = d
+			result = Result{ItemID: item.ID, Catched: item.Catch}
+		}
+	}
+
+	g.Phase = PhaseSettled
+	g.Result = &result
+	return result, nil
+}
+
+func distanceSquared(x1, y1, x2, y2 int32) int64 {
+	dx := int64(x1 - x2)
+	dy := int64(y1 - y2)
+	return dx*dx + dy*dy
+}
+
+func clamp(v, lo, hi int32) int32 {
+	return min(max(v, lo), hi)
+}
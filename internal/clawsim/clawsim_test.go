@@ -0,0 +1,168 @@
+package clawsim
+
+import (
+	"errors"
+	"testing"
+)
+
+// game returns an aiming game with the claw at x, y and the items where
+// they are given, without random placement
+func game(x, y int32, items ...Item) *Game {
+	return &Game{GameID: 1, X: x, Y: y, Phase: PhaseAiming, Items: items}
+}
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		name         string
+		x, y         int32
+		dx, dy       int32
+		wantX, wantY int32
+		wantErr      error
+	}{
+		{"within a step", 500, 0, 20, 30, 520, 30, nil},
+		{"capped at MaxStep", 500, 500, 500, -500, 500 + MaxStep, 500 - MaxStep, nil},
+		{"clamped to the left edge", 10, 10, -MaxStep, -MaxStep, 0, 0, nil},
+		{"clamped to the far corner", BoardWidth - 10, BoardHeight - 10, MaxStep, MaxStep, BoardWidth, BoardHeight, nil},
+		{"no movement", 500, 0, 0, 0, 500, 0, ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := game(tt.x, tt.y)
+			err := g.Move(tt.dx, tt.dy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Move() error = %v, want %v", err, tt.wantErr)
+			}
+			if g.X != tt.wantX || g.Y != tt.wantY {
+				t.Errorf("claw at (%d, %d), want (%d, %d)", g.X, g.Y, tt.wantX, tt.wantY)
+			}
+			wantMoves := 1
+			if tt.wantErr != nil {
+				wantMoves = 0
+			}
+			if g.Moves != wantMoves {
+				t.Errorf("Moves = %d, want %d", g.Moves, wantMoves)
+			}
+		})
+	}
+}
+
+func TestMoveLimits(t *testing.T) {
+	g := game(BoardWidth/2, 0)
+	for i := 0; i < MaxMoves; i++ {
+		if err := g.Move(1, 0); err != nil {
+			t.Fatalf("move %d: %v", i+1, err)
+		}
+	}
+	if g.MovesLeft() != 0 {
+		t.Errorf("MovesLeft() = %d after %d moves, want 0", g.MovesLeft(), MaxMoves)
+	}
+	if err := g.Move(1, 0); !errors.Is(err, ErrMovesUsed) {
+		t.Errorf("move past MaxMoves: error = %v, want %v", err, ErrMovesUsed)
+	}
+
+	// out of moves, the claw can still drop
+	if _, err := g.Drop(); err != nil {
+		t.Fatalf("Drop() error = %v", err)
+	}
+	if err := g.Move(1, 0); !errors.Is(err, ErrSettled) {
+		t.Errorf("move after the drop: error = %v, want %v", err, ErrSettled)
+	}
+}
+
+func TestDrop(t *testing.T) {
+	tests := []struct {
+		name  string
+		x, y  int32
+		items []Item
+		want  Result
+	}{
+		{"nothing on the board", 500, 500, nil, Result{}},
+		{"directly above a caught item", 500, 500, []Item{{ID: 1, X: 500, Y: 500, Catch: true}}, Result{ItemID: 1, Catched: true}},
+		{"directly above a missed item", 500, 500, []Item{{ID: 1, X: 500, Y: 500}}, Result{ItemID: 1}},
+		{"at the edge of reach", 500, 500, []Item{{ID: 1, X: 500 + GrabRadius, Y: 500, Catch: true}}, Result{ItemID: 1, Catched: true}},
+		{"just out of reach", 500, 500, []Item{{ID: 1, X: 500 + GrabRadius + 1, Y: 500, Catch: true}}, Result{}},
+		// 36² + 48² = 60², exactly GrabRadius away on the diagonal
+		{"diagonal edge of reach", 500, 500, []Item{{ID: 1, X: 536, Y: 548}}, Result{ItemID: 1}},
+		{"diagonal out of reach", 500, 500, []Item{{ID: 1, X: 537, Y: 548}}, Result{}},
+		{"nearest of two in reach", 500, 500, []Item{
+			{ID: 1, X: 540, Y: 500, Catch: true},
+			{ID: 2, X: 490, Y: 500},
+		}, Result{ItemID: 2}},
+		{"later of two at the same distance", 500, 500, []Item{
+			{ID: 1, X: 520, Y: 500},
+			{ID: 2, X: 480, Y: 500, Catch: true},
+		}, Result{ItemID: 2, Catched: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := game(tt.x, tt.y, tt.items...)
+			got, err := g.Drop()
+			if err != nil {
+				t.Fatalf("Drop() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Drop() = %+v, want %+v", got, tt.want)
+			}
+			if g.Phase != PhaseSettled || g.Result == nil || *g.Result != tt.want {
+				t.Errorf("game not settled with the result: phase %s, result %+v", g.Phase, g.Result)
+			}
+			if _, err := g.Drop(); !errors.Is(err, ErrSettled) {
+				t.Errorf("second Drop() error = %v, want %v", err, ErrSettled)
+			}
+		})
+	}
+}
+
+func TestPlace(t *testing.T) {
+	tests := []struct {
+		name  string
+		items int
+		// apart is whether the board has room for every item to be
+		// itemSpacing from the others
+		apart bool
+	}{
+		{"none", 0, true},
+		{"one", 1, true},
+		{"a full machine", 10, true},
+		{"crowded", 200, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]Item, tt.items)
+			for i := range items {
+				items[i] = Item{ID: int64(i + 1), Catch: i%2 == 0}
+			}
+
+			placed := Place(items)
+			if len(placed) != len(items) {
+				t.Fatalf("placed %d items, want %d", len(placed), len(items))
+			}
+
+			for i, item := range placed {
+				if item.ID != items[i].ID || item.Catch != items[i].Catch {
+					t.Errorf("item %d changed: %+v", i, item)
+				}
+				if item.X < itemMargin || item.X >= BoardWidth-itemMargin ||
+					item.Y < itemMargin || item.Y >= BoardHeight-itemMargin {
+					t.Errorf("item %d at (%d, %d), outside the margins", item.ID, item.X, item.Y)
+				}
+				if tt.apart && crowded(placed[:i], item.X, item.Y) {
+					t.Errorf("item %d at (%d, %d) is within itemSpacing of another", item.ID, item.X, item.Y)
+				}
+			}
+		})
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	g := NewGame(3, 4, 5, []Item{{ID: 1}, {ID: 2}})
+	if g.X != BoardWidth/2 || g.Y != 0 {
+		t.Errorf("claw starts at (%d, %d), want top centre", g.X, g.Y)
+	}
+	if g.Phase != PhaseAiming || g.MovesLeft() != MaxMoves || len(g.Items) != 2 {
+		t.Errorf("unexpected new game: %+v", g)
+	}
+}
@@ -79,6 +79,10 @@ type RedisConfig struct {
 type GRPCConfig struct {
 	Host string `mapstructure:"host"`
 	Port int    `mapstructure:"port"`
+	// ServiceToken is the shared secret internal callers present to gRPC
+	// APIs that are not for players, such as the gateway push API and
+	// PlayerService.IssueToken
+	ServiceToken string `mapstructure:"service_token"`
 }
 
 type WebSocketConfig struct {
@@ -87,6 +91,62 @@ type WebSocketConfig struct {
 	Path            string `mapstructure:"path"`
 	ReadBufferSize  int    `mapstructure:"read_buffer_size"`
 	WriteBufferSize int    `mapstructure:"write_buffer_size"`
+	// CheckOrigin restricts upgrades to same-origin requests and AllowedOrigins
+	CheckOrigin    bool     `mapstructure:"check_origin"`
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+	// Workers bounds how many requests of one connection run concurrently
+	Workers int `mapstructure:"workers"`
+	// SendQueueSize and OverflowPolicy (drop or disconnect) control the
+	// per-connection outbound queue
+	SendQueueSize  int    `mapstructure:"send_queue_size"`
+	OverflowPolicy string `mapstructure:"overflow_policy"`
+	// Keepalive timings in seconds; a connection silent for PongWait is closed
+	PingInterval   int   `mapstructure:"ping_interval"`
+	PongWait       int   `mapstructure:"pong_wait"`
+	WriteWait      int   `mapstructure:"write_wait"`
+	MaxMessageSize int64 `mapstructure:"max_message_size"`
+	// ResumeGrace is how long, in seconds, a dropped session can be resumed;
+	// 0 disables resume
+	ResumeGrace int             `mapstructure:"resume_grace"`
+	RateLimit   RateLimitConfig `mapstructure:"rate_limit"`
+	// MinProtocolVersion is the oldest protocol version clients may speak in
+	// their HelloReq; raise it to retire old clients after a rollout.
+	// HandshakeTimeout is how long, in seconds, a client has to send it.
+	MinProtocolVersion int           `mapstructure:"min_protocol_version"`
+	HandshakeTimeout   int           `mapstructure:"handshake_timeout"`
+	Capture            CaptureConfig `mapstructure:"capture"`
+}
+
+// CaptureConfig records the envelopes of selected sessions to Dir, one file
+// per session, for replay with ws-replay. An empty PlayerIDs captures every
+// session; MaxBytes caps each file.
+type CaptureConfig struct {
+	Enabled   bool    `mapstructure:"enabled"`
+	Dir       string  `mapstructure:"dir"`
+	PlayerIDs []int64 `mapstructure:"player_ids"`
+	MaxBytes  int64   `mapstructure:"max_bytes"`
+}
+
+// RateLimitConfig sets token buckets for real-time messages. MessageTypes is
+// keyed by message type name, matched case-insensitively, and falls back to
+// Default; buckets are per player and shared across gateway instances and
+// transports through Redis, so they only apply where Redis is configured.
+// Connection limits every message on one connection regardless of type.
+type RateLimitConfig struct {
+	Enabled      bool                     `mapstructure:"enabled"`
+	Default      RateLimitRule            `mapstructure:"default"`
+	Connection   RateLimitRule            `mapstructure:"connection"`
+	MessageTypes map[string]RateLimitRule `mapstructure:"message_types"`
+	// A connection exceeding MaxViolations limited messages within
+	// ViolationWindow seconds is disconnected
+	MaxViolations   int `mapstructure:"max_violations"`
+	ViolationWindow int `mapstructure:"violation_window"`
+}
+
+// RateLimitRule is a token bucket refilling Rate tokens per second up to Burst
+type RateLimitRule struct {
+	Rate  float64 `mapstructure:"rate"`
+	Burst int     `mapstructure:"burst"`
 }
 
 type TCPConfig struct {
@@ -95,6 +155,39 @@ type TCPConfig struct {
 	KeepAlive    bool   `mapstructure:"keep_alive"`
 	ReadTimeout  int    `mapstructure:"read_timeout"`
 	WriteTimeout int    `mapstructure:"write_timeout"`
+	// MaxFrameSize bounds the length of one frame in bytes. Clients have
+	// HandshakeTimeout seconds to send their HelloReq, which must speak at
+	// least MinProtocolVersion.
+	MaxFrameSize int `mapstructure:"max_frame_size"`
+	// SendQueueSize bounds the frames waiting to be written to one client;
+	// clients that let it fill up are disconnected
+	SendQueueSize      int `mapstructure:"send_queue_size"`
+	HandshakeTimeout   int `mapstructure:"handshake_timeout"`
+	MinProtocolVersion int `mapstructure:"min_protocol_version"`
+	// Workers bounds how many requests of one connection run concurrently
+	Workers   int             `mapstructure:"workers"`
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	Capture   CaptureConfig   `mapstructure:"capture"`
+}
+
+// KCPConfig configures the reliable-UDP gateway. It carries the TCP
+// gateway's frames and shares its connection settings; the rest tunes KCP.
+type KCPConfig struct {
+	TCPConfig `mapstructure:",squash"`
+	// NoDelay, Interval (ms), Resend and NoCongestion trade bandwidth for
+	// latency as in ikcp_nodelay
+	NoDelay      bool `mapstructure:"no_delay"`
+	Interval     int  `mapstructure:"interval"`
+	Resend       int  `mapstructure:"resend"`
+	NoCongestion bool `mapstructure:"no_congestion"`
+	// SendWindow and ReceiveWindow are in packets
+	SendWindow    int `mapstructure:"send_window"`
+	ReceiveWindow int `mapstructure:"receive_window"`
+	MTU           int `mapstructure:"mtu"`
+	// DataShards and ParityShards enable forward error correction, which
+	// recovers lost packets without waiting for a retransmission; 0 disables
+	DataShards   int `mapstructure:"data_shards"`
+	ParityShards int `mapstructure:"parity_shards"`
 }
 
 type JWTConfig struct {
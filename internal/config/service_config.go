@@ -10,20 +10,24 @@ import (
 )
 
 type ServiceConfig struct {
-	Service             ServiceDetails  `mapstructure:"service"`
-	Shared              SharedConfig    `mapstructure:"shared"`
-	Database            DatabaseConfig  `mapstructure:"database"`
-	PlayerDatabase      DatabaseConfig  `mapstructure:"player_database"`
-	ClawmachineDatabase DatabaseConfig  `mapstructure:"clawmachine_database"`
-	Redis               RedisConfig     `mapstructure:"redis"`
-	GRPC                GRPCConfig      `mapstructure:"grpc"`
-	WebSocket           WebSocketConfig `mapstructure:"websocket"`
-	TCP                 TCPConfig       `mapstructure:"tcp"`
-	CORS                CORSConfig      `mapstructure:"cors"`
-	Logging             LoggingConfig   `mapstructure:"logging"`
-	JWT                 JWTConfig       `mapstructure:"jwt"`
-	Tracing             TracingConfig   `mapstructure:"tracing"`
-	Discovery           DiscoveryConfig `mapstructure:"discovery"`
+	Service             ServiceDetails    `mapstructure:"service"`
+	Shared              SharedConfig      `mapstructure:"shared"`
+	Database            DatabaseConfig    `mapstructure:"database"`
+	PlayerDatabase      DatabaseConfig    `mapstructure:"player_database"`
+	ClawmachineDatabase DatabaseConfig    `mapstructure:"clawmachine_database"`
+	Redis               RedisConfig       `mapstructure:"redis"`
+	GRPC                GRPCConfig        `mapstructure:"grpc"`
+	WebSocket           WebSocketConfig   `mapstructure:"websocket"`
+	TCP                 TCPConfig         `mapstructure:"tcp"`
+	KCP                 KCPConfig         `mapstructure:"kcp"`
+	CORS                CORSConfig        `mapstructure:"cors"`
+	Logging             LoggingConfig     `mapstructure:"logging"`
+	JWT                 JWTConfig         `mapstructure:"jwt"`
+	Tracing             TracingConfig     `mapstructure:"tracing"`
+	Discovery           DiscoveryConfig   `mapstructure:"discovery"`
+	Entitlement         EntitlementConfig `mapstructure:"entitlement"`
+	Events              EventsConfig      `mapstructure:"events"`
+	OpenAPI             OpenAPIConfig     `mapstructure:"openapi"`
 }
 
 // GetRedisAddr returns the Redis address in host:port format
@@ -54,6 +58,7 @@ type SharedConfig struct {
 	Logging             string `mapstructure:"logging"`
 	JWT                 string `mapstructure:"jwt"`
 	Tracing             string `mapstructure:"tracing"`
+	Events              string `mapstructure:"events"`
 }
 
 type CORSConfig struct {
@@ -132,6 +137,12 @@ func LoadServiceConfigFromPath(configFile string) (*ServiceConfig, error) {
 		}
 	}
 
+	if config.Shared.Events != "" {
+		if err := loadSharedConfig(v, config.Shared.Events, "events"); err != nil {
+			return nil, err
+		}
+	}
+
 	// Re-unmarshal after loading shared configs
 	if err := v.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("error unmarshaling final config: %w", err)
@@ -203,6 +214,13 @@ func (c *ServiceConfig) GetTCPAddr() string {
 	return c.GetServiceAddr()
 }
 
+func (c *ServiceConfig) GetKCPAddr() string {
+	if c.KCP.Port != 0 {
+		return fmt.Sprintf("%s:%d", c.KCP.Host, c.KCP.Port)
+	}
+	return c.GetServiceAddr()
+}
+
 // GetDSN returns database connection string
 func (c *ServiceConfig) GetDSN() string {
 	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
@@ -246,6 +264,28 @@ type EtcdConfig struct {
 	Timeout   string   `mapstructure:"timeout"`
 }
 
+// EventsConfig describes the domain event stream and the outbox relay feeding it
+type EventsConfig struct {
+	Stream          string `mapstructure:"stream"`
+	StreamMaxLen    int64  `mapstructure:"stream_max_len"`
+	RelayEnabled    bool   `mapstructure:"relay_enabled"`
+	RelayIntervalMs int    `mapstructure:"relay_interval_ms"`
+	RelayBatchSize  int    `mapstructure:"relay_batch_size"`
+	RetentionHours  int    `mapstructure:"retention_hours"` // published outbox rows older than this are deleted, 0 keeps them
+}
+
+// OpenAPIConfig controls how the api-service uses its OpenAPI document
+type OpenAPIConfig struct {
+	DocsPath         string `mapstructure:"docs_path"` // serves the viewer and document, empty disables them
+	ValidateRequests bool   `mapstructure:"validate_requests"`
+}
+
+// EntitlementConfig controls the daily free-play allowance for claw players
+type EntitlementConfig struct {
+	DailyFreePlays int32  `mapstructure:"daily_free_plays"`
+	ResetTimezone  string `mapstructure:"reset_timezone"`
+}
+
 // validateServiceConfig validates the service configuration
 func validateServiceConfig(config *ServiceConfig) error {
 	// Validate service configuration
@@ -321,11 +361,86 @@ func validateServiceConfig(config *ServiceConfig) error {
 		return fmt.Errorf("websocket write buffer size must be between 512 and 65536")
 	}
 
+	if config.WebSocket.Workers < 0 || config.WebSocket.SendQueueSize < 0 {
+		return fmt.Errorf("websocket workers and send queue size cannot be negative")
+	}
+
+	if config.WebSocket.PingInterval < 0 || config.WebSocket.PongWait < 0 || config.WebSocket.WriteWait < 0 || config.WebSocket.ResumeGrace < 0 {
+		return fmt.Errorf("websocket keepalive and resume timings cannot be negative")
+	}
+
+	if config.WebSocket.PingInterval > 0 && config.WebSocket.PongWait > 0 && config.WebSocket.PingInterval >= config.WebSocket.PongWait {
+		return fmt.Errorf("websocket ping interval must be shorter than pong wait")
+	}
+
+	switch config.WebSocket.OverflowPolicy {
+	case "", "drop", "disconnect":
+	default:
+		return fmt.Errorf("websocket overflow policy must be drop or disconnect")
+	}
+
+	if config.WebSocket.MinProtocolVersion < 0 || config.WebSocket.HandshakeTimeout < 0 {
+		return fmt.Errorf("websocket protocol version and handshake timeout cannot be negative")
+	}
+
+	if err := validateRateLimitConfig("websocket", config.WebSocket.RateLimit); err != nil {
+		return err
+	}
+
+	if err := validateCaptureConfig("websocket", config.WebSocket.Capture); err != nil {
+		return err
+	}
+
 	// Validate TCP configuration only if port is specified
 	if config.TCP.Port != 0 && (config.TCP.Port < 1024 || config.TCP.Port > 65535) {
 		return fmt.Errorf("tcp port must be between 1024 and 65535")
 	}
 
+	if config.TCP.MaxFrameSize < 0 || config.TCP.HandshakeTimeout < 0 || config.TCP.MinProtocolVersion < 0 {
+		return fmt.Errorf("tcp frame size, handshake timeout and protocol version cannot be negative")
+	}
+
+	if config.TCP.Workers < 0 || config.TCP.SendQueueSize < 0 || config.TCP.ReadTimeout < 0 || config.TCP.WriteTimeout < 0 {
+		return fmt.Errorf("tcp workers, send queue size and timeouts cannot be negative")
+	}
+
+	if err := validateRateLimitConfig("tcp", config.TCP.RateLimit); err != nil {
+		return err
+	}
+
+	if err := validateCaptureConfig("tcp", config.TCP.Capture); err != nil {
+		return err
+	}
+
+	// Validate KCP configuration only if port is specified
+	if config.KCP.Port != 0 && (config.KCP.Port < 1024 || config.KCP.Port > 65535) {
+		return fmt.Errorf("kcp port must be between 1024 and 65535")
+	}
+
+	if config.KCP.MaxFrameSize < 0 || config.KCP.HandshakeTimeout < 0 || config.KCP.MinProtocolVersion < 0 {
+		return fmt.Errorf("kcp frame size, handshake timeout and protocol version cannot be negative")
+	}
+
+	if config.KCP.Workers < 0 || config.KCP.SendQueueSize < 0 || config.KCP.ReadTimeout < 0 || config.KCP.WriteTimeout < 0 {
+		return fmt.Errorf("kcp workers, send queue size and timeouts cannot be negative")
+	}
+
+	if config.KCP.Interval < 0 || config.KCP.Resend < 0 || config.KCP.SendWindow < 0 || config.KCP.ReceiveWindow < 0 || config.KCP.MTU < 0 {
+		return fmt.Errorf("kcp interval, resend, windows and mtu cannot be negative")
+	}
+
+	if config.KCP.DataShards < 0 || config.KCP.ParityShards < 0 || (config.KCP.DataShards > 0) != (config.KCP.ParityShards > 0) {
+		return fmt.Errorf("kcp data and parity shards must both be set or both be 0")
+	}
+
+	if err := validateRateLimitConfig("kcp", config.KCP.RateLimit); err != nil {
+		return err
+	}
+
+	if err := validateCaptureConfig("kcp", config.KCP.Capture); err != nil {
+		return err
+	}
+
 	// Validate JWT configuration only if secret is specified
 	if config.JWT.Secret != "" {
 		if config.JWT.ExpirationTime < 300 || config.JWT.ExpirationTime > 86400*30 {
@@ -333,7 +448,58 @@ func validateServiceConfig(config *ServiceConfig) error {
 		}
 	}
 
+	// Validate entitlement configuration
+	if config.Entitlement.DailyFreePlays < 0 {
+		return fmt.Errorf("entitlement daily free plays cannot be negative")
+	}
+
+	// Validate OpenAPI configuration
+	if path := config.OpenAPI.DocsPath; path != "" && !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("openapi docs path must start with /")
+	}
+
 	return nil
 }
 
 // ... (rest of the code remains the same)
+
+func validateRateLimitConfig(transport string, cfg RateLimitConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	rules := map[string]RateLimitRule{"default": cfg.Default, "connection": cfg.Connection}
+	for name, rule := range cfg.MessageTypes {
+		rules[name] = rule
+	}
+	for name, rule := range rules {
+		if rule.Rate < 0 || rule.Burst < 0 {
+			return fmt.Errorf("%s rate limit %s cannot be negative", transport, name)
+		}
+		if (rule.Rate > 0) != (rule.Burst > 0) {
+			return fmt.Errorf("%s rate limit %s needs both rate and burst", transport, name)
+		}
+	}
+
+	if cfg.MaxViolations < 0 || cfg.ViolationWindow < 0 {
+		return fmt.Errorf("%s rate limit violations cannot be negative", transport)
+	}
+
+	return nil
+}
+
+func validateCaptureConfig(transport string, cfg CaptureConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.Dir == "" {
+		return fmt.Errorf("%s capture needs a directory", transport)
+	}
+
+	if cfg.MaxBytes < 0 {
+		return fmt.Errorf("%s capture max bytes cannot be negative", transport)
+	}
+
+	return nil
+}
@@ -63,7 +63,10 @@ func InitClawmachineDB(cfg *config.ServiceConfig) (*gorm.DB, error) {
 	}
 
 	// Auto migrate the schema
-	err = db.AutoMigrate(&domain.ClawMachine{}, &domain.ClawMachineItem{}, &domain.Item{}, &domain.ClawPlayer{}, &domain.ClawMachineGameRecord{})
+	err = db.AutoMigrate(
+		&domain.ClawMachine{}, &domain.ClawMachineItem{}, &domain.ClawMachineTag{}, &domain.Item{}, &domain.ClawPlayer{}, &domain.ClawMachineGameRecord{}, &domain.ClawPlayerEntitlement{}, &domain.OutboxEvent{}, &domain.Promotion{}, &domain.PromotionMachine{},
+		&domain.ClawPlayerItem{}, &domain.Voucher{}, &domain.VoucherRedemption{},
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate clawmachine database: %w", err)
 	}
@@ -1,12 +1,38 @@
 package domain
 
+import "time"
+
+const (
+	MachineCurrencyCoin    = "coin"
+	MachineCurrencyDiamond = "diamond"
+)
+
+const (
+	MachineStatusActive      = "active"
+	MachineStatusMaintenance = "maintenance"
+	MachineStatusRetired     = "retired"
+)
+
 type ClawMachine struct {
-	ID      int64  `gorm:"column:id;primaryKey" json:"machineID"`
-	Name    string `gorm:"column:name" json:"name"`
-	Price   int64  `gorm:"column:price" json:"price"`
-	MaxItem int32  `gorm:"column:max_item" json:"maxItem"`
+	ID       int64  `gorm:"column:id;primaryKey" json:"machineID"`
+	Name     string `gorm:"column:name" json:"name"`
+	Price    int64  `gorm:"column:price;index" json:"price"`
+	MaxItem  int32  `gorm:"column:max_item" json:"maxItem"`
+	Currency string `gorm:"column:currency;size:16;not null;default:coin" json:"currency"`
+	Status   string `gorm:"column:status;size:16;not null;default:active;index" json:"status"`
+
+	// PlayCount counts the games started on the machine, for sorting the
+	// lobby by popularity
+	PlayCount int64 `gorm:"column:play_count;not null;default:0;index" json:"playCount"`
 
 	Items []ClawMachineItem `gorm:"foreignKey:ClawMachineID;constraint:OnDelete:CASCADE"`
+	Tags  []ClawMachineTag  `gorm:"foreignKey:ClawMachineID;constraint:OnDelete:CASCADE"`
+}
+
+type ClawMachineTag struct {
+	ID            int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ClawMachineID int64  `gorm:"column:claw_machine_id;uniqueIndex:idx_machine_tag" json:"clawMachineID"`
+	Tag           string `gorm:"column:tag;size:32;uniqueIndex:idx_machine_tag;index" json:"tag"`
 }
 
 type ClawMachineItem struct {
@@ -20,16 +46,24 @@ type ClawMachineItem struct {
 type Item struct {
 	ID              int64  `gorm:"column:id;primaryKey" json:"itemID"`
 	Name            string `gorm:"column:name" json:"name"`
-	Rarity          string `gorm:"column:rarity" json:"rarity"`
+	Rarity          string `gorm:"column:rarity;index" json:"rarity"`
 	SpawnPercentage int64  `gorm:"column:spawn_percentage" json:"spawnPercentage"`
 	CatchPercentage int64  `gorm:"column:catch_percentage" json:"catchPercentage"`
 	MaxItemSpawned  int64  `gorm:"column:max_item_spawned" json:"maxItemSpawned"`
 }
 
 type ClawPlayer struct {
-	Player  Player `gorm:"embedded;embeddedPrefix:player_"`
-	Coin    int64  `gorm:"column:coin;not null" json:"coin"`
-	Diamond int64  `gorm:"column:diamond;not null" json:"diamond"`
+	Player         Player `gorm:"embedded;embeddedPrefix:player_"`
+	Coin           int64  `gorm:"column:coin;not null" json:"coin"`
+	Diamond        int64  `gorm:"column:diamond;not null" json:"diamond"`
+	BonusFreePlays int64  `gorm:"column:bonus_free_plays;not null;default:0" json:"bonusFreePlays"`
+}
+
+type ClawPlayerItem struct {
+	ID       int64 `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	PlayerID int64 `gorm:"column:player_id;uniqueIndex:idx_player_item" json:"playerID"`
+	ItemID   int64 `gorm:"column:item_id;uniqueIndex:idx_player_item" json:"itemID"`
+	Quantity int64 `gorm:"column:quantity;not null;default:0" json:"quantity"`
 }
 
 type ClawMachineGameRecord struct {
@@ -38,6 +72,37 @@ type ClawMachineGameRecord struct {
 	PlayerID      int64 `gorm:"column:player_id" json:"playerID"`
 	TouchedItemID int64 `gorm:"column:touched_item_id" json:"touchedItemID"`
 	Catched       bool  `gorm:"column:catched" json:"catched"`
+	FreePlay      bool  `gorm:"column:free_play" json:"freePlay"`
+	PricePaid     int64 `gorm:"column:price_paid" json:"pricePaid"`
+	PromotionID   int64 `gorm:"column:promotion_id" json:"promotionID"`
+	// Settled is set with the outcome; TouchedItemID is 0 both before and
+	// after a drop that reached nothing
+	Settled bool `gorm:"column:settled;not null;default:false" json:"settled"`
+}
+
+type ClawPlayerEntitlement struct {
+	ID            int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	PlayerID      int64  `gorm:"column:player_id;uniqueIndex:idx_player_period" json:"playerID"`
+	Period        string `gorm:"column:period;size:10;uniqueIndex:idx_player_period" json:"period"`
+	FreePlaysUsed int32  `gorm:"column:free_plays_used;not null;default:0" json:"freePlaysUsed"`
+}
+
+type Promotion struct {
+	ID            int64     `gorm:"column:id;primaryKey;autoIncrement" json:"promotionID"`
+	Name          string    `gorm:"column:name" json:"name"`
+	DiscountType  string    `gorm:"column:discount_type;size:16" json:"discountType"`
+	DiscountValue int64     `gorm:"column:discount_value" json:"discountValue"`
+	StartsAt      time.Time `gorm:"column:starts_at;index" json:"startsAt"`
+	EndsAt        time.Time `gorm:"column:ends_at;index" json:"endsAt"`
+
+	// Machines lists the targeted machines, an empty list targets every machine
+	Machines []PromotionMachine `gorm:"foreignKey:PromotionID;constraint:OnDelete:CASCADE" json:"machines"`
+}
+
+type PromotionMachine struct {
+	ID            int64 `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	PromotionID   int64 `gorm:"column:promotion_id;index" json:"promotionID"`
+	ClawMachineID int64 `gorm:"column:claw_machine_id;index" json:"clawMachineID"`
 }
 
 func (ClawMachine) TableName() string {
@@ -48,6 +113,10 @@ func (ClawMachineItem) TableName() string {
 	return "claw_machine_item"
 }
 
+func (ClawMachineTag) TableName() string {
+	return "claw_machine_tag"
+}
+
 func (Item) TableName() string {
 	return "claw_item"
 }
@@ -56,6 +125,22 @@ func (ClawPlayer) TableName() string {
 	return "claw_player"
 }
 
+func (ClawPlayerItem) TableName() string {
+	return "claw_player_item"
+}
+
 func (ClawMachineGameRecord) TableName() string {
 	return "claw_machine_game_record"
 }
+
+func (ClawPlayerEntitlement) TableName() string {
+	return "claw_player_entitlement"
+}
+
+func (Promotion) TableName() string {
+	return "claw_promotion"
+}
+
+func (PromotionMachine) TableName() string {
+	return "claw_promotion_machine"
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventType names a domain event on the outbox and on the event stream
+type EventType string
+
+const (
+	EventTypeGameStarted    EventType = "GameStarted"
+	EventTypeItemTouched    EventType = "ItemTouched"
+	EventTypeGameSettled    EventType = "GameSettled"
+	EventTypeBalanceChanged EventType = "BalanceChanged"
+)
+
+// Event is implemented by every domain event payload
+type Event interface {
+	EventType() EventType
+	AggregateID() int64
+}
+
+type GameStartedEvent struct {
+	GameID      int64 `json:"gameID"`
+	PlayerID    int64 `json:"playerID"`
+	MachineID   int64 `json:"machineID"`
+	FreePlay    bool  `json:"freePlay"`
+	PricePaid   int64 `json:"pricePaid"`
+	PromotionID int64 `json:"promotionID,omitempty"`
+}
+
+type ItemTouchedEvent struct {
+	GameID   int64 `json:"gameID"`
+	PlayerID int64 `json:"playerID"`
+	ItemID   int64 `json:"itemID"`
+	Catched  bool  `json:"catched"`
+}
+
+type GameSettledEvent struct {
+	GameID    int64 `json:"gameID"`
+	PlayerID  int64 `json:"playerID"`
+	MachineID int64 `json:"machineID"`
+	ItemID    int64 `json:"itemID"`
+	Catched   bool  `json:"catched"`
+}
+
+type BalanceChangedEvent struct {
+	PlayerID int64  `json:"playerID"`
+	Currency string `json:"currency"` // coin or diamond
+	Delta    int64  `json:"delta"`
+	Balance  int64  `json:"balance"`
+}
+
+func (GameStartedEvent) EventType() EventType    { return EventTypeGameStarted }
+func (GameSettledEvent) EventType() EventType    { return EventTypeGameSettled }
+func (ItemTouchedEvent) EventType() EventType    { return EventTypeItemTouched }
+func (BalanceChangedEvent) EventType() EventType { return EventTypeBalanceChanged }
+
+func (e GameStartedEvent) AggregateID() int64    { return e.GameID }
+func (e GameSettledEvent) AggregateID() int64    { return e.GameID }
+func (e ItemTouchedEvent) AggregateID() int64    { return e.GameID }
+func (e BalanceChangedEvent) AggregateID() int64 { return e.PlayerID }
+
+// OutboxEvent is a domain event persisted in the same transaction as the
+// state change it describes, waiting to be relayed to the event stream
+type OutboxEvent struct {
+	ID          int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	EventType   EventType  `gorm:"column:event_type;size:64;not null" json:"eventType"`
+	AggregateID int64      `gorm:"column:aggregate_id;not null" json:"aggregateID"`
+	Payload     string     `gorm:"column:payload;type:text;not null" json:"payload"`
+	CreatedAt   time.Time  `gorm:"column:created_at;not null" json:"createdAt"`
+	PublishedAt *time.Time `gorm:"column:published_at;index" json:"publishedAt"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_event"
+}
+
+// NewOutboxEvent serializes a domain event into an outbox row
+func NewOutboxEvent(e Event) (*OutboxEvent, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s event: %w", e.EventType(), err)
+	}
+
+	return &OutboxEvent{
+		EventType:   e.EventType(),
+		AggregateID: e.AggregateID(),
+		Payload:     string(payload),
+		CreatedAt:   time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+const (
+	VoucherRewardCoin     = "coin"
+	VoucherRewardDiamond  = "diamond"
+	VoucherRewardFreePlay = "free_play"
+	VoucherRewardItem     = "item"
+)
+
+type Voucher struct {
+	ID             int64     `gorm:"column:id;primaryKey;autoIncrement" json:"voucherID"`
+	Code           string    `gorm:"column:code;size:64;uniqueIndex" json:"code"`
+	RewardType     string    `gorm:"column:reward_type;size:16;not null" json:"rewardType"`
+	RewardAmount   int64     `gorm:"column:reward_amount;not null" json:"rewardAmount"`
+	RewardItemID   int64     `gorm:"column:reward_item_id" json:"rewardItemID"`
+	MaxRedemptions int64     `gorm:"column:max_redemptions;not null" json:"maxRedemptions"`
+	RedeemedCount  int64     `gorm:"column:redeemed_count;not null;default:0" json:"redeemedCount"`
+	PerPlayerLimit int64     `gorm:"column:per_player_limit;not null" json:"perPlayerLimit"`
+	ExpiresAt      time.Time `gorm:"column:expires_at;not null" json:"expiresAt"`
+	CreatedAt      time.Time `gorm:"column:created_at" json:"createdAt"`
+}
+
+type VoucherRedemption struct {
+	ID         int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	VoucherID  int64     `gorm:"column:voucher_id;index:idx_voucher_player" json:"voucherID"`
+	PlayerID   int64     `gorm:"column:player_id;index:idx_voucher_player" json:"playerID"`
+	RedeemedAt time.Time `gorm:"column:redeemed_at" json:"redeemedAt"`
+}
+
+func (Voucher) TableName() string {
+	return "claw_voucher"
+}
+
+func (VoucherRedemption) TableName() string {
+	return "claw_voucher_redemption"
+}
@@ -0,0 +1,57 @@
+package entitlement
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // reset timezones must resolve inside minimal containers
+
+	"github.com/Richard-inter/game/internal/config"
+)
+
+const (
+	// periodLayout is the format of the period key stored per player and day
+	periodLayout = "2006-01-02"
+
+	defaultResetTimezone = "UTC"
+)
+
+// Policy describes how many free plays a player gets per day and
+// where the day boundary lies
+type Policy struct {
+	DailyFreePlays int32
+	location       *time.Location
+}
+
+// NewPolicy builds a Policy from the service configuration
+func NewPolicy(cfg config.EntitlementConfig) (*Policy, error) {
+	tz := cfg.ResetTimezone
+	if tz == "" {
+		tz = defaultResetTimezone
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entitlement reset timezone %q: %w", tz, err)
+	}
+
+	return &Policy{
+		DailyFreePlays: cfg.DailyFreePlays,
+		location:       loc,
+	}, nil
+}
+
+// Enabled reports whether players get any free plays at all
+func (p *Policy) Enabled() bool {
+	return p != nil && p.DailyFreePlays > 0
+}
+
+// Period returns the key of the entitlement period that contains now
+func (p *Policy) Period(now time.Time) string {
+	return now.In(p.location).Format(periodLayout)
+}
+
+// NextReset returns the moment the period containing now ends
+func (p *Policy) NextReset(now time.Time) time.Time {
+	local := now.In(p.location)
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, p.location)
+}
@@ -0,0 +1,71 @@
+package entitlement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Richard-inter/game/internal/config"
+)
+
+func TestPolicyPeriod(t *testing.T) {
+	tests := []struct {
+		name          string
+		timezone      string
+		now           time.Time
+		wantPeriod    string
+		wantNextReset time.Time
+	}{
+		{"utc by default", "", time.Date(2026, 10, 18, 23, 59, 59, 0, time.UTC),
+			"2026-10-18", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		// midnight belongs to the day it starts
+		{"utc at midnight", "UTC", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			"2026-10-19", time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		// 16:00 UTC is already midnight in Shanghai (UTC+8)
+		{"ahead of utc", "Asia/Shanghai", time.Date(2026, 10, 18, 16, 0, 0, 0, time.UTC),
+			"2026-10-19", time.Date(2026, 10, 19, 16, 0, 0, 0, time.UTC)},
+		{"ahead of utc before midnight", "Asia/Shanghai", time.Date(2026, 10, 18, 15, 59, 59, 0, time.UTC),
+			"2026-10-18", time.Date(2026, 10, 18, 16, 0, 0, 0, time.UTC)},
+		{"behind utc", "America/New_York", time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC),
+			"2026-10-18", time.Date(2026, 10, 19, 4, 0, 0, 0, time.UTC)},
+		// the day clocks go back is 25 hours long
+		{"daylight saving ends", "America/New_York", time.Date(2026, 11, 1, 4, 0, 0, 0, time.UTC),
+			"2026-11-01", time.Date(2026, 11, 2, 5, 0, 0, 0, time.UTC)},
+		{"end of year", "Europe/Berlin", time.Date(2026, 12, 31, 23, 30, 0, 0, time.UTC),
+			"2027-01-01", time.Date(2027, 1, 1, 23, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewPolicy(config.EntitlementConfig{DailyFreePlays: 3, ResetTimezone: tt.timezone})
+			if err != nil {
+				t.Fatalf("NewPolicy() error = %v", err)
+			}
+
+			if got := policy.Period(tt.now); got != tt.wantPeriod {
+				t.Errorf("Period() = %s, want %s", got, tt.wantPeriod)
+			}
+			if got := policy.NextReset(tt.now); !got.Equal(tt.wantNextReset) {
+				t.Errorf("NextReset() = %s, want %s", got.UTC(), tt.wantNextReset)
+			}
+		})
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	if _, err := NewPolicy(config.EntitlementConfig{ResetTimezone: "Mars/Olympus"}); err == nil {
+		t.Error("NewPolicy() accepted an unknown timezone")
+	}
+
+	var disabled *Policy
+	if disabled.Enabled() {
+		t.Error("nil policy is enabled")
+	}
+
+	policy, err := NewPolicy(config.EntitlementConfig{})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	if policy.Enabled() {
+		t.Error("policy without daily free plays is enabled")
+	}
+}
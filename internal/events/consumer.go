@@ -0,0 +1,138 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/cache"
+)
+
+const (
+	defaultConsumerBatchSize = 50
+	defaultConsumerBlock     = 5 * time.Second
+	defaultClaimMinIdle      = 30 * time.Second
+	errorBackoff             = time.Second
+	maxErrorBackoff          = 30 * time.Second
+)
+
+// Handler processes one event. Returning an error leaves the event
+// unacknowledged so it is delivered again later.
+type Handler func(ctx context.Context, event *Envelope) error
+
+type ConsumerConfig struct {
+	Stream    string
+	Group     string
+	Consumer  string
+	BatchSize int64
+	Block     time.Duration
+	// ClaimMinIdle is how long an entry may stay unacknowledged by a
+	// crashed or stuck consumer before another group member takes it over
+	ClaimMinIdle time.Duration
+}
+
+// Consumer reads events as a member of a consumer group with at-least-once
+// delivery: entries are acknowledged only after the handler succeeded.
+// Handlers must therefore be idempotent, keyed on Envelope.EventID.
+type Consumer struct {
+	redis   *cache.RedisClient
+	logger  *zap.SugaredLogger
+	handler Handler
+	cfg     ConsumerConfig
+}
+
+func NewConsumer(redis *cache.RedisClient, logger *zap.SugaredLogger, cfg ConsumerConfig, handler Handler) (*Consumer, error) {
+	if cfg.Group == "" || cfg.Consumer == "" {
+		return nil, fmt.Errorf("consumer group and consumer name are required")
+	}
+	if cfg.Stream == "" {
+		cfg.Stream = DefaultStream
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultConsumerBatchSize
+	}
+	if cfg.Block <= 0 {
+		cfg.Block = defaultConsumerBlock
+	}
+	if cfg.ClaimMinIdle <= 0 {
+		cfg.ClaimMinIdle = defaultClaimMinIdle
+	}
+
+	return &Consumer{
+		redis:   redis,
+		logger:  logger,
+		handler: handler,
+		cfg:     cfg,
+	}, nil
+}
+
+// Run consumes events until ctx is cancelled
+func (c *Consumer) Run(ctx context.Context) error {
+	if err := c.redis.EnsureConsumerGroup(ctx, c.cfg.Stream, c.cfg.Group); err != nil {
+		return err
+	}
+
+	c.logger.Infow("Event consumer started", "stream", c.cfg.Stream, "group", c.cfg.Group, "consumer", c.cfg.Consumer)
+
+	claimCursor := "0-0"
+	lastClaim := time.Time{}
+
+	for ctx.Err() == nil {
+		// Periodically pick up entries abandoned by other group members,
+		// including our own from before a restart
+		if time.Since(lastClaim) >= c.cfg.ClaimMinIdle {
+			messages, next, err := c.redis.ClaimStale(ctx, c.cfg.Stream, c.cfg.Group, c.cfg.Consumer, c.cfg.ClaimMinIdle, claimCursor, c.cfg.BatchSize)
+			if err != nil {
+				c.logger.Errorw("Failed to claim stale events", "error", err)
+			} else {
+				c.process(ctx, messages)
+				claimCursor = next
+				if claimCursor == "0-0" {
+					lastClaim = time.Now()
+				}
+			}
+		}
+
+		messages, err := c.redis.ReadGroup(ctx, c.cfg.Stream, c.cfg.Group, c.cfg.Consumer, c.cfg.BatchSize, c.cfg.Block)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			c.logger.Errorw("Failed to read events", "error", err)
+			time.Sleep(errorBackoff)
+			continue
+		}
+		c.process(ctx, messages)
+	}
+
+	c.logger.Infow("Event consumer stopped", "group", c.cfg.Group, "consumer", c.cfg.Consumer)
+	return nil
+}
+
+func (c *Consumer) process(ctx context.Context, messages []redis.XMessage) {
+	for _, msg := range messages {
+		event, err := parseEnvelope(msg)
+		if err != nil {
+			// A malformed entry will never succeed, acknowledge it so it
+			// does not block the group forever
+			c.logger.Errorw("Dropping malformed event", "stream_id", msg.ID, "error", err)
+			c.ack(ctx, msg.ID)
+			continue
+		}
+
+		if err := c.handler(ctx, event); err != nil {
+			c.logger.Warnw("Event handler failed, will retry", "stream_id", msg.ID, "type", event.Type, "error", err)
+			continue
+		}
+		c.ack(ctx, msg.ID)
+	}
+}
+
+func (c *Consumer) ack(ctx context.Context, id string) {
+	if err := c.redis.Ack(ctx, c.cfg.Stream, c.cfg.Group, id); err != nil {
+		c.logger.Errorw("Failed to acknowledge event", "stream_id", id, "error", err)
+	}
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Richard-inter/game/internal/domain"
+)
+
+// DefaultStream is the Redis stream domain events are relayed to
+const DefaultStream = "game:events"
+
+// Stream entry field names
+const (
+	fieldEventID     = "event_id"
+	fieldType        = "type"
+	fieldAggregateID = "aggregate_id"
+	fieldOccurredAt  = "occurred_at"
+	fieldPayload     = "payload"
+)
+
+// Envelope is a domain event as delivered to consumers. EventID is the
+// outbox row ID and stays the same across redeliveries, so consumers can use
+// it to drop duplicates.
+type Envelope struct {
+	StreamID    string
+	EventID     int64
+	Type        domain.EventType
+	AggregateID int64
+	OccurredAt  time.Time
+	Payload     json.RawMessage
+}
+
+// Decode unmarshals the payload into the typed event
+func (e *Envelope) Decode(dest domain.Event) error {
+	if dest.EventType() != e.Type {
+		return fmt.Errorf("cannot decode %s event into %s", e.Type, dest.EventType())
+	}
+	return json.Unmarshal(e.Payload, dest)
+}
+
+func streamValues(event *domain.OutboxEvent) map[string]any {
+	return map[string]any{
+		fieldEventID:     event.ID,
+		fieldType:        string(event.EventType),
+		fieldAggregateID: event.AggregateID,
+		fieldOccurredAt:  event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		fieldPayload:     event.Payload,
+	}
+}
+
+func parseEnvelope(msg redis.XMessage) (*Envelope, error) {
+	str := func(key string) string {
+		v, _ := msg.Values[key].(string)
+		return v
+	}
+
+	eventID, err := strconv.ParseInt(str(fieldEventID), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event id in stream entry %s: %w", msg.ID, err)
+	}
+
+	aggregateID, err := strconv.ParseInt(str(fieldAggregateID), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregate id in stream entry %s: %w", msg.ID, err)
+	}
+
+	occurredAt, err := time.Parse(time.RFC3339Nano, str(fieldOccurredAt))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp in stream entry %s: %w", msg.ID, err)
+	}
+
+	return &Envelope{
+		StreamID:    msg.ID,
+		EventID:     eventID,
+		Type:        domain.EventType(str(fieldType)),
+		AggregateID: aggregateID,
+		OccurredAt:  occurredAt,
+		Payload:     json.RawMessage(str(fieldPayload)),
+	}, nil
+}
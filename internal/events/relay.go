@@ -0,0 +1,126 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/cache"
+	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/internal/repository"
+)
+
+const (
+	defaultRelayInterval  = time.Second
+	defaultRelayBatchSize = 100
+
+	// pruneInterval is how often published events past their retention are
+	// deleted, in batches of pruneBatchSize
+	pruneInterval  = time.Minute
+	pruneBatchSize = 1000
+)
+
+type RelayConfig struct {
+	Stream    string
+	MaxLen    int64
+	Interval  time.Duration
+	BatchSize int
+	Retention time.Duration // how long published events are kept, 0 keeps them
+}
+
+// Relay moves events from the transactional outbox to the Redis stream.
+// An event is only marked as published after it was appended, so a crash in
+// between leads to a duplicate on the stream rather than a lost event.
+type Relay struct {
+	repo   repository.OutboxRepository
+	redis  *cache.RedisClient
+	logger *zap.SugaredLogger
+	cfg    RelayConfig
+}
+
+func NewRelay(repo repository.OutboxRepository, redis *cache.RedisClient, logger *zap.SugaredLogger, cfg RelayConfig) *Relay {
+	if cfg.Stream == "" {
+		cfg.Stream = DefaultStream
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultRelayInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultRelayBatchSize
+	}
+
+	return &Relay{
+		repo:   repo,
+		redis:  redis,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// Run relays events until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	r.logger.Infow("Outbox relay started", "stream", r.cfg.Stream, "interval", r.cfg.Interval, "retention", r.cfg.Retention)
+
+	var lastPrune time.Time
+
+	for {
+		// Drain the backlog before waiting for the next tick
+		for {
+			n, err := r.RelayOnce(ctx)
+			if err != nil {
+				r.logger.Errorw("Failed to relay outbox events", "error", err)
+				break
+			}
+			if n < r.cfg.BatchSize {
+				break
+			}
+		}
+
+		if r.cfg.Retention > 0 && time.Since(lastPrune) >= pruneInterval {
+			lastPrune = time.Now()
+			if _, err := r.PruneOnce(lastPrune); err != nil {
+				r.logger.Errorw("Failed to prune published outbox events", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Infow("Outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes a single batch and returns how many events were relayed
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	return r.repo.RelayPendingEvents(r.cfg.BatchSize, func(event *domain.OutboxEvent) error {
+		_, err := r.redis.AppendToStream(ctx, r.cfg.Stream, r.cfg.MaxLen, streamValues(event))
+		return err
+	})
+}
+
+// PruneOnce deletes the events published longer than the retention before now
+// and returns how many were removed
+func (r *Relay) PruneOnce(now time.Time) (int64, error) {
+	if r.cfg.Retention <= 0 {
+		return 0, nil
+	}
+
+	before := now.Add(-r.cfg.Retention)
+	var pruned int64
+	for {
+		n, err := r.repo.PrunePublishedEvents(before, pruneBatchSize)
+		pruned += n
+		if err != nil {
+			return pruned, err
+		}
+		if n < pruneBatchSize {
+			return pruned, nil
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/domain"
+)
+
+// pruneRepo deletes from a fixed number of prunable rows
+type pruneRepo struct {
+	prunable int64
+	err      error
+	before   []time.Time
+}
+
+func (r *pruneRepo) RelayPendingEvents(int, func(*domain.OutboxEvent) error) (int, error) {
+	return 0, nil
+}
+
+func (r *pruneRepo) PrunePublishedEvents(before time.Time, limit int) (int64, error) {
+	r.before = append(r.before, before)
+	if r.err != nil {
+		return 0, r.err
+	}
+	n := min(r.prunable, int64(limit))
+	r.prunable -= n
+	return n, nil
+}
+
+func TestRelayPruneOnce(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	errDB := errors.New("db down")
+
+	tests := []struct {
+		name       string
+		retention  time.Duration
+		prunable   int64
+		err        error
+		wantPruned int64
+		wantCalls  int
+	}{
+		{"one batch", 72 * time.Hour, 10, nil, 10, 1},
+		// a full batch means more rows may be waiting
+		{"several batches", 72 * time.Hour, 2*pruneBatchSize + 1, nil, 2*pruneBatchSize + 1, 3},
+		{"exact batch", 72 * time.Hour, pruneBatchSize, nil, pruneBatchSize, 2},
+		{"retention disabled", 0, 10, nil, 0, 0},
+		{"error", 72 * time.Hour, 10, errDB, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &pruneRepo{prunable: tt.prunable, err: tt.err}
+			relay := NewRelay(repo, nil, zap.NewNop().Sugar(), RelayConfig{Retention: tt.retention})
+
+			pruned, err := relay.PruneOnce(now)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("PruneOnce() error = %v, want %v", err, tt.err)
+			}
+			if pruned != tt.wantPruned {
+				t.Errorf("pruned = %d, want %d", pruned, tt.wantPruned)
+			}
+			if len(repo.before) != tt.wantCalls {
+				t.Fatalf("PrunePublishedEvents called %d times, want %d", len(repo.before), tt.wantCalls)
+			}
+			for _, before := range repo.before {
+				if want := now.Add(-tt.retention); !before.Equal(want) {
+					t.Errorf("pruned before %s, want %s", before, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/cache"
+)
+
+type SubscriberConfig struct {
+	Stream    string
+	BatchSize int64
+	Block     time.Duration
+}
+
+// Subscriber reads live events without a consumer group, so it leaves no
+// state in Redis behind: every subscriber sees each event appended after it
+// started, once. Events appended while it is down are not replayed and a
+// failed handler is not retried, which suits fan-out to whoever is
+// connected right now. Work that must not be lost belongs in a Consumer.
+type Subscriber struct {
+	redis   *cache.RedisClient
+	logger  *zap.SugaredLogger
+	handler Handler
+	cfg     SubscriberConfig
+}
+
+func NewSubscriber(redis *cache.RedisClient, logger *zap.SugaredLogger, cfg SubscriberConfig, handler Handler) *Subscriber {
+	if cfg.Stream == "" {
+		cfg.Stream = DefaultStream
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultConsumerBatchSize
+	}
+	if cfg.Block <= 0 {
+		cfg.Block = defaultConsumerBlock
+	}
+
+	return &Subscriber{
+		redis:   redis,
+		logger:  logger,
+		handler: handler,
+		cfg:     cfg,
+	}
+}
+
+// Run delivers events appended from now on until ctx is cancelled. Redis
+// being unavailable, at start or later, is retried until ctx is cancelled.
+func (s *Subscriber) Run(ctx context.Context) error {
+	last, ok := s.start(ctx)
+	if !ok {
+		return nil
+	}
+
+	s.logger.Infow("Event subscriber started", "stream", s.cfg.Stream, "after", last)
+
+	for ctx.Err() == nil {
+		messages, err := s.redis.ReadStream(ctx, s.cfg.Stream, last, s.cfg.BatchSize, s.cfg.Block)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			s.logger.Errorw("Failed to read events", "error", err)
+			time.Sleep(errorBackoff)
+			continue
+		}
+
+		for _, msg := range messages {
+			last = msg.ID
+
+			event, err := parseEnvelope(msg)
+			if err != nil {
+				s.logger.Errorw("Dropping malformed event", "stream_id", msg.ID, "error", err)
+				continue
+			}
+			if err := s.handler(ctx, event); err != nil {
+				s.logger.Warnw("Event handler failed", "stream_id", msg.ID, "type", event.Type, "error", err)
+			}
+		}
+	}
+
+	s.logger.Infow("Event subscriber stopped", "stream", s.cfg.Stream)
+	return nil
+}
+
+// start finds the newest entry to read after, retrying with backoff. It
+// starts from there rather than "$", which would skip entries appended
+// between two reads. It reports false when ctx is cancelled first.
+func (s *Subscriber) start(ctx context.Context) (string, bool) {
+	backoff := errorBackoff
+	for {
+		last, err := s.redis.LastStreamID(ctx, s.cfg.Stream)
+		if err == nil {
+			return last, true
+		}
+		if ctx.Err() != nil {
+			return "", false
+		}
+
+		s.logger.Errorw("Failed to find the end of the event stream", "stream", s.cfg.Stream, "retry_in", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return "", false
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxErrorBackoff)
+	}
+}
@@ -0,0 +1,109 @@
+package events
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/Richard-inter/game/internal/cache"
+	"github.com/Richard-inter/game/internal/domain"
+)
+
+// freeAddr returns a loopback address nothing listens on
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	return addr
+}
+
+func TestSubscriberWaitsForRedis(t *testing.T) {
+	addr := freeAddr(t)
+	redisClient := cache.NewRedisClient(addr, "")
+
+	core, logs := observer.New(zapcore.ErrorLevel)
+	received := make(chan *Envelope, 1)
+	subscriber := NewSubscriber(redisClient, zap.New(core).Sugar(), SubscriberConfig{Block: 100 * time.Millisecond},
+		func(_ context.Context, event *Envelope) error {
+			received <- event
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- subscriber.Run(ctx) }()
+
+	// Redis comes up only after the subscriber has failed to reach it
+	for logs.FilterMessage("Failed to find the end of the event stream").Len() == 0 {
+		select {
+		case err := <-stopped:
+			t.Fatalf("Run() returned %v while Redis was down", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	server := miniredis.NewMiniRedis()
+	if err := server.StartAddr(addr); err != nil {
+		t.Fatalf("failed to start redis: %v", err)
+	}
+	defer server.Close()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		appended, err := redisClient.AppendToStream(ctx, DefaultStream, 0, streamValues(&domain.OutboxEvent{
+			ID: 2, EventType: domain.EventTypeGameSettled, AggregateID: 7, CreatedAt: time.Now(), Payload: "{}",
+		}))
+		if err != nil {
+			t.Fatalf("failed to append event: %v", err)
+		}
+
+		select {
+		case event := <-received:
+			if event.EventID != 2 || event.StreamID != appended {
+				t.Fatalf("received event %d (%s), want 2 (%s)", event.EventID, event.StreamID, appended)
+			}
+			cancel()
+			if err := <-stopped; err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+			return
+		case <-time.After(200 * time.Millisecond):
+			// the subscriber may still be backing off; append another
+		case <-deadline:
+			t.Fatal("no event delivered after Redis came up")
+		}
+	}
+}
+
+func TestSubscriberStopsWhileRetrying(t *testing.T) {
+	redisClient := cache.NewRedisClient(freeAddr(t), "")
+	subscriber := NewSubscriber(redisClient, zap.NewNop().Sugar(), SubscriberConfig{}, func(context.Context, *Envelope) error {
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- subscriber.Run(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() kept retrying after ctx was cancelled")
+	}
+}
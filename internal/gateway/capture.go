@@ -0,0 +1,258 @@
+package gateway
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/config"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+const (
+	// CaptureFormatVersion is written in every capture header
+	CaptureFormatVersion = 1
+
+	// CaptureInbound marks envelopes sent by the client
+	CaptureInbound = "in"
+	// CaptureOutbound marks envelopes sent to the client
+	CaptureOutbound = "out"
+
+	defaultCaptureMaxBytes = 16 * 1024 * 1024
+)
+
+// CaptureHeader is the first line of a capture file. It holds what a replay
+// needs to open an equivalent session.
+type CaptureHeader struct {
+	FormatVersion   int       `json:"format_version"`
+	SessionID       uint64    `json:"session_id"`
+	PlayerID        int64     `json:"player_id"`
+	Transport       string    `json:"transport"`
+	ProtocolVersion uint32    `json:"protocol_version"`
+	ClientBuild     string    `json:"client_build,omitempty"`
+	Features        []string  `json:"features,omitempty"`
+	StartedAt       time.Time `json:"started_at"`
+}
+
+// CaptureRecord is one envelope of a captured session. Type and RequestID
+// repeat what the envelope holds so captures can be read without decoding.
+type CaptureRecord struct {
+	Time      time.Time `json:"time"`
+	Direction string    `json:"dir"`
+	Type      string    `json:"type"`
+	RequestID uint64    `json:"request_id"`
+	Envelope  []byte    `json:"envelope"`
+}
+
+// Capture is a whole capture file
+type Capture struct {
+	Header  CaptureHeader
+	Records []CaptureRecord
+}
+
+// ReadCapture loads a capture file written by a Capturer
+func ReadCapture(path string) (*Capture, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open capture: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read capture: %w", err)
+		}
+		return nil, fmt.Errorf("capture %s is empty", path)
+	}
+
+	capture := &Capture{}
+	if err := json.Unmarshal(scanner.Bytes(), &capture.Header); err != nil {
+		return nil, fmt.Errorf("invalid capture header: %w", err)
+	}
+	if capture.Header.FormatVersion != CaptureFormatVersion {
+		return nil, fmt.Errorf("unsupported capture format version %d", capture.Header.FormatVersion)
+	}
+
+	for line := 2; scanner.Scan(); line++ {
+		var record CaptureRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("invalid capture record on line %d: %w", line, err)
+		}
+		// malformed frames were recorded too, so only an empty one is invalid
+		if len(record.Envelope) == 0 {
+			return nil, fmt.Errorf("capture record on line %d has no envelope", line)
+		}
+		capture.Records = append(capture.Records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read capture: %w", err)
+	}
+
+	return capture, nil
+}
+
+// Capturer records the traffic of selected sessions to disk, one JSON Lines
+// file per session. A nil Capturer records nothing.
+type Capturer struct {
+	dir      string
+	players  []int64
+	maxBytes int64
+	logger   *zap.SugaredLogger
+}
+
+func NewCapturer(cfg config.CaptureConfig, logger *zap.SugaredLogger) (*Capturer, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create capture directory: %w", err)
+	}
+
+	c := &Capturer{
+		dir:      cfg.Dir,
+		players:  cfg.PlayerIDs,
+		maxBytes: cfg.MaxBytes,
+		logger:   logger,
+	}
+	if c.maxBytes <= 0 {
+		c.maxBytes = defaultCaptureMaxBytes
+	}
+
+	logger.Infow("Capturing gateway sessions", "dir", cfg.Dir, "players", cfg.PlayerIDs)
+	return c, nil
+}
+
+// selects reports whether sessions of the player are captured; an empty
+// player list captures every session
+func (c *Capturer) selects(playerID int64) bool {
+	return c != nil && (len(c.players) == 0 || slices.Contains(c.players, playerID))
+}
+
+// open starts the capture of a session, or returns nil when the session is
+// not selected or its file cannot be created
+func (c *Capturer) open(session *Session) *recorder {
+	if !c.selects(session.PlayerID) {
+		return nil
+	}
+
+	startedAt := time.Now().UTC()
+	name := fmt.Sprintf("%s-%d-%d-%s.jsonl", session.Transport, session.PlayerID, session.ID, startedAt.Format("20060102T150405.000"))
+	path := filepath.Join(c.dir, name)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		c.logger.Errorw("Failed to create capture", "session_id", session.ID, "path", path, "error", err)
+		return nil
+	}
+
+	header := CaptureHeader{
+		FormatVersion:   CaptureFormatVersion,
+		SessionID:       session.ID,
+		PlayerID:        session.PlayerID,
+		Transport:       session.Transport,
+		ProtocolVersion: session.Client.ProtocolVersion,
+		ClientBuild:     session.Client.Build,
+		StartedAt:       startedAt,
+	}
+	for feature, enabled := range session.Client.Features {
+		if enabled {
+			header.Features = append(header.Features, feature)
+		}
+	}
+	slices.Sort(header.Features)
+
+	r := &recorder{
+		path:     path,
+		file:     file,
+		writer:   bufio.NewWriter(file),
+		maxBytes: c.maxBytes,
+		logger:   c.logger,
+	}
+	r.writeLine(header)
+
+	c.logger.Infow("Capturing session", "session_id", session.ID, "player_id", session.PlayerID, "path", path)
+	return r
+}
+
+// recorder appends the envelopes of one session to its capture file. It
+// stops once the file reaches its size limit or a write fails.
+type recorder struct {
+	mu       sync.Mutex
+	path     string
+	file     *os.File
+	writer   *bufio.Writer
+	written  int64
+	maxBytes int64
+	stopped  bool
+	logger   *zap.SugaredLogger
+}
+
+func (r *recorder) record(direction string, envelope []byte) {
+	line := CaptureRecord{
+		Time:      time.Now().UTC(),
+		Direction: direction,
+		Envelope:  envelope,
+	}
+	// malformed frames are kept as they came, without a type to read
+	if ValidateEnvelope(envelope) == nil {
+		env := fbs.GetRootAsEnvelope(envelope, 0)
+		line.Type = env.Type().String()
+		line.RequestID = env.RequestId()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.writeLine(line)
+}
+
+// writeLine must be called with mu held, or before the recorder is shared
+func (r *recorder) writeLine(v any) {
+	if r.stopped {
+		return
+	}
+
+	line, err := json.Marshal(v)
+	if err != nil {
+		r.logger.Errorw("Failed to encode capture record", "path", r.path, "error", err)
+		return
+	}
+
+	if r.written+int64(len(line))+1 > r.maxBytes {
+		r.logger.Warnw("Capture reached its size limit", "path", r.path, "max_bytes", r.maxBytes)
+		r.stopped = true
+		return
+	}
+
+	line = append(line, '\n')
+	if _, err := r.writer.Write(line); err == nil {
+		err = r.writer.Flush()
+	}
+	if err != nil {
+		r.logger.Errorw("Failed to write capture", "path", r.path, "error", err)
+		r.stopped = true
+		return
+	}
+	r.written += int64(len(line))
+}
+
+func (r *recorder) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopped = true
+	if err := r.file.Close(); err != nil {
+		r.logger.Errorw("Failed to close capture", "path", r.path, "error", err)
+	}
+}
@@ -0,0 +1,136 @@
+package gateway
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/config"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+func TestCaptureRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	capturer, err := NewCapturer(config.CaptureConfig{Enabled: true, Dir: dir, PlayerIDs: []int64{42}}, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("NewCapturer() error = %v", err)
+	}
+
+	registry := NewRegistry()
+	registry.SetCapturer(capturer)
+	router := NewRouter(zap.NewNop().Sugar())
+	router.Register(fbs.MessageTypeGetPlayerInfoWsReq, 1, 0, echo)
+
+	client := ClientInfo{ProtocolVersion: ProtocolVersion, Build: "web-1.0", Features: map[string]bool{FeaturePush: true, FeatureResume: true}}
+	session := registry.Register(newTestConn(), 42, client, "websocket")
+	// other players are not selected
+	registry.Unregister(registry.Register(newTestConn(), 43, client, "websocket"))
+
+	router.Serve(context.Background(), session, request(fbs.MessageTypeGetPlayerInfoWsReq, 5, []byte("hi")))
+	router.reject(session, []byte{1, 2}, ValidateEnvelope([]byte{1, 2}))
+	registry.Unregister(session)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if len(files) != 1 {
+		t.Fatalf("capture files = %v, want one for player 42", files)
+	}
+
+	capture, err := ReadCapture(files[0])
+	if err != nil {
+		t.Fatalf("ReadCapture() error = %v", err)
+	}
+
+	header := capture.Header
+	if header.PlayerID != 42 || header.SessionID != session.ID || header.Transport != "websocket" ||
+		header.ProtocolVersion != ProtocolVersion || header.ClientBuild != "web-1.0" ||
+		!slices.Equal(header.Features, []string{FeaturePush, FeatureResume}) {
+		t.Errorf("header = %+v", header)
+	}
+
+	want := []struct {
+		direction string
+		msgType   string
+		requestID uint64
+	}{
+		{CaptureInbound, "GetPlayerInfoWsReq", 5},
+		{CaptureOutbound, "GetPlayerInfoWsResp", 5},
+		// malformed frames are kept without a type
+		{CaptureInbound, "", 0},
+		{CaptureOutbound, "ErrorResp", 0},
+	}
+	if len(capture.Records) != len(want) {
+		t.Fatalf("records = %d, want %d", len(capture.Records), len(want))
+	}
+	for i, w := range want {
+		r := capture.Records[i]
+		if r.Direction != w.direction || r.Type != w.msgType || r.RequestID != w.requestID {
+			t.Errorf("record %d = %s %s #%d, want %s %s #%d", i, r.Direction, r.Type, r.RequestID, w.direction, w.msgType, w.requestID)
+		}
+	}
+}
+
+func TestCaptureSizeLimit(t *testing.T) {
+	dir := t.TempDir()
+	capturer, err := NewCapturer(config.CaptureConfig{Enabled: true, Dir: dir, MaxBytes: 1024}, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("NewCapturer() error = %v", err)
+	}
+
+	registry := NewRegistry()
+	registry.SetCapturer(capturer)
+	session := registry.Register(newTestConn(), 7, ClientInfo{ProtocolVersion: ProtocolVersion}, "tcp")
+	for i := 0; i < 50; i++ {
+		_ = session.Send(BuildEnvelope(fbs.EnvelopeKindPush, fbs.MessageTypeNotificationPush, 0, []byte("a notification payload")))
+	}
+	registry.Unregister(session)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if len(files) != 1 {
+		t.Fatalf("capture files = %v, want one", files)
+	}
+	info, err := os.Stat(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > 1024 {
+		t.Errorf("capture is %d bytes, over its 1024 byte limit", info.Size())
+	}
+
+	// whatever was written before the limit is still a valid capture
+	capture, err := ReadCapture(files[0])
+	if err != nil {
+		t.Fatalf("ReadCapture() error = %v", err)
+	}
+	if len(capture.Records) == 0 || len(capture.Records) == 50 {
+		t.Errorf("records = %d, want some but not all", len(capture.Records))
+	}
+}
+
+func TestReadCaptureRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"empty", ""},
+		{"bad header", "not json\n"},
+		{"newer format", `{"format_version":2,"player_id":1}` + "\n"},
+		{"bad record", `{"format_version":1,"player_id":1}` + "\n" + "{\n"},
+		{"record without envelope", `{"format_version":1,"player_id":1}` + "\n" + `{"dir":"in","type":"HelloReq"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "capture.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ReadCapture(path); err == nil {
+				t.Error("ReadCapture() accepted the capture")
+			}
+		})
+	}
+}
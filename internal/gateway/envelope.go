@@ -0,0 +1,140 @@
+package gateway
+
+import (
+	"errors"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// Envelope field slots in the vtable and the size of the value each holds
+var envelopeFields = []struct {
+	slot flatbuffers.VOffsetT
+	size flatbuffers.UOffsetT
+}{
+	{4, flatbuffers.SizeInt8},     // type
+	{6, flatbuffers.SizeUOffsetT}, // payload
+	{8, flatbuffers.SizeUint64},   // request_id
+	{10, flatbuffers.SizeInt8},    // kind
+}
+
+const envelopePayloadSlot = 6
+
+// ValidateEnvelope checks that every offset of an Envelope frame, from the
+// root table through its vtable and fields to the payload vector, points
+// inside the frame. The generated accessors do no bounds checks, so frames
+// from clients must pass it before anything reads them.
+func ValidateEnvelope(message []byte) error {
+	size := uint64(len(message))
+	if size < flatbuffers.SizeUOffsetT {
+		return errcode.New(errcode.InvalidArgument, "frame too short")
+	}
+	malformed := errcode.New(errcode.InvalidArgument, "malformed envelope")
+
+	root := uint64(flatbuffers.GetUOffsetT(message))
+	if root+flatbuffers.SizeSOffsetT > size {
+		return malformed
+	}
+
+	vtable := int64(root) - int64(flatbuffers.GetSOffsetT(message[root:]))
+	if vtable < 0 || uint64(vtable)+2*flatbuffers.SizeVOffsetT > size {
+		return malformed
+	}
+	vtableSize := uint64(flatbuffers.GetVOffsetT(message[vtable:]))
+	tableSize := uint64(flatbuffers.GetVOffsetT(message[vtable+flatbuffers.SizeVOffsetT:]))
+	if vtableSize < 2*flatbuffers.SizeVOffsetT || uint64(vtable)+vtableSize > size ||
+		tableSize < flatbuffers.SizeSOffsetT || root+tableSize > size {
+		return malformed
+	}
+
+	// field returns the offset of a field within the table, 0 when absent
+	field := func(slot flatbuffers.VOffsetT) uint64 {
+		if uint64(slot)+flatbuffers.SizeVOffsetT > vtableSize {
+			return 0
+		}
+		return uint64(flatbuffers.GetVOffsetT(message[uint64(vtable)+uint64(slot):]))
+	}
+
+	for _, f := range envelopeFields {
+		if o := field(f.slot); o != 0 && o+uint64(f.size) > tableSize {
+			return malformed
+		}
+	}
+
+	if o := field(envelopePayloadSlot); o != 0 {
+		at := root + o
+		vector := at + uint64(flatbuffers.GetUOffsetT(message[at:]))
+		if vector+flatbuffers.SizeUOffsetT > size {
+			return malformed
+		}
+		length := uint64(flatbuffers.GetUOffsetT(message[vector:]))
+		if vector+flatbuffers.SizeUOffsetT+length > size {
+			return malformed
+		}
+	}
+
+	return nil
+}
+
+// BuildEnvelope places an encoded table into an Envelope
+func BuildEnvelope(kind fbs.EnvelopeKind, msgType fbs.MessageType, requestID uint64, payload []byte) []byte {
+	envBuilder := flatbuffers.NewBuilder(len(payload) + 64)
+	payloadOffset := envBuilder.CreateByteVector(payload)
+
+	fbs.EnvelopeStart(envBuilder)
+	fbs.EnvelopeAddType(envBuilder, msgType)
+	fbs.EnvelopeAddPayload(envBuilder, payloadOffset)
+	fbs.EnvelopeAddRequestId(envBuilder, requestID)
+	fbs.EnvelopeAddKind(envBuilder, kind)
+	envOffset := fbs.EnvelopeEnd(envBuilder)
+	envBuilder.Finish(envOffset)
+
+	return envBuilder.FinishedBytes()
+}
+
+// AsResponse re-wraps an Envelope produced by a backend service as the
+// response to requestID, so clients can match replies that arrive out of order
+func AsResponse(envelope []byte, requestID uint64) []byte {
+	env := fbs.GetRootAsEnvelope(envelope, 0)
+	return BuildEnvelope(fbs.EnvelopeKindResponse, env.Type(), requestID, env.PayloadBytes())
+}
+
+// BuildErrorResp builds an ErrorResp Envelope for a catalogue code
+func BuildErrorResp(code errcode.Code, message string) []byte {
+	return BuildErrorEnvelope(errcode.New(code, message), 0)
+}
+
+// BuildErrorEnvelope builds the ErrorResp Envelope for err, with a retry
+// hint for rate limited requests
+func BuildErrorEnvelope(err *errcode.Error, retryAfter time.Duration) []byte {
+	builder := flatbuffers.NewBuilder(128)
+
+	msgOffset := builder.CreateString(err.PublicMessage())
+	codeOffset := builder.CreateString(string(err.Code))
+
+	fbs.ErrorRespStart(builder)
+	fbs.ErrorRespAddCode(builder, int32(err.Code.HTTPStatus()))
+	fbs.ErrorRespAddMessage(builder, msgOffset)
+	fbs.ErrorRespAddRetryAfterMs(builder, retryAfter.Milliseconds())
+	fbs.ErrorRespAddErrorCode(builder, codeOffset)
+	errorResp := fbs.ErrorRespEnd(builder)
+
+	builder.Finish(errorResp)
+
+	// Wrap error response in Envelope
+	return BuildEnvelope(fbs.EnvelopeKindResponse, fbs.MessageTypeErrorResp, 0, builder.FinishedBytes())
+}
+
+// ErrorResponse builds the ErrorResp answering requestID. Errors outside the
+// catalogue are reported as Internal, and a Rejection adds its retry hint.
+func ErrorResponse(err error, requestID uint64) []byte {
+	var retryAfter time.Duration
+	var rejection *Rejection
+	if errors.As(err, &rejection) {
+		retryAfter = rejection.RetryAfter
+	}
+	return AsResponse(BuildErrorEnvelope(errcode.FromGRPC(err), retryAfter), requestID)
+}
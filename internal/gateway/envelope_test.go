@@ -0,0 +1,55 @@
+package gateway
+
+import (
+	"bytes"
+	"testing"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+func TestBuildEnvelope(t *testing.T) {
+	payload := []byte("payload")
+	env := fbs.GetRootAsEnvelope(BuildEnvelope(fbs.EnvelopeKindPush, fbs.MessageTypeBalanceChangedPush, 7, payload), 0)
+
+	if env.Kind() != fbs.EnvelopeKindPush || env.Type() != fbs.MessageTypeBalanceChangedPush || env.RequestId() != 7 {
+		t.Errorf("got %s %s #%d, want Push BalanceChangedPush #7", env.Kind(), env.Type(), env.RequestId())
+	}
+	if !bytes.Equal(env.PayloadBytes(), payload) {
+		t.Errorf("payload = %q, want %q", env.PayloadBytes(), payload)
+	}
+}
+
+// Clients from before request IDs send Envelopes with neither field, which
+// read as request 0
+func TestEnvelopeWithoutRequestID(t *testing.T) {
+	builder := flatbuffers.NewBuilder(64)
+	payload := builder.CreateByteVector([]byte("payload"))
+	fbs.EnvelopeStart(builder)
+	fbs.EnvelopeAddType(builder, fbs.MessageTypeGetPlayerInfoWsReq)
+	fbs.EnvelopeAddPayload(builder, payload)
+	builder.Finish(fbs.EnvelopeEnd(builder))
+	message := builder.FinishedBytes()
+
+	if err := ValidateEnvelope(message); err != nil {
+		t.Fatalf("ValidateEnvelope() error = %v", err)
+	}
+	env := fbs.GetRootAsEnvelope(message, 0)
+	if env.Kind() != fbs.EnvelopeKindRequest || env.RequestId() != 0 {
+		t.Errorf("got %s #%d, want Request #0", env.Kind(), env.RequestId())
+	}
+}
+
+func TestAsResponse(t *testing.T) {
+	// backend services answer without the client's request ID
+	backend := BuildEnvelope(fbs.EnvelopeKindRequest, fbs.MessageTypeGetPlayerInfoWsResp, 0, []byte("payload"))
+
+	env := fbs.GetRootAsEnvelope(AsResponse(backend, 12), 0)
+	if env.Kind() != fbs.EnvelopeKindResponse || env.Type() != fbs.MessageTypeGetPlayerInfoWsResp || env.RequestId() != 12 {
+		t.Errorf("got %s %s #%d, want Response GetPlayerInfoWsResp #12", env.Kind(), env.Type(), env.RequestId())
+	}
+	if string(env.PayloadBytes()) != "payload" {
+		t.Errorf("payload = %q, want %q", env.PayloadBytes(), "payload")
+	}
+}
@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+const (
+	// ProtocolVersion is the newest protocol version the gateways speak.
+	// Bump it when message semantics change and register the new handlers
+	// next to the old ones until older clients are gone.
+	ProtocolVersion uint32 = 2
+	// MinProtocolVersion is the oldest version handlers are still registered
+	// for, accepted unless the gateway is configured otherwise
+	MinProtocolVersion uint32 = 1
+
+	// FeatureResume lets sessions be resumed after a reconnect
+	FeatureResume = "resume"
+	// FeaturePush delivers server-initiated pushes
+	FeaturePush = "push"
+
+	defaultHandshakeTimeout = 10 * time.Second
+	maxClientFeatures       = 32
+)
+
+// ClientInfo is what a client announced in its HelloReq, reduced to what the
+// server agreed to
+type ClientInfo struct {
+	ProtocolVersion uint32
+	Build           string
+	Features        map[string]bool
+}
+
+// HasFeature reports whether both sides support feature on this connection
+func (c ClientInfo) HasFeature(feature string) bool {
+	return c.Features[feature]
+}
+
+// Hello is an accepted HelloReq
+type Hello struct {
+	RequestID uint64
+	Client    ClientInfo
+	// Token authenticates transports that have no other place to carry it
+	Token string
+}
+
+// Handshake negotiates the protocol of new connections. Transports read the
+// first request of a connection, pass it to Accept and write back either the
+// rejection or the Welcome.
+type Handshake struct {
+	MinVersion uint32
+	Build      string
+	Features   []string
+	Timeout    time.Duration
+}
+
+func NewHandshake(minVersion uint32, timeout time.Duration, features []string) *Handshake {
+	if minVersion == 0 {
+		minVersion = MinProtocolVersion
+	}
+	if timeout <= 0 {
+		timeout = defaultHandshakeTimeout
+	}
+	return &Handshake{
+		MinVersion: minVersion,
+		Build:      "dev",
+		Features:   features,
+		Timeout:    timeout,
+	}
+}
+
+// Validate checks the handshake can accept any client at all
+func (hs *Handshake) Validate() error {
+	if hs.MinVersion > ProtocolVersion {
+		return fmt.Errorf("minimum protocol version %d is newer than supported version %d", hs.MinVersion, ProtocolVersion)
+	}
+	return nil
+}
+
+// Accept checks that message is a HelloReq speaking a supported protocol
+// version. A rejected client gets the returned ErrorResp before its
+// connection is closed.
+func (hs *Handshake) Accept(message []byte) (accepted *Hello, response []byte, err error) {
+	if err := ValidateEnvelope(message); err != nil {
+		return nil, ErrorResponse(err, 0), err
+	}
+
+	defer func() {
+		// the HelloReq payload is not validated, so a malformed one panics
+		if p := recover(); p != nil {
+			accepted = nil
+			err = errcode.New(errcode.InvalidArgument, "malformed HelloReq")
+			response = ErrorResponse(err, 0)
+		}
+	}()
+
+	envelope := fbs.GetRootAsEnvelope(message, 0)
+	requestID := envelope.RequestId()
+	if envelope.Type() != fbs.MessageTypeHelloReq || len(envelope.PayloadBytes()) == 0 {
+		err := errcode.New(errcode.UnsupportedVersion, "HelloReq must be the first request")
+		return nil, ErrorResponse(err, requestID), err
+	}
+
+	hello := fbs.GetRootAsHelloReq(envelope.PayloadBytes(), 0)
+	version := hello.ProtocolVersion()
+	if version < hs.MinVersion || version > ProtocolVersion {
+		err := errcode.Newf(errcode.UnsupportedVersion, "protocol version %d not supported, use %d to %d", version, hs.MinVersion, ProtocolVersion)
+		return nil, ErrorResponse(err, requestID), err
+	}
+
+	accepted = &Hello{
+		RequestID: requestID,
+		Client: ClientInfo{
+			ProtocolVersion: version,
+			Build:           string(hello.ClientBuild()),
+			Features:        make(map[string]bool),
+		},
+		Token: string(hello.Token()),
+	}
+	for i := 0; i < hello.FeaturesLength() && i < maxClientFeatures; i++ {
+		feature := string(hello.Features(i))
+		if slices.Contains(hs.Features, feature) {
+			accepted.Client.Features[feature] = true
+		}
+	}
+
+	return accepted, nil, nil
+}
+
+// Welcome builds the WelcomeResp answering an accepted HelloReq, listing
+// the requests the router serves at the negotiated version
+func (hs *Handshake) Welcome(hello *Hello, router *Router) []byte {
+	version := hello.Client.ProtocolVersion
+	welcome := &fbs.WelcomeRespT{
+		ProtocolVersion:    version,
+		MinProtocolVersion: hs.MinVersion,
+		MaxProtocolVersion: ProtocolVersion,
+		ServerBuild:        hs.Build,
+		Features:           make([]string, 0, len(hello.Client.Features)),
+		MessageTypes:       router.MessageTypes(version),
+	}
+	for _, feature := range hs.Features {
+		if hello.Client.Features[feature] {
+			welcome.Features = append(welcome.Features, feature)
+		}
+	}
+
+	builder := flatbuffers.NewBuilder(256)
+	builder.Finish(welcome.Pack(builder))
+	return BuildEnvelope(fbs.EnvelopeKindResponse, fbs.MessageTypeWelcomeResp, hello.RequestID, builder.FinishedBytes())
+}
@@ -0,0 +1,159 @@
+package gateway
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+func helloReq(version uint32, features ...string) []byte {
+	builder := flatbuffers.NewBuilder(128)
+	builder.Finish((&fbs.HelloReqT{ProtocolVersion: version, ClientBuild: "test", Features: features, Token: "t"}).Pack(builder))
+	return request(fbs.MessageTypeHelloReq, 1, builder.FinishedBytes())
+}
+
+func TestHandshakeVersions(t *testing.T) {
+	tests := []struct {
+		name       string
+		minVersion uint32
+		version    uint32
+		wantCode   errcode.Code // "" when the client is accepted
+	}{
+		{"newest", 0, ProtocolVersion, ""},
+		{"oldest by default", 0, MinProtocolVersion, ""},
+		{"before the oldest", 0, MinProtocolVersion - 1, errcode.UnsupportedVersion},
+		{"newer than the server", 0, ProtocolVersion + 1, errcode.UnsupportedVersion},
+		// a gateway can stop accepting old clients before their handlers go
+		{"below the configured minimum", ProtocolVersion, ProtocolVersion - 1, errcode.UnsupportedVersion},
+		{"at the configured minimum", ProtocolVersion, ProtocolVersion, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hs := NewHandshake(tt.minVersion, 0, nil)
+
+			hello, response, err := hs.Accept(helloReq(tt.version))
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("Accept() error = %v", err)
+				}
+				if hello.Client.ProtocolVersion != tt.version || hello.RequestID != 1 || hello.Token != "t" {
+					t.Errorf("accepted %+v", hello)
+				}
+				return
+			}
+
+			if code := errcode.From(err).Code; code != tt.wantCode {
+				t.Fatalf("Accept() error = %v, want %s", err, tt.wantCode)
+			}
+			envelope := fbs.GetRootAsEnvelope(response, 0)
+			if code := errorCode(envelope); code != tt.wantCode || envelope.RequestId() != 1 {
+				t.Errorf("response is %s #%d, want %s #1", code, envelope.RequestId(), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHandshakeFeatures(t *testing.T) {
+	// more features than are read, with a supported one past the cap
+	many := make([]string, 0, maxClientFeatures+1)
+	for i := 0; i < maxClientFeatures; i++ {
+		many = append(many, fmt.Sprintf("feature-%d", i))
+	}
+	many = append(many, FeatureResume)
+
+	tests := []struct {
+		name   string
+		server []string
+		client []string
+		want   []string // in the server's order
+	}{
+		{"both", []string{FeatureResume, FeaturePush}, []string{FeaturePush, FeatureResume}, []string{FeatureResume, FeaturePush}},
+		{"client only", []string{FeaturePush}, []string{FeatureResume, FeaturePush}, []string{FeaturePush}},
+		{"server only", []string{FeatureResume, FeaturePush}, []string{FeaturePush}, []string{FeaturePush}},
+		{"unknown to the server", []string{FeatureResume}, []string{"telepathy"}, []string{}},
+		{"none", []string{FeatureResume}, nil, []string{}},
+		{"past the cap", []string{FeatureResume}, many, []string{}},
+	}
+
+	router := NewRouter(zap.NewNop().Sugar())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hs := NewHandshake(0, 0, tt.server)
+
+			hello, _, err := hs.Accept(helloReq(ProtocolVersion, tt.client...))
+			if err != nil {
+				t.Fatalf("Accept() error = %v", err)
+			}
+			for _, feature := range []string{FeatureResume, FeaturePush} {
+				if hello.Client.HasFeature(feature) != slices.Contains(tt.want, feature) {
+					t.Errorf("HasFeature(%s) = %v", feature, hello.Client.HasFeature(feature))
+				}
+			}
+
+			envelope := fbs.GetRootAsEnvelope(hs.Welcome(hello, router), 0)
+			welcome := fbs.GetRootAsWelcomeResp(envelope.PayloadBytes(), 0).UnPack()
+			if !slices.Equal(welcome.Features, tt.want) {
+				t.Errorf("welcome features = %v, want %v", welcome.Features, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandshakeWelcome(t *testing.T) {
+	router := NewRouter(zap.NewNop().Sugar())
+	router.Register(fbs.MessageTypeStartClawGameReq, 1, 1, echo)
+	router.Register(fbs.MessageTypeClawInputReq, 2, 0, echo)
+
+	hs := NewHandshake(0, time.Second, nil)
+	hs.Build = "1.2.3"
+
+	tests := []struct {
+		version uint32
+		want    []fbs.MessageType
+	}{
+		{1, []fbs.MessageType{fbs.MessageTypeStartClawGameReq}},
+		{2, []fbs.MessageType{fbs.MessageTypeClawInputReq}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("v%d", tt.version), func(t *testing.T) {
+			hello, _, err := hs.Accept(helloReq(tt.version))
+			if err != nil {
+				t.Fatalf("Accept() error = %v", err)
+			}
+
+			envelope := fbs.GetRootAsEnvelope(hs.Welcome(hello, router), 0)
+			if envelope.Type() != fbs.MessageTypeWelcomeResp || envelope.Kind() != fbs.EnvelopeKindResponse || envelope.RequestId() != 1 {
+				t.Fatalf("got %s %s #%d, want WelcomeResp response #1", envelope.Kind(), envelope.Type(), envelope.RequestId())
+			}
+
+			welcome := fbs.GetRootAsWelcomeResp(envelope.PayloadBytes(), 0).UnPack()
+			if welcome.ProtocolVersion != tt.version ||
+				welcome.MinProtocolVersion != MinProtocolVersion ||
+				welcome.MaxProtocolVersion != ProtocolVersion ||
+				welcome.ServerBuild != "1.2.3" {
+				t.Errorf("welcome = %+v", welcome)
+			}
+			if !slices.Equal(welcome.MessageTypes, tt.want) {
+				t.Errorf("message types = %v, want %v", welcome.MessageTypes, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandshakeValidate(t *testing.T) {
+	if err := NewHandshake(ProtocolVersion, 0, nil).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := NewHandshake(ProtocolVersion+1, 0, nil).Validate(); err == nil {
+		t.Error("Validate() accepted a minimum no client can meet")
+	}
+}
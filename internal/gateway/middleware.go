@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// Logging logs every request with its outcome. Internal errors are logged
+// as errors, rejections the client caused only at debug level.
+func Logging(logger *zap.SugaredLogger) Middleware {
+	return func(msgType fbs.MessageType, next Handler) Handler {
+		return func(ctx context.Context, session *Session, payload []byte) ([]byte, error) {
+			start := time.Now()
+			response, err := next(ctx, session, payload)
+
+			fields := []any{
+				"type", msgType,
+				"session_id", session.ID,
+				"player_id", session.PlayerID,
+				"duration", time.Since(start),
+			}
+			switch {
+			case err == nil:
+				logger.Debugw("Request handled", fields...)
+			case errcode.FromGRPC(err).Code == errcode.Internal:
+				logger.Errorw("Error handling message", append(fields, "error", err)...)
+			default:
+				logger.Debugw("Request rejected", append(fields, "code", errcode.FromGRPC(err).Code, "error", err)...)
+			}
+
+			return response, err
+		}
+	}
+}
+
+// Authenticated forwards the session's player with every backend call, so
+// the payload cannot choose which player it acts for
+func Authenticated() Middleware {
+	return func(_ fbs.MessageType, next Handler) Handler {
+		return func(ctx context.Context, session *Session, payload []byte) ([]byte, error) {
+			if session.PlayerID <= 0 {
+				return nil, errcode.New(errcode.Unauthenticated, "")
+			}
+			return next(auth.NewOutgoingContext(ctx, session.PlayerID), session, payload)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/internal/events"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+	pb "github.com/Richard-inter/game/pkg/protocol/gateway"
+)
+
+// PushService delivers server-initiated messages to connected clients,
+// whichever transport they use. It backs the GatewayService gRPC endpoint
+// and the domain event consumer. Pushes only reach sessions held by this
+// gateway instance.
+type PushService struct {
+	pb.UnimplementedGatewayServiceServer
+	sessions *Registry
+	logger   *zap.SugaredLogger
+}
+
+func NewPushService(sessions *Registry, logger *zap.SugaredLogger) *PushService {
+	return &PushService{
+		sessions: sessions,
+		logger:   logger,
+	}
+}
+
+func (p *PushService) PushToPlayer(ctx context.Context, req *pb.PushToPlayerReq) (*pb.PushResp, error) {
+	if req.PlayerID <= 0 {
+		return nil, fmt.Errorf("invalid player ID")
+	}
+	if len(req.Envelope) == 0 {
+		return nil, fmt.Errorf("envelope is required")
+	}
+
+	return &pb.PushResp{
+		Delivered: p.send(p.sessions.PlayerSessions(req.PlayerID), req.Envelope),
+	}, nil
+}
+
+func (p *PushService) PushToRoom(ctx context.Context, req *pb.PushToRoomReq) (*pb.PushResp, error) {
+	if req.Room == "" {
+		return nil, fmt.Errorf("room is required")
+	}
+	if len(req.Envelope) == 0 {
+		return nil, fmt.Errorf("envelope is required")
+	}
+
+	return &pb.PushResp{
+		Delivered: p.send(p.sessions.RoomSessions(req.Room), req.Envelope),
+	}, nil
+}
+
+// HandleEvent turns domain events into client pushes. It is meant to be used
+// as an events.Handler; events nobody is connected for are simply dropped.
+func (p *PushService) HandleEvent(ctx context.Context, event *events.Envelope) error {
+	switch event.Type {
+	case domain.EventTypeBalanceChanged:
+		var changed domain.BalanceChangedEvent
+		if err := event.Decode(&changed); err != nil {
+			p.logger.Errorw("Dropping malformed event", "event_id", event.EventID, "error", err)
+			return nil
+		}
+		p.send(p.sessions.PlayerSessions(changed.PlayerID), buildBalanceChangedPush(&changed))
+	}
+
+	return nil
+}
+
+func (p *PushService) send(sessions []*Session, envelope []byte) int32 {
+	var delivered int32
+	for _, session := range sessions {
+		if !session.Client.HasFeature(FeaturePush) {
+			continue
+		}
+		if err := session.Send(envelope); err != nil {
+			p.logger.Infow("Failed to push message", "session_id", session.ID, "player_id", session.PlayerID, "error", err)
+			continue
+		}
+		delivered++
+	}
+	return delivered
+}
+
+func buildBalanceChangedPush(changed *domain.BalanceChangedEvent) []byte {
+	builder := flatbuffers.NewBuilder(128)
+	currencyOffset := builder.CreateString(changed.Currency)
+
+	fbs.BalanceChangedPushStart(builder)
+	fbs.BalanceChangedPushAddPlayerId(builder, uint64(changed.PlayerID))
+	fbs.BalanceChangedPushAddCurrency(builder, currencyOffset)
+	fbs.BalanceChangedPushAddDelta(builder, changed.Delta)
+	fbs.BalanceChangedPushAddBalance(builder, changed.Balance)
+	pushOffset := fbs.BalanceChangedPushEnd(builder)
+	builder.Finish(pushOffset)
+
+	return BuildEnvelope(fbs.EnvelopeKindPush, fbs.MessageTypeBalanceChangedPush, 0, builder.FinishedBytes())
+}
+
+// BuildNotificationPush wraps a notification in an Envelope ready to be sent
+// through PushToPlayer or PushToRoom
+func BuildNotificationPush(kind, title, body string) []byte {
+	builder := flatbuffers.NewBuilder(256)
+	kindOffset := builder.CreateString(kind)
+	titleOffset := builder.CreateString(title)
+	bodyOffset := builder.CreateString(body)
+
+	fbs.NotificationPushStart(builder)
+	fbs.NotificationPushAddKind(builder, kindOffset)
+	fbs.NotificationPushAddTitle(builder, titleOffset)
+	fbs.NotificationPushAddBody(builder, bodyOffset)
+	pushOffset := fbs.NotificationPushEnd(builder)
+	builder.Finish(pushOffset)
+
+	return BuildEnvelope(fbs.EnvelopeKindPush, fbs.MessageTypeNotificationPush, 0, builder.FinishedBytes())
+}
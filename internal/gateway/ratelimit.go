@@ -0,0 +1,181 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/cache"
+	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+const defaultViolationWindow = time.Minute
+
+type rateRule struct {
+	rate  float64
+	burst int
+}
+
+func (r rateRule) enabled() bool {
+	return r.rate > 0 && r.burst > 0
+}
+
+// RateLimiter applies token buckets to incoming messages: one per
+// connection, kept in memory, and one per player and message type, kept in
+// Redis so limits hold across gateway instances and transports. A nil
+// RateLimiter allows everything.
+type RateLimiter struct {
+	redis  *cache.RedisClient
+	logger *zap.SugaredLogger
+
+	defaultRule    rateRule
+	connectionRule rateRule
+	messageRules   map[fbs.MessageType]rateRule
+
+	maxViolations   int
+	violationWindow time.Duration
+}
+
+func NewRateLimiter(cfg config.RateLimitConfig, redis *cache.RedisClient, logger *zap.SugaredLogger) (*RateLimiter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	l := &RateLimiter{
+		redis:           redis,
+		logger:          logger,
+		defaultRule:     rateRule{rate: cfg.Default.Rate, burst: cfg.Default.Burst},
+		connectionRule:  rateRule{rate: cfg.Connection.Rate, burst: cfg.Connection.Burst},
+		messageRules:    make(map[fbs.MessageType]rateRule),
+		maxViolations:   cfg.MaxViolations,
+		violationWindow: time.Duration(cfg.ViolationWindow) * time.Second,
+	}
+	if l.violationWindow <= 0 {
+		l.violationWindow = defaultViolationWindow
+	}
+
+	// viper lowercases map keys, so match message type names case-insensitively
+	typesByName := make(map[string]fbs.MessageType, len(fbs.EnumValuesMessageType))
+	for name, msgType := range fbs.EnumValuesMessageType {
+		typesByName[strings.ToLower(name)] = msgType
+	}
+	for name, rule := range cfg.MessageTypes {
+		msgType, ok := typesByName[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("rate limit configured for unknown message type %q", name)
+		}
+		if rule.Rate < 0 || rule.Burst < 0 {
+			return nil, fmt.Errorf("rate limit for %s cannot be negative", name)
+		}
+		l.messageRules[msgType] = rateRule{rate: rule.Rate, burst: rule.Burst}
+	}
+
+	return l, nil
+}
+
+// Allow reports whether the session may send a message of msgType now and,
+// if not, how long until it may retry. Redis failures let the message through
+// rather than taking the gateway down with them.
+func (l *RateLimiter) Allow(ctx context.Context, session *Session, msgType fbs.MessageType) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+
+	if l.connectionRule.enabled() {
+		if ok, retryAfter := session.connectionBucket(l.connectionRule).take(time.Now()); !ok {
+			return false, retryAfter
+		}
+	}
+
+	rule, ok := l.messageRules[msgType]
+	if !ok {
+		rule = l.defaultRule
+	}
+	if !rule.enabled() || l.redis == nil {
+		return true, 0
+	}
+
+	key := fmt.Sprintf("gw:%d:%s", session.PlayerID, msgType)
+	allowed, retryAfter, err := l.redis.TakeToken(ctx, key, rule.rate, rule.burst)
+	if err != nil {
+		l.logger.Warnw("Rate limit check failed, allowing message", "player_id", session.PlayerID, "type", msgType, "error", err)
+		return true, 0
+	}
+
+	return allowed, retryAfter
+}
+
+// RecordViolation counts a rejected message and reports whether the session
+// has exceeded the allowed number of violations and should be disconnected
+func (l *RateLimiter) RecordViolation(session *Session) bool {
+	if l == nil || l.maxViolations <= 0 {
+		return false
+	}
+	return session.recordViolation(time.Now(), l.violationWindow) > l.maxViolations
+}
+
+// RateLimit rejects requests over the limiter's limits with a retry hint,
+// and disconnects sessions that keep exceeding them
+func RateLimit(l *RateLimiter) Middleware {
+	return func(msgType fbs.MessageType, next Handler) Handler {
+		if l == nil {
+			return next
+		}
+		return func(ctx context.Context, session *Session, payload []byte) ([]byte, error) {
+			allowed, retryAfter := l.Allow(ctx, session, msgType)
+			if allowed {
+				return next(ctx, session, payload)
+			}
+
+			rejection := &Rejection{
+				Err:        errcode.New(errcode.RateLimited, "Rate limit exceeded"),
+				RetryAfter: retryAfter,
+			}
+			if l.RecordViolation(session) {
+				rejection.Disconnect = "rate limit exceeded"
+			}
+			return nil, rejection
+		}
+	}
+}
+
+// tokenBucket is an in-memory token bucket for limits scoped to a single
+// connection
+type tokenBucket struct {
+	mu     sync.Mutex
+	rule   rateRule
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rule rateRule) *tokenBucket {
+	return &tokenBucket{
+		rule:   rule,
+		tokens: float64(rule.burst),
+		last:   time.Now(),
+	}
+}
+
+func (b *tokenBucket) take(now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+	b.tokens = math.Min(float64(b.rule.burst), b.tokens+elapsed*b.rule.rate)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := (1 - b.tokens) / b.rule.rate
+	return false, time.Duration(math.Ceil(wait*1000)) * time.Millisecond
+}
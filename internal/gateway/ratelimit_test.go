@@ -0,0 +1,188 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/cache"
+	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+func TestTokenBucket(t *testing.T) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	type take struct {
+		at        time.Time
+		allowed   bool
+		wantRetry time.Duration
+	}
+	tests := []struct {
+		name  string
+		rule  rateRule
+		takes []take
+	}{
+		{"burst then empty", rateRule{rate: 2, burst: 3}, []take{
+			{at(0), true, 0}, {at(0), true, 0}, {at(0), true, 0},
+			// one token takes 500ms at 2 per second
+			{at(0), false, 500 * time.Millisecond},
+			{at(250), false, 250 * time.Millisecond},
+			{at(500), true, 0},
+		}},
+		// an idle bucket refills only up to its burst
+		{"refill is capped", rateRule{rate: 10, burst: 2}, []take{
+			{at(0), true, 0}, {at(0), true, 0},
+			{at(60000), true, 0}, {at(60000), true, 0},
+			{at(60000), false, 100 * time.Millisecond},
+		}},
+		{"rejections do not cost tokens", rateRule{rate: 1, burst: 1}, []take{
+			{at(0), true, 0},
+			{at(100), false, 900 * time.Millisecond},
+			{at(200), false, 800 * time.Millisecond},
+			{at(1000), true, 0},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := newTokenBucket(tt.rule)
+			bucket.last = start
+			for i, take := range tt.takes {
+				allowed, retry := bucket.take(take.at)
+				if allowed != take.allowed || retry != take.wantRetry {
+					t.Errorf("take %d = %v, %s, want %v, %s", i, allowed, retry, take.allowed, take.wantRetry)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.RateLimitConfig
+		wantErr bool
+	}{
+		// viper lowercases the keys of message_types
+		{"lowercase type name", config.RateLimitConfig{Enabled: true, MessageTypes: map[string]config.RateLimitRule{"clawinputreq": {Rate: 1, Burst: 1}}}, false},
+		{"unknown type", config.RateLimitConfig{Enabled: true, MessageTypes: map[string]config.RateLimitRule{"TeleportReq": {Rate: 1, Burst: 1}}}, true},
+		{"negative rule", config.RateLimitConfig{Enabled: true, MessageTypes: map[string]config.RateLimitRule{"ClawInputReq": {Rate: -1, Burst: 1}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRateLimiter(tt.cfg, nil, zap.NewNop().Sugar())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRateLimiter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	limiter, err := NewRateLimiter(config.RateLimitConfig{}, nil, zap.NewNop().Sugar())
+	if err != nil || limiter != nil {
+		t.Fatalf("disabled NewRateLimiter() = %v, %v, want nil", limiter, err)
+	}
+	if allowed, _ := limiter.Allow(context.Background(), &Session{}, fbs.MessageTypeClawInputReq); !allowed {
+		t.Error("nil limiter rejected a message")
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	server := miniredis.RunT(t)
+	limiter, err := NewRateLimiter(config.RateLimitConfig{
+		Enabled: true,
+		// no bucket refills a whole token in the milliseconds the test takes
+		Default: config.RateLimitRule{Rate: 1, Burst: 2},
+		MessageTypes: map[string]config.RateLimitRule{
+			"ClawInputReq":       {Rate: 1, Burst: 4},
+			"GetPlayerInfoWsReq": {Rate: 0, Burst: 0},
+		},
+	}, cache.NewRedisClient(server.Addr(), ""), zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("NewRateLimiter() error = %v", err)
+	}
+
+	ctx := context.Background()
+	// allowed counts the messages of msgType let through out of n
+	allowed := func(session *Session, msgType fbs.MessageType, n int) int {
+		count := 0
+		for i := 0; i < n; i++ {
+			if ok, retry := limiter.Allow(ctx, session, msgType); ok {
+				count++
+			} else if retry <= 0 {
+				t.Errorf("rejected %s without a retry hint", msgType)
+			}
+		}
+		return count
+	}
+
+	first := &Session{ID: 1, PlayerID: 42}
+	if got := allowed(first, fbs.MessageTypeClawInputReq, 10); got != 4 {
+		t.Errorf("ClawInputReq allowed %d, want its burst of 4", got)
+	}
+	if got := allowed(first, fbs.MessageTypeRedeemVoucherReq, 10); got != 2 {
+		t.Errorf("RedeemVoucherReq allowed %d, want the default burst of 2", got)
+	}
+	// a zero rule leaves the type unlimited
+	if got := allowed(first, fbs.MessageTypeGetPlayerInfoWsReq, 10); got != 10 {
+		t.Errorf("GetPlayerInfoWsReq allowed %d, want 10", got)
+	}
+
+	// the bucket belongs to the player, not the connection
+	second := &Session{ID: 2, PlayerID: 42}
+	if got := allowed(second, fbs.MessageTypeClawInputReq, 1); got != 0 {
+		t.Errorf("another connection of the player allowed %d, want 0", got)
+	}
+	other := &Session{ID: 3, PlayerID: 43}
+	if got := allowed(other, fbs.MessageTypeClawInputReq, 1); got != 1 {
+		t.Errorf("another player allowed %d, want 1", got)
+	}
+
+	// without Redis, messages are let through rather than dropped
+	server.Close()
+	if got := allowed(other, fbs.MessageTypeClawInputReq, 1); got != 1 {
+		t.Errorf("allowed %d while Redis was down, want 1", got)
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limiter, err := NewRateLimiter(config.RateLimitConfig{
+		Enabled:         true,
+		Connection:      config.RateLimitRule{Rate: 0.01, Burst: 1},
+		MaxViolations:   2,
+		ViolationWindow: 60,
+	}, nil, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("NewRateLimiter() error = %v", err)
+	}
+
+	handler := RateLimit(limiter)(fbs.MessageTypeClawInputReq, echo)
+	session, _ := testSession(ProtocolVersion)
+
+	if _, err := handler(context.Background(), session, nil); err != nil {
+		t.Fatalf("first message rejected: %v", err)
+	}
+
+	// the connection bucket is empty; the third rejection exceeds the
+	// allowed violations and disconnects
+	for i, wantDisconnect := range []bool{false, false, true} {
+		_, err := handler(context.Background(), session, nil)
+		var rejection *Rejection
+		if !errors.As(err, &rejection) {
+			t.Fatalf("message %d error = %v, want a Rejection", i, err)
+		}
+		if rejection.Err.Code != errcode.RateLimited || rejection.RetryAfter <= 0 {
+			t.Errorf("rejection = %s retry %s", rejection.Err.Code, rejection.RetryAfter)
+		}
+		if (rejection.Disconnect != "") != wantDisconnect {
+			t.Errorf("message %d disconnect = %q, want %v", i, rejection.Disconnect, wantDisconnect)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package gateway
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// maxRoomsPerSession bounds how many rooms one connection may subscribe to
+const maxRoomsPerSession = 32
+
+// Registry tracks live sessions by authenticated player and by subscribed
+// room. A player may hold several connections at once, over any transport.
+type Registry struct {
+	nextID   atomic.Uint64
+	capturer atomic.Pointer[Capturer]
+
+	mu       sync.RWMutex
+	sessions map[*Session]struct{}
+	byPlayer map[int64]map[*Session]struct{}
+	rooms    map[string]map[*Session]struct{}
+	joined   map[*Session]map[string]struct{}
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		sessions: make(map[*Session]struct{}),
+		byPlayer: make(map[int64]map[*Session]struct{}),
+		rooms:    make(map[string]map[*Session]struct{}),
+		joined:   make(map[*Session]map[string]struct{}),
+	}
+}
+
+// Register creates the session of a connection that completed its handshake
+func (r *Registry) Register(conn Conn, playerID int64, client ClientInfo, transport string) *Session {
+	session := &Session{
+		ID:        r.nextID.Add(1),
+		PlayerID:  playerID,
+		Client:    client,
+		Transport: transport,
+		conn:      conn,
+	}
+	session.recorder = r.capturer.Load().open(session)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[session] = struct{}{}
+	if r.byPlayer[playerID] == nil {
+		r.byPlayer[playerID] = make(map[*Session]struct{})
+	}
+	r.byPlayer[playerID][session] = struct{}{}
+
+	return session
+}
+
+// SetCapturer records the sessions registered from now on that c selects;
+// nil stops capturing new sessions
+func (r *Registry) SetCapturer(c *Capturer) {
+	r.capturer.Store(c)
+}
+
+// Unregister forgets the session and all of its room subscriptions, and ends
+// its capture
+func (r *Registry) Unregister(session *Session) {
+	if session.recorder != nil {
+		session.recorder.close()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions, session)
+
+	if sessions := r.byPlayer[session.PlayerID]; sessions != nil {
+		delete(sessions, session)
+		if len(sessions) == 0 {
+			delete(r.byPlayer, session.PlayerID)
+		}
+	}
+
+	for room := range r.joined[session] {
+		r.leaveLocked(session, room)
+	}
+	delete(r.joined, session)
+}
+
+// Join subscribes the session to room. It reports false when the session
+// already holds the maximum number of subscriptions.
+func (r *Registry) Join(session *Session, room string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sessions[session]; !ok {
+		return false
+	}
+
+	rooms := r.joined[session]
+	if rooms == nil {
+		rooms = make(map[string]struct{})
+		r.joined[session] = rooms
+	}
+	if _, ok := rooms[room]; ok {
+		return true
+	}
+	if len(rooms) >= maxRoomsPerSession {
+		return false
+	}
+	rooms[room] = struct{}{}
+
+	if r.rooms[room] == nil {
+		r.rooms[room] = make(map[*Session]struct{})
+	}
+	r.rooms[room][session] = struct{}{}
+
+	return true
+}
+
+// Leave unsubscribes the session from room
+func (r *Registry) Leave(session *Session, room string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.leaveLocked(session, room)
+}
+
+func (r *Registry) leaveLocked(session *Session, room string) {
+	delete(r.joined[session], room)
+
+	if members := r.rooms[room]; members != nil {
+		delete(members, session)
+		if len(members) == 0 {
+			delete(r.rooms, room)
+		}
+	}
+}
+
+// Rooms returns the rooms the session is subscribed to
+func (r *Registry) Rooms(session *Session) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rooms := make([]string, 0, len(r.joined[session]))
+	for room := range r.joined[session] {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// PlayerSessions returns every live session of the player
+func (r *Registry) PlayerSessions(playerID int64) []*Session {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return collect(r.byPlayer[playerID])
+}
+
+// RoomSessions returns every session subscribed to room
+func (r *Registry) RoomSessions(room string) []*Session {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return collect(r.rooms[room])
+}
+
+// All returns every live session
+func (r *Registry) All() []*Session {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return collect(r.sessions)
+}
+
+// Count returns the number of live sessions
+func (r *Registry) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.sessions)
+}
+
+func collect(set map[*Session]struct{}) []*Session {
+	sessions := make([]*Session, 0, len(set))
+	for session := range set {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
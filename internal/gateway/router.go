@@ -0,0 +1,285 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+const defaultWorkersPerSession = 4
+
+// Handler serves one request and returns the response Envelope
+type Handler func(ctx context.Context, session *Session, payload []byte) ([]byte, error)
+
+// Middleware wraps the handler of msgType
+type Middleware func(msgType fbs.MessageType, next Handler) Handler
+
+// Rejection is a request error carrying hints for the transport: how long
+// the client should wait before retrying and whether its session is closed
+type Rejection struct {
+	Err        *errcode.Error
+	RetryAfter time.Duration
+	// Disconnect, when set, closes the session with this reason once the
+	// error has been queued
+	Disconnect string
+}
+
+func (r *Rejection) Error() string {
+	return r.Err.Error()
+}
+
+func (r *Rejection) Unwrap() error {
+	return r.Err
+}
+
+// versionedHandler serves a message type for clients speaking protocol
+// versions since through until; until 0 leaves the range open
+type versionedHandler struct {
+	since   uint32
+	until   uint32
+	handler Handler
+}
+
+func (v versionedHandler) supports(version uint32) bool {
+	return version >= v.since && (v.until == 0 || version <= v.until)
+}
+
+// Router dispatches request Envelopes to the handler registered for their
+// message type and the session's protocol version. Every transport serves
+// requests through the same Router, so a message type is registered once.
+type Router struct {
+	logger     *zap.SugaredLogger
+	handlers   map[fbs.MessageType][]versionedHandler
+	middleware []Middleware
+	// ordered message types are processed one at a time in arrival order
+	// instead of concurrently
+	ordered map[fbs.MessageType]bool
+}
+
+func NewRouter(logger *zap.SugaredLogger) *Router {
+	return &Router{
+		logger:   logger,
+		handlers: make(map[fbs.MessageType][]versionedHandler),
+		ordered:  make(map[fbs.MessageType]bool),
+	}
+}
+
+// Use appends middleware run around every handler, the first added being
+// the outermost
+func (r *Router) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// Register adds the handler of msgType for protocol versions since through
+// until, 0 meaning every later version. When a message changes in a new
+// protocol version the old handler gets an until and the new one is
+// registered next to it, so both generations of clients are served during a
+// rollout.
+func (r *Router) Register(msgType fbs.MessageType, since, until uint32, handler Handler) {
+	r.handlers[msgType] = append(r.handlers[msgType], versionedHandler{
+		since:   since,
+		until:   until,
+		handler: handler,
+	})
+}
+
+// Order makes requests of the given types apply in the order the client
+// sent them
+func (r *Router) Order(msgTypes ...fbs.MessageType) {
+	for _, msgType := range msgTypes {
+		r.ordered[msgType] = true
+	}
+}
+
+// Ordered reports whether requests of msgType must be processed in order
+func (r *Router) Ordered(msgType fbs.MessageType) bool {
+	return r.ordered[msgType]
+}
+
+// handlerFor returns the handler of msgType for a protocol version
+func (r *Router) handlerFor(msgType fbs.MessageType, version uint32) (Handler, bool) {
+	for _, v := range r.handlers[msgType] {
+		if v.supports(version) {
+			return v.handler, true
+		}
+	}
+	return nil, false
+}
+
+// MessageTypes lists the requests accepted at a protocol version
+func (r *Router) MessageTypes(version uint32) []fbs.MessageType {
+	var types []fbs.MessageType
+	for msgType := range r.handlers {
+		if _, ok := r.handlerFor(msgType, version); ok {
+			types = append(types, msgType)
+		}
+	}
+	slices.Sort(types)
+	return types
+}
+
+// Serve handles one request and queues its response, tagged with the
+// request's ID. Failures are reported as ErrorResp. message must already
+// have passed ValidateEnvelope, as Pipeline.Submit checks.
+func (r *Router) Serve(ctx context.Context, session *Session, message []byte) {
+	if session.recorder != nil {
+		session.recorder.record(CaptureInbound, message)
+	}
+
+	response, err := r.dispatch(ctx, session, message)
+
+	if sendErr := session.Send(response); sendErr != nil {
+		r.logger.Warnw("Failed to queue response", "session_id", session.ID, "error", sendErr)
+	}
+
+	var rejection *Rejection
+	if errors.As(err, &rejection) && rejection.Disconnect != "" {
+		r.logger.Warnw("Disconnecting session", "session_id", session.ID, "player_id", session.PlayerID, "reason", rejection.Disconnect)
+		_ = session.Disconnect(rejection.Disconnect)
+	}
+}
+
+// reject answers a frame that is not a valid Envelope
+func (r *Router) reject(session *Session, message []byte, err error) {
+	if session.recorder != nil {
+		session.recorder.record(CaptureInbound, message)
+	}
+
+	r.logger.Debugw("Malformed envelope", "session_id", session.ID, "size", len(message), "error", err)
+	if sendErr := session.Send(ErrorResponse(err, 0)); sendErr != nil {
+		r.logger.Warnw("Failed to queue response", "session_id", session.ID, "error", sendErr)
+	}
+}
+
+func (r *Router) dispatch(ctx context.Context, session *Session, message []byte) (response []byte, err error) {
+	var msgType fbs.MessageType
+	var requestID uint64
+
+	defer func() {
+		// a malformed Envelope or payload must only cost its own request
+		if p := recover(); p != nil {
+			r.logger.Errorw("Request handler panicked", "type", msgType, "request_id", requestID, "panic", p)
+			err = errcode.Wrap(errcode.Internal, fmt.Errorf("panic: %v", p), "")
+			response = ErrorResponse(err, requestID)
+		}
+	}()
+
+	envelope := fbs.GetRootAsEnvelope(message, 0)
+	msgType = envelope.Type()
+	requestID = envelope.RequestId()
+
+	payload := envelope.PayloadBytes()
+
+	handler, ok := r.handlerFor(msgType, session.Client.ProtocolVersion)
+	if !ok {
+		r.logger.Debugw("Unknown message type", "type", msgType, "request_id", requestID)
+		err := errcode.New(errcode.InvalidArgument, "Unknown message type")
+		return ErrorResponse(err, requestID), err
+	}
+
+	if len(payload) == 0 {
+		r.logger.Debugw("Empty payload", "type", msgType, "request_id", requestID)
+		err := errcode.New(errcode.InvalidArgument, "Empty payload")
+		return ErrorResponse(err, requestID), err
+	}
+
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](msgType, handler)
+	}
+
+	response, err = handler(ctx, session, payload)
+	if err != nil {
+		return ErrorResponse(err, requestID), err
+	}
+
+	return AsResponse(response, requestID), nil
+}
+
+// Pipeline runs the requests of one session. Requests run concurrently on a
+// bounded pool of workers, except ordered message types which share a
+// single lane. When every worker is busy Submit blocks, so the transport
+// stops reading and pushes back on the client.
+type Pipeline struct {
+	router  *Router
+	ctx     context.Context
+	session *Session
+
+	wg      sync.WaitGroup
+	workers chan struct{}
+	ordered chan []byte
+}
+
+// NewPipeline starts the pipeline of a session, running at most workers
+// requests at a time
+func (r *Router) NewPipeline(ctx context.Context, session *Session, workers int) *Pipeline {
+	if workers <= 0 {
+		workers = defaultWorkersPerSession
+	}
+
+	p := &Pipeline{
+		router:  r,
+		ctx:     ctx,
+		session: session,
+		workers: make(chan struct{}, workers),
+		ordered: make(chan []byte, workers),
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		for message := range p.ordered {
+			r.Serve(ctx, session, message)
+		}
+	}()
+
+	return p
+}
+
+// Submit schedules one request. A malformed Envelope is answered with an
+// ErrorResp right away and never reaches the handlers. It reports false
+// once the session is closed.
+func (p *Pipeline) Submit(message []byte) bool {
+	if err := ValidateEnvelope(message); err != nil {
+		p.router.reject(p.session, message, err)
+		return true
+	}
+
+	if p.router.Ordered(fbs.GetRootAsEnvelope(message, 0).Type()) {
+		select {
+		case p.ordered <- message:
+			return true
+		case <-p.session.Done():
+			return false
+		}
+	}
+
+	select {
+	case p.workers <- struct{}{}:
+	case <-p.session.Done():
+		return false
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() { <-p.workers }()
+		p.router.Serve(p.ctx, p.session, message)
+	}()
+
+	return true
+}
+
+// Close waits for the requests already submitted. Nothing may be submitted
+// afterwards.
+func (p *Pipeline) Close() {
+	close(p.ordered)
+	p.wg.Wait()
+}
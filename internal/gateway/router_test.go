@@ -0,0 +1,377 @@
+package gateway
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// testConn is a Conn that keeps what was sent to it
+type testConn struct {
+	mu   sync.Mutex
+	sent [][]byte
+	done chan struct{}
+}
+
+func newTestConn() *testConn {
+	return &testConn{done: make(chan struct{})}
+}
+
+func (c *testConn) Send(envelope []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, envelope)
+	return nil
+}
+
+func (c *testConn) Done() <-chan struct{} { return c.done }
+
+func (c *testConn) Close() error { return nil }
+
+func (c *testConn) envelopes() []*fbs.Envelope {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	envelopes := make([]*fbs.Envelope, len(c.sent))
+	for i, sent := range c.sent {
+		envelopes[i] = fbs.GetRootAsEnvelope(sent, 0)
+	}
+	return envelopes
+}
+
+func testSession(version uint32) (*Session, *testConn) {
+	conn := newTestConn()
+	return &Session{ID: 1, PlayerID: 42, Client: ClientInfo{ProtocolVersion: version}, conn: conn}, conn
+}
+
+func request(msgType fbs.MessageType, requestID uint64, payload []byte) []byte {
+	return BuildEnvelope(fbs.EnvelopeKindRequest, msgType, requestID, payload)
+}
+
+// echo answers with its payload as a GetPlayerInfoWsResp
+func echo(_ context.Context, _ *Session, payload []byte) ([]byte, error) {
+	return BuildEnvelope(fbs.EnvelopeKindResponse, fbs.MessageTypeGetPlayerInfoWsResp, 0, payload), nil
+}
+
+// errorCode returns the catalogue code of an ErrorResp Envelope, "" for any
+// other response
+func errorCode(envelope *fbs.Envelope) errcode.Code {
+	if envelope.Type() != fbs.MessageTypeErrorResp {
+		return ""
+	}
+	return errcode.Code(fbs.GetRootAsErrorResp(envelope.PayloadBytes(), 0).ErrorCode())
+}
+
+func TestValidateEnvelope(t *testing.T) {
+	valid := request(fbs.MessageTypeGetPlayerInfoWsReq, 7, []byte("payload"))
+
+	// corrupt returns a copy of valid with the uint32 at the offset of the
+	// field found by locate replaced by value
+	corrupt := func(locate func(message []byte) uint32, value uint32) []byte {
+		message := slices.Clone(valid)
+		binary.LittleEndian.PutUint32(message[locate(message):], value)
+		return message
+	}
+	root := func([]byte) uint32 { return 0 }
+	vtable := func(message []byte) uint32 { return binary.LittleEndian.Uint32(message) }
+	payloadLength := func(message []byte) uint32 {
+		table := fbs.GetRootAsEnvelope(message, 0).Table()
+		// Vector points past the length that precedes the bytes
+		return uint32(table.Vector(flatbuffers.UOffsetT(table.Offset(envelopePayloadSlot)))) - flatbuffers.SizeUOffsetT
+	}
+
+	tests := []struct {
+		name    string
+		message []byte
+		wantErr bool
+	}{
+		{"valid", valid, false},
+		{"valid without payload", request(fbs.MessageTypeGetPlayerInfoWsReq, 1, nil), false},
+		{"empty", nil, true},
+		{"shorter than the root offset", []byte{1, 0, 0}, true},
+		{"root past the end", corrupt(root, uint32(len(valid))), true},
+		{"root at the last byte", corrupt(root, uint32(len(valid)-1)), true},
+		{"vtable before the frame", corrupt(vtable, 0x7fffffff), true},
+		{"vtable past the end", corrupt(vtable, 0x80000000), true},
+		{"payload longer than the frame", corrupt(payloadLength, uint32(len(valid))), true},
+		{"payload length overflowing", corrupt(payloadLength, 0xffffffff), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEnvelope(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateEnvelope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && errcode.FromGRPC(err).Code != errcode.InvalidArgument {
+				t.Errorf("error code = %s, want %s", errcode.FromGRPC(err).Code, errcode.InvalidArgument)
+			}
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		for n := 0; n < len(valid); n++ {
+			if ValidateEnvelope(valid[:n]) == nil {
+				readEnvelope(t, valid[:n])
+			}
+		}
+	})
+
+	t.Run("random bytes", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(1, 2))
+		for i := 0; i < 10000; i++ {
+			message := make([]byte, r.IntN(64))
+			for j := range message {
+				message[j] = byte(r.Uint32())
+			}
+			if ValidateEnvelope(message) == nil {
+				readEnvelope(t, message)
+			}
+		}
+	})
+}
+
+// readEnvelope reads every field of a frame that passed ValidateEnvelope,
+// failing if that panics
+func readEnvelope(t *testing.T, message []byte) {
+	t.Helper()
+	defer func() {
+		if p := recover(); p != nil {
+			t.Fatalf("reading a validated envelope %x panicked: %v", message, p)
+		}
+	}()
+	env := fbs.GetRootAsEnvelope(message, 0)
+	_, _, _, _ = env.Type(), env.RequestId(), env.Kind(), env.PayloadBytes()
+}
+
+func TestRouterServe(t *testing.T) {
+	failing := func(context.Context, *Session, []byte) ([]byte, error) {
+		return nil, errcode.New(errcode.NotFound, "no such machine")
+	}
+	panicking := func(context.Context, *Session, []byte) ([]byte, error) {
+		panic("boom")
+	}
+
+	router := NewRouter(zap.NewNop().Sugar())
+	router.Register(fbs.MessageTypeGetPlayerInfoWsReq, 1, 0, echo)
+	router.Register(fbs.MessageTypeGetMachineInfoWsReq, 1, 0, failing)
+	router.Register(fbs.MessageTypeRedeemVoucherReq, 1, 0, panicking)
+	router.Register(fbs.MessageTypeClawInputReq, 2, 0, echo)
+
+	tests := []struct {
+		name     string
+		version  uint32
+		message  []byte
+		wantType fbs.MessageType
+		wantID   uint64
+		wantCode errcode.Code
+	}{
+		{"handled", 1, request(fbs.MessageTypeGetPlayerInfoWsReq, 11, []byte{1}), fbs.MessageTypeGetPlayerInfoWsResp, 11, ""},
+		{"handler error", 1, request(fbs.MessageTypeGetMachineInfoWsReq, 12, []byte{1}), fbs.MessageTypeErrorResp, 12, errcode.NotFound},
+		{"handler panic", 1, request(fbs.MessageTypeRedeemVoucherReq, 13, []byte{1}), fbs.MessageTypeErrorResp, 13, errcode.Internal},
+		{"unknown type", 1, request(fbs.MessageTypeSubscribeReq, 14, []byte{1}), fbs.MessageTypeErrorResp, 14, errcode.InvalidArgument},
+		{"empty payload", 1, request(fbs.MessageTypeGetPlayerInfoWsReq, 15, nil), fbs.MessageTypeErrorResp, 15, errcode.InvalidArgument},
+		{"newer than the client", 1, request(fbs.MessageTypeClawInputReq, 16, []byte{1}), fbs.MessageTypeErrorResp, 16, errcode.InvalidArgument},
+		{"supported by the client", 2, request(fbs.MessageTypeClawInputReq, 17, []byte{1}), fbs.MessageTypeGetPlayerInfoWsResp, 17, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, conn := testSession(tt.version)
+			router.Serve(context.Background(), session, tt.message)
+
+			sent := conn.envelopes()
+			if len(sent) != 1 {
+				t.Fatalf("sent %d envelopes, want 1", len(sent))
+			}
+			resp := sent[0]
+			if resp.Type() != tt.wantType || resp.RequestId() != tt.wantID || resp.Kind() != fbs.EnvelopeKindResponse {
+				t.Errorf("response %s #%d (%s), want %s #%d", resp.Type(), resp.RequestId(), resp.Kind(), tt.wantType, tt.wantID)
+			}
+			if code := errorCode(resp); code != tt.wantCode {
+				t.Errorf("error code = %q, want %q", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRouterMiddleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(msgType fbs.MessageType, next Handler) Handler {
+			return func(ctx context.Context, session *Session, payload []byte) ([]byte, error) {
+				calls = append(calls, name+" "+msgType.String())
+				response, err := next(ctx, session, payload)
+				calls = append(calls, name+" done")
+				return response, err
+			}
+		}
+	}
+	stop := func(_ fbs.MessageType, _ Handler) Handler {
+		return func(context.Context, *Session, []byte) ([]byte, error) {
+			calls = append(calls, "stop")
+			return nil, errcode.New(errcode.Unauthenticated, "")
+		}
+	}
+	handler := func(ctx context.Context, session *Session, payload []byte) ([]byte, error) {
+		calls = append(calls, "handler")
+		return echo(ctx, session, payload)
+	}
+
+	tests := []struct {
+		name       string
+		middleware []Middleware
+		wantCalls  []string
+		wantCode   errcode.Code
+	}{
+		{"none", nil, []string{"handler"}, ""},
+		{"first added is outermost", []Middleware{trace("outer"), trace("inner")}, []string{
+			"outer GetPlayerInfoWsReq", "inner GetPlayerInfoWsReq", "handler", "inner done", "outer done",
+		}, ""},
+		{"short circuit", []Middleware{trace("outer"), stop, trace("inner")}, []string{
+			"outer GetPlayerInfoWsReq", "stop", "outer done",
+		}, errcode.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			router := NewRouter(zap.NewNop().Sugar())
+			router.Use(tt.middleware...)
+			router.Register(fbs.MessageTypeGetPlayerInfoWsReq, 1, 0, handler)
+
+			session, conn := testSession(1)
+			router.Serve(context.Background(), session, request(fbs.MessageTypeGetPlayerInfoWsReq, 1, []byte{1}))
+
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("calls = %q, want %q", calls, tt.wantCalls)
+			}
+			if code := errorCode(conn.envelopes()[0]); code != tt.wantCode {
+				t.Errorf("error code = %q, want %q", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPipelineOrder(t *testing.T) {
+	const requests = 50
+
+	var mu sync.Mutex
+	var order []byte
+	record := func(_ context.Context, _ *Session, payload []byte) ([]byte, error) {
+		// later requests finish sooner, so only ordering keeps them in line
+		time.Sleep(time.Duration(requests-int(payload[0])) * 20 * time.Microsecond)
+		mu.Lock()
+		order = append(order, payload[0])
+		mu.Unlock()
+		return echo(context.Background(), nil, payload)
+	}
+
+	router := NewRouter(zap.NewNop().Sugar())
+	router.Register(fbs.MessageTypeClawInputReq, 1, 0, record)
+	router.Order(fbs.MessageTypeClawInputReq)
+
+	session, conn := testSession(1)
+	pipeline := router.NewPipeline(context.Background(), session, 4)
+	for i := 0; i < requests; i++ {
+		if !pipeline.Submit(request(fbs.MessageTypeClawInputReq, uint64(i+1), []byte{byte(i)})) {
+			t.Fatalf("Submit(%d) reported a closed session", i)
+		}
+	}
+	pipeline.Close()
+
+	for i, got := range order {
+		if int(got) != i {
+			t.Fatalf("ordered requests ran as %v", order)
+		}
+	}
+	for i, resp := range conn.envelopes() {
+		if resp.RequestId() != uint64(i+1) {
+			t.Fatalf("response %d answers request %d", i, resp.RequestId())
+		}
+	}
+	if len(order) != requests {
+		t.Errorf("ran %d requests, want %d", len(order), requests)
+	}
+}
+
+func TestPipelineRejectsMalformed(t *testing.T) {
+	called := false
+	router := NewRouter(zap.NewNop().Sugar())
+	router.Register(fbs.MessageTypeGetPlayerInfoWsReq, 1, 0, func(context.Context, *Session, []byte) ([]byte, error) {
+		called = true
+		return nil, errors.New("must not be called")
+	})
+	router.Order(fbs.MessageTypeGetPlayerInfoWsReq)
+
+	// a GetPlayerInfoWsReq whose root offset points past the frame
+	message := request(fbs.MessageTypeGetPlayerInfoWsReq, 9, []byte{1})
+	binary.LittleEndian.PutUint32(message, 0xfffffff0)
+
+	session, conn := testSession(1)
+	pipeline := router.NewPipeline(context.Background(), session, 1)
+	if !pipeline.Submit(message) {
+		t.Fatal("Submit reported a closed session")
+	}
+	pipeline.Close()
+
+	if called {
+		t.Error("the handler saw a malformed envelope")
+	}
+	sent := conn.envelopes()
+	if len(sent) != 1 {
+		t.Fatalf("sent %d envelopes, want 1", len(sent))
+	}
+	if code := errorCode(sent[0]); code != errcode.InvalidArgument || sent[0].RequestId() != 0 {
+		t.Errorf("response %s #%d with code %q, want an INVALID_ARGUMENT ErrorResp #0", sent[0].Type(), sent[0].RequestId(), code)
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    errcode.Code
+		wantStatus  int32
+		wantMessage string
+		wantRetry   int64
+	}{
+		{"catalogue error", errcode.New(errcode.VoucherExpired, "voucher expired"), errcode.VoucherExpired, 410, "voucher expired", 0},
+		// what a backend returned, as the gateway's gRPC clients see it
+		{"backend status", errcode.New(errcode.InsufficientFunds, "not enough coin").GRPCStatus().Err(), errcode.InsufficientFunds, 402, "not enough coin", 0},
+		{"backend failure", status.Error(codes.Internal, "Error 1146: Table doesn't exist"), errcode.Internal, 500, "internal error", 0},
+		{"plain error", errors.New("redis: connection refused"), errcode.Internal, 500, "internal error", 0},
+		{"rejection", &Rejection{Err: errcode.New(errcode.RateLimited, ""), RetryAfter: 1500 * time.Millisecond}, errcode.RateLimited, 429, "rate limit exceeded", 1500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope := fbs.GetRootAsEnvelope(ErrorResponse(tt.err, 9), 0)
+			if envelope.Kind() != fbs.EnvelopeKindResponse || envelope.RequestId() != 9 {
+				t.Fatalf("got %s #%d, want a response to #9", envelope.Kind(), envelope.RequestId())
+			}
+			if code := errorCode(envelope); code != tt.wantCode {
+				t.Fatalf("error code = %s, want %s", code, tt.wantCode)
+			}
+
+			resp := fbs.GetRootAsErrorResp(envelope.PayloadBytes(), 0)
+			if resp.Code() != tt.wantStatus || string(resp.Message()) != tt.wantMessage || resp.RetryAfterMs() != tt.wantRetry {
+				t.Errorf("ErrorResp = %d %q retry %dms, want %d %q retry %dms",
+					resp.Code(), resp.Message(), resp.RetryAfterMs(), tt.wantStatus, tt.wantMessage, tt.wantRetry)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"context"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/transport/grpc"
+	"github.com/Richard-inter/game/pkg/errcode"
+	runtimepb "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// maxRoomNameLength bounds room names clients may subscribe to
+const maxRoomNameLength = 64
+
+type runtimeCall func(ctx context.Context, req *runtimepb.RuntimeRequest) (*runtimepb.RuntimeResponse, error)
+
+// NewGameRouter builds the router every transport serves: game requests are
+// forwarded to the clawmachine runtime service, room subscriptions are kept
+// in sessions.
+func NewGameRouter(
+	logger *zap.SugaredLogger,
+	grpcManager *grpc.ClientManager,
+	sessions *Registry,
+	limiter *RateLimiter,
+) (*Router, error) {
+	runtimeClient, err := grpcManager.GetClawMachineRuntimeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	r := NewRouter(logger)
+	r.Use(Logging(logger), Authenticated(), RateLimit(limiter))
+
+	// Version 2 moved claw games to the server: version 1 clients settled
+	// them with the item they reported, which is no longer trusted. They are
+	// still served everything else and told to upgrade to play.
+	r.Register(fbs.MessageTypeStartClawGameReq, 1, 1, upgradeRequired(2))
+	r.Register(fbs.MessageTypeAddTouchedItemRecordReq, 1, 1, upgradeRequired(2))
+	r.Register(fbs.MessageTypeStartClawGameReq, 2, 0, forward(runtimeClient.StartClawGameWs))
+	r.Register(fbs.MessageTypeClawInputReq, 2, 0, forward(runtimeClient.ClawInputWs))
+	r.Register(fbs.MessageTypeGetPlayerInfoWsReq, 1, 0, forward(runtimeClient.GetPlayerSnapshotWs))
+	r.Register(fbs.MessageTypeRedeemVoucherReq, 1, 0, forward(runtimeClient.RedeemVoucherWs))
+	r.Register(fbs.MessageTypeGetMachineInfoWsReq, 1, 0, forward(runtimeClient.GetMachineSnapshotWs))
+	r.Register(fbs.MessageTypeSubscribeReq, 1, 0, subscribe(logger, sessions))
+	r.Register(fbs.MessageTypeUnsubscribeReq, 1, 0, unsubscribe(logger, sessions))
+
+	// subscription changes and claw inputs must apply in the order the
+	// client sent them
+	r.Order(fbs.MessageTypeSubscribeReq, fbs.MessageTypeUnsubscribeReq, fbs.MessageTypeClawInputReq)
+
+	return r, nil
+}
+
+// forward passes the payload to a runtime service call, which answers with
+// the Envelope to send back
+func forward(call runtimeCall) Handler {
+	return func(ctx context.Context, _ *Session, payload []byte) ([]byte, error) {
+		resp, err := call(ctx, &runtimepb.RuntimeRequest{
+			Payload: payload,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Payload, nil
+	}
+}
+
+// upgradeRequired answers requests an older protocol version can no longer
+// make, naming the version that serves them
+func upgradeRequired(version uint32) Handler {
+	return func(context.Context, *Session, []byte) ([]byte, error) {
+		return nil, errcode.Newf(errcode.UnsupportedVersion, "upgrade to protocol version %d to play", version)
+	}
+}
+
+func subscribe(logger *zap.SugaredLogger, sessions *Registry) Handler {
+	return func(_ context.Context, session *Session, payload []byte) ([]byte, error) {
+		room := string(fbs.GetRootAsSubscribeReq(payload, 0).Room())
+		if room == "" || len(room) > maxRoomNameLength {
+			return nil, errcode.New(errcode.InvalidArgument, "Invalid room")
+		}
+
+		if !sessions.Join(session, room) {
+			return nil, errcode.New(errcode.InvalidArgument, "Too many subscriptions")
+		}
+
+		logger.Debugw("Session subscribed", "session_id", session.ID, "room", room)
+		return buildSubscribeResp(room, true), nil
+	}
+}
+
+func unsubscribe(logger *zap.SugaredLogger, sessions *Registry) Handler {
+	return func(_ context.Context, session *Session, payload []byte) ([]byte, error) {
+		room := string(fbs.GetRootAsUnsubscribeReq(payload, 0).Room())
+		if room == "" {
+			return nil, errcode.New(errcode.InvalidArgument, "Invalid room")
+		}
+
+		sessions.Leave(session, room)
+
+		logger.Debugw("Session unsubscribed", "session_id", session.ID, "room", room)
+		return buildSubscribeResp(room, false), nil
+	}
+}
+
+func buildSubscribeResp(room string, subscribed bool) []byte {
+	builder := flatbuffers.NewBuilder(128)
+	roomOffset := builder.CreateString(room)
+
+	fbs.SubscribeRespStart(builder)
+	fbs.SubscribeRespAddRoom(builder, roomOffset)
+	fbs.SubscribeRespAddSubscribed(builder, subscribed)
+	respOffset := fbs.SubscribeRespEnd(builder)
+	builder.Finish(respOffset)
+
+	return BuildEnvelope(fbs.EnvelopeKindResponse, fbs.MessageTypeSubscribeResp, 0, builder.FinishedBytes())
+}
@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/transport/grpc"
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+func TestGameRouterVersions(t *testing.T) {
+	// nothing is forwarded, so the runtime service is never dialled
+	grpcManager, err := grpc.NewClientManager(&grpc.ClientManagerConfig{RuntimeAddr: "127.0.0.1:1"})
+	if err != nil {
+		t.Fatalf("failed to create client manager: %v", err)
+	}
+	router, err := NewGameRouter(zap.NewNop().Sugar(), grpcManager, nil, nil)
+	if err != nil {
+		t.Fatalf("NewGameRouter() error = %v", err)
+	}
+
+	tests := []struct {
+		version  uint32
+		accepts  []fbs.MessageType
+		declines []fbs.MessageType
+	}{
+		{
+			version:  1,
+			accepts:  []fbs.MessageType{fbs.MessageTypeStartClawGameReq, fbs.MessageTypeAddTouchedItemRecordReq, fbs.MessageTypeGetPlayerInfoWsReq, fbs.MessageTypeSubscribeReq},
+			declines: []fbs.MessageType{fbs.MessageTypeClawInputReq},
+		},
+		{
+			version:  2,
+			accepts:  []fbs.MessageType{fbs.MessageTypeStartClawGameReq, fbs.MessageTypeClawInputReq, fbs.MessageTypeGetPlayerInfoWsReq, fbs.MessageTypeSubscribeReq},
+			declines: []fbs.MessageType{fbs.MessageTypeAddTouchedItemRecordReq},
+		},
+	}
+
+	for _, tt := range tests {
+		types := router.MessageTypes(tt.version)
+		for _, msgType := range tt.accepts {
+			if !slices.Contains(types, msgType) {
+				t.Errorf("version %d does not accept %s", tt.version, msgType)
+			}
+		}
+		for _, msgType := range tt.declines {
+			if slices.Contains(types, msgType) {
+				t.Errorf("version %d accepts %s", tt.version, msgType)
+			}
+		}
+	}
+
+	// version 1 game requests are answered, not forwarded
+	for _, msgType := range []fbs.MessageType{fbs.MessageTypeStartClawGameReq, fbs.MessageTypeAddTouchedItemRecordReq} {
+		session, conn := testSession(1)
+		router.Serve(context.Background(), session, request(msgType, 3, []byte{1}))
+
+		sent := conn.envelopes()
+		if len(sent) != 1 {
+			t.Fatalf("%s: sent %d envelopes, want 1", msgType, len(sent))
+		}
+		if code := errorCode(sent[0]); code != errcode.UnsupportedVersion || sent[0].RequestId() != 3 {
+			t.Errorf("%s: got %q #%d, want %s #3", msgType, code, sent[0].RequestId(), errcode.UnsupportedVersion)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package gateway
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrSessionClosed is returned when sending to a session that went away
+var ErrSessionClosed = errors.New("session closed")
+
+// Conn is the transport side of a session: it delivers Envelopes to the
+// client in whatever framing the transport uses. Send must not block.
+type Conn interface {
+	Send(envelope []byte) error
+	Done() <-chan struct{}
+	Close() error
+}
+
+// Disconnector is implemented by connections that can tell the client why
+// they are being closed
+type Disconnector interface {
+	Disconnect(reason string) error
+}
+
+// Session is one authenticated client, whichever transport it came in on
+type Session struct {
+	ID        uint64
+	PlayerID  int64
+	Client    ClientInfo
+	Transport string
+
+	conn Conn
+
+	// recorder captures the session's envelopes when it was selected
+	recorder *recorder
+
+	// rate limit state of the connection
+	limitMu         sync.Mutex
+	bucket          *tokenBucket
+	violations      int
+	violationsSince time.Time
+}
+
+// Send queues one Envelope for the client
+func (s *Session) Send(envelope []byte) error {
+	if err := s.conn.Send(envelope); err != nil {
+		return err
+	}
+	if s.recorder != nil {
+		s.recorder.record(CaptureOutbound, envelope)
+	}
+	return nil
+}
+
+// Done is closed once the connection is closed
+func (s *Session) Done() <-chan struct{} {
+	return s.conn.Done()
+}
+
+// Close closes the connection
+func (s *Session) Close() error {
+	return s.conn.Close()
+}
+
+// Disconnect closes the connection, telling the client why when the
+// transport can
+func (s *Session) Disconnect(reason string) error {
+	if d, ok := s.conn.(Disconnector); ok {
+		return d.Disconnect(reason)
+	}
+	return s.conn.Close()
+}
+
+func (s *Session) connectionBucket(rule rateRule) *tokenBucket {
+	s.limitMu.Lock()
+	defer s.limitMu.Unlock()
+
+	if s.bucket == nil {
+		s.bucket = newTokenBucket(rule)
+	}
+	return s.bucket
+}
+
+// recordViolation counts a rate limited message and returns the number of
+// violations within the current window
+func (s *Session) recordViolation(now time.Time, window time.Duration) int {
+	s.limitMu.Lock()
+	defer s.limitMu.Unlock()
+
+	if now.Sub(s.violationsSince) > window {
+		s.violations = 0
+		s.violationsSince = now
+	}
+	s.violations++
+	return s.violations
+}
@@ -0,0 +1,81 @@
+package pricing
+
+import (
+	"fmt"
+
+	"github.com/Richard-inter/game/internal/domain"
+)
+
+const (
+	DiscountPercentage = "percentage"
+	DiscountFixed      = "fixed"
+)
+
+// ValidatePromotion checks that a promotion describes a usable discount window
+func ValidatePromotion(p *domain.Promotion) error {
+	switch p.DiscountType {
+	case DiscountPercentage:
+		if p.DiscountValue <= 0 || p.DiscountValue > 100 {
+			return fmt.Errorf("percentage discount must be between 1 and 100")
+		}
+	case DiscountFixed:
+		if p.DiscountValue <= 0 {
+			return fmt.Errorf("fixed discount must be positive")
+		}
+	default:
+		return fmt.Errorf("invalid discount type: %s", p.DiscountType)
+	}
+
+	if !p.EndsAt.After(p.StartsAt) {
+		return fmt.Errorf("promotion must end after it starts")
+	}
+
+	return nil
+}
+
+// AppliesTo reports whether the promotion targets the machine
+func AppliesTo(p *domain.Promotion, machineID int64) bool {
+	if len(p.Machines) == 0 {
+		return true
+	}
+	for _, m := range p.Machines {
+		if m.ClawMachineID == machineID {
+			return true
+		}
+	}
+	return false
+}
+
+// Discounted applies a single promotion to a base price, never going below zero
+func Discounted(basePrice int64, p *domain.Promotion) int64 {
+	var price int64
+	switch p.DiscountType {
+	case DiscountPercentage:
+		price = basePrice - basePrice*p.DiscountValue/100
+	case DiscountFixed:
+		price = basePrice - p.DiscountValue
+	default:
+		price = basePrice
+	}
+	return max(price, 0)
+}
+
+// EffectivePrice picks the cheapest of the active promotions targeting the
+// machine. Promotions do not stack. The returned promotion is nil when the
+// base price applies.
+func EffectivePrice(basePrice int64, machineID int64, active []domain.Promotion) (int64, *domain.Promotion) {
+	price := basePrice
+	var applied *domain.Promotion
+
+	for i := range active {
+		if !AppliesTo(&active[i], machineID) {
+			continue
+		}
+		if discounted := Discounted(basePrice, &active[i]); discounted < price {
+			price = discounted
+			applied = &active[i]
+		}
+	}
+
+	return price, applied
+}
@@ -0,0 +1,125 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Richard-inter/game/internal/domain"
+)
+
+func percentage(id, value int64, machines ...int64) domain.Promotion {
+	return promotion(id, DiscountPercentage, value, machines...)
+}
+
+func fixed(id, value int64, machines ...int64) domain.Promotion {
+	return promotion(id, DiscountFixed, value, machines...)
+}
+
+func promotion(id int64, discountType string, value int64, machines ...int64) domain.Promotion {
+	p := domain.Promotion{ID: id, DiscountType: discountType, DiscountValue: value}
+	for _, m := range machines {
+		p.Machines = append(p.Machines, domain.PromotionMachine{PromotionID: id, ClawMachineID: m})
+	}
+	return p
+}
+
+func TestDiscounted(t *testing.T) {
+	tests := []struct {
+		name      string
+		basePrice int64
+		promotion domain.Promotion
+		want      int64
+	}{
+		{"percentage", 100, percentage(1, 25), 75},
+		// the discount is truncated, so odd prices round up
+		{"percentage rounds the price up", 15, percentage(1, 10), 14},
+		{"percentage below one coin", 9, percentage(1, 10), 9},
+		{"percentage of everything", 80, percentage(1, 100), 0},
+		{"fixed", 100, fixed(1, 30), 70},
+		{"fixed above the price", 20, fixed(1, 30), 0},
+		{"unknown type", 100, promotion(1, "bogus", 50), 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Discounted(tt.basePrice, &tt.promotion); got != tt.want {
+				t.Errorf("Discounted(%d) = %d, want %d", tt.basePrice, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectivePrice(t *testing.T) {
+	const machineID = 7
+
+	tests := []struct {
+		name       string
+		basePrice  int64
+		active     []domain.Promotion
+		wantPrice  int64
+		wantPromID int64 // 0 when the base price applies
+	}{
+		{"no promotions", 100, nil, 100, 0},
+		{"cheapest wins", 100, []domain.Promotion{percentage(1, 10), fixed(2, 30), percentage(3, 20)}, 70, 2},
+		// 10% of 40 is 4, less than the fixed 5
+		{"fixed beats percentage on a low price", 40, []domain.Promotion{percentage(1, 10), fixed(2, 5)}, 35, 2},
+		{"percentage beats fixed on a high price", 400, []domain.Promotion{percentage(1, 10), fixed(2, 5)}, 360, 1},
+		{"first of equal discounts wins", 100, []domain.Promotion{fixed(1, 20), percentage(2, 20)}, 80, 1},
+		{"targeted at this machine", 100, []domain.Promotion{fixed(1, 50, machineID)}, 50, 1},
+		{"targeted at another machine", 100, []domain.Promotion{fixed(1, 50, machineID+1)}, 100, 0},
+		{"other machine's deeper discount ignored", 100, []domain.Promotion{fixed(1, 90, machineID+1), fixed(2, 10)}, 90, 2},
+		{"discount too small to change the price", 9, []domain.Promotion{percentage(1, 10)}, 9, 0},
+		{"free play", 100, []domain.Promotion{percentage(1, 100), fixed(2, 500)}, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, applied := EffectivePrice(tt.basePrice, machineID, tt.active)
+			if price != tt.wantPrice {
+				t.Errorf("price = %d, want %d", price, tt.wantPrice)
+			}
+			var id int64
+			if applied != nil {
+				id = applied.ID
+			}
+			if id != tt.wantPromID {
+				t.Errorf("promotion = %d, want %d", id, tt.wantPromID)
+			}
+		})
+	}
+}
+
+func TestValidatePromotion(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	window := func(p domain.Promotion, starts, ends time.Time) domain.Promotion {
+		p.StartsAt, p.EndsAt = starts, ends
+		return p
+	}
+
+	tests := []struct {
+		name      string
+		promotion domain.Promotion
+		wantErr   bool
+	}{
+		{"percentage", window(percentage(1, 50), start, start.Add(time.Hour)), false},
+		{"full percentage", window(percentage(1, 100), start, start.Add(time.Hour)), false},
+		{"percentage above 100", window(percentage(1, 101), start, start.Add(time.Hour)), true},
+		{"zero percentage", window(percentage(1, 0), start, start.Add(time.Hour)), true},
+		{"fixed", window(fixed(1, 1), start, start.Add(time.Hour)), false},
+		{"negative fixed", window(fixed(1, -5), start, start.Add(time.Hour)), true},
+		{"unknown type", window(promotion(1, "bogus", 5), start, start.Add(time.Hour)), true},
+		{"shortest window", window(fixed(1, 5), start, start.Add(time.Nanosecond)), false},
+		{"empty window", window(fixed(1, 5), start, start), true},
+		{"ends before it starts", window(fixed(1, 5), start, start.Add(-time.Second)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePromotion(&tt.promotion)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePromotion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
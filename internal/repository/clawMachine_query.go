@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/pkg/errcode"
+)
+
+// MachineSort is the order ListClawMachines returns machines in
+type MachineSort string
+
+const (
+	MachineSortNewest     MachineSort = "newest"
+	MachineSortPriceAsc   MachineSort = "price_asc"
+	MachineSortPriceDesc  MachineSort = "price_desc"
+	MachineSortPopularity MachineSort = "popularity"
+)
+
+// ClawMachineQuery selects one page of machines. Zero filters match every
+// machine.
+type ClawMachineQuery struct {
+	MinPrice int64
+	MaxPrice int64
+	Currency string
+	Status   string
+	Rarity   string // machines holding at least one item of this rarity
+	Tag      string
+
+	Sort  MachineSort
+	After *MachineCursor // nil for the first page
+	Limit int
+
+	// WithItems loads the items of each machine
+	WithItems bool
+}
+
+// MachineCursor is the position of a machine in a sort order: its sort key
+// and its ID, which breaks ties
+type MachineCursor struct {
+	Key int64
+	ID  int64
+}
+
+// order is the column the sort is on and whether it is descending
+func (s MachineSort) order() (string, bool, error) {
+	switch s {
+	case MachineSortNewest:
+		return "id", true, nil
+	case MachineSortPriceAsc:
+		return "price", false, nil
+	case MachineSortPriceDesc:
+		return "price", true, nil
+	case MachineSortPopularity:
+		return "play_count", true, nil
+	default:
+		return "", false, errcode.Newf(errcode.InvalidArgument, "invalid sort: %s", s)
+	}
+}
+
+// Valid reports whether s is one of the supported sorts
+func (s MachineSort) Valid() bool {
+	_, _, err := s.order()
+	return err == nil
+}
+
+// Cursor is the position of machine in the sort order, to continue after it
+func (s MachineSort) Cursor(machine *domain.ClawMachine) MachineCursor {
+	switch s {
+	case MachineSortPriceAsc, MachineSortPriceDesc:
+		return MachineCursor{Key: machine.Price, ID: machine.ID}
+	case MachineSortPopularity:
+		return MachineCursor{Key: machine.PlayCount, ID: machine.ID}
+	default:
+		return MachineCursor{Key: machine.ID, ID: machine.ID}
+	}
+}
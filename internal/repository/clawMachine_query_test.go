@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/pkg/errcode"
+)
+
+func TestMachineSortCursor(t *testing.T) {
+	machine := &domain.ClawMachine{ID: 9, Price: 150, PlayCount: 42}
+
+	tests := []struct {
+		sort  MachineSort
+		valid bool
+		want  MachineCursor
+	}{
+		{MachineSortNewest, true, MachineCursor{Key: 9, ID: 9}},
+		{MachineSortPriceAsc, true, MachineCursor{Key: 150, ID: 9}},
+		{MachineSortPriceDesc, true, MachineCursor{Key: 150, ID: 9}},
+		{MachineSortPopularity, true, MachineCursor{Key: 42, ID: 9}},
+		{"", false, MachineCursor{}},
+		{"price", false, MachineCursor{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.sort), func(t *testing.T) {
+			if got := tt.sort.Valid(); got != tt.valid {
+				t.Fatalf("Valid() = %v, want %v", got, tt.valid)
+			}
+			if !tt.valid {
+				return
+			}
+			if got := tt.sort.Cursor(machine); got != tt.want {
+				t.Errorf("Cursor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// sqlRecorder is a gorm logger keeping the statements it is shown
+type sqlRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+// dryRunRepository returns a repository that builds MySQL statements
+// without a database, and the recorder they are written to
+func dryRunRepository(t *testing.T) (*clawMachineRepository, *sqlRecorder) {
+	t.Helper()
+
+	recorder := &sqlRecorder{Interface: logger.Discard}
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:pass@tcp(127.0.0.1:3306)/game",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, Logger: recorder})
+	if err != nil {
+		t.Fatalf("failed to open dry run database: %v", err)
+	}
+	return &clawMachineRepository{db: db}, recorder
+}
+
+func TestListClawMachinesKeyset(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     ClawMachineQuery
+		wantWhere string
+		wantOrder string
+	}{
+		{
+			name:      "first page",
+			query:     ClawMachineQuery{Sort: MachineSortPriceAsc, Limit: 10},
+			wantOrder: "ORDER BY price ASC,id ASC LIMIT 10",
+		},
+		{
+			name:      "newest after a machine",
+			query:     ClawMachineQuery{Sort: MachineSortNewest, After: &MachineCursor{Key: 30, ID: 30}, Limit: 5},
+			wantWhere: "WHERE id < 30",
+			wantOrder: "ORDER BY id DESC LIMIT 5",
+		},
+		{
+			// machines priced like the last one are continued by ID, not skipped
+			name:      "tie on equal price ascending",
+			query:     ClawMachineQuery{Sort: MachineSortPriceAsc, After: &MachineCursor{Key: 100, ID: 12}, Limit: 5},
+			wantWhere: "WHERE price > 100 OR (price = 100 AND id > 12) ORDER",
+			wantOrder: "ORDER BY price ASC,id ASC LIMIT 5",
+		},
+		{
+			name:      "tie on equal price descending",
+			query:     ClawMachineQuery{Sort: MachineSortPriceDesc, After: &MachineCursor{Key: 100, ID: 12}, Limit: 5},
+			wantWhere: "WHERE price < 100 OR (price = 100 AND id < 12) ORDER",
+			wantOrder: "ORDER BY price DESC,id DESC LIMIT 5",
+		},
+		{
+			name:      "popularity after filters",
+			query:     ClawMachineQuery{Status: "active", Sort: MachineSortPopularity, After: &MachineCursor{Key: 7, ID: 3}, Limit: 5},
+			wantWhere: "WHERE status = 'active' AND (play_count < 7 OR (play_count = 7 AND id < 3))",
+			wantOrder: "ORDER BY play_count DESC,id DESC LIMIT 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, recorder := dryRunRepository(t)
+			if _, err := repo.ListClawMachines(tt.query); err != nil {
+				t.Fatalf("ListClawMachines() error = %v", err)
+			}
+			if len(recorder.statements) == 0 {
+				t.Fatal("no statement was built")
+			}
+
+			sql := recorder.statements[0]
+			if tt.wantWhere != "" && !strings.Contains(sql, tt.wantWhere) {
+				t.Errorf("statement %q\ndoes not contain %q", sql, tt.wantWhere)
+			}
+			if tt.wantWhere == "" && strings.Contains(sql, "WHERE") {
+				t.Errorf("first page statement %q has a WHERE clause", sql)
+			}
+			if !strings.HasSuffix(sql, tt.wantOrder) {
+				t.Errorf("statement %q\ndoes not end with %q", sql, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestListClawMachinesInvalidSort(t *testing.T) {
+	repo, recorder := dryRunRepository(t)
+
+	_, err := repo.ListClawMachines(ClawMachineQuery{Sort: "cheapest", Limit: 5})
+	if errcode.FromGRPC(err).Code != errcode.InvalidArgument {
+		t.Errorf("error = %v, want %s", err, errcode.InvalidArgument)
+	}
+	if len(recorder.statements) != 0 {
+		t.Errorf("built %q for an invalid sort", recorder.statements)
+	}
+}
@@ -2,10 +2,13 @@ package repository
 
 import (
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/pkg/errcode"
 )
 
 type clawMachineRepository struct {
@@ -19,16 +22,31 @@ type ClawMachineRepository interface {
 	AdjustPlayerCoin(playerID int64, amount int64, adjustmentType string) (*domain.ClawPlayer, error)
 	AdjustPlayerDiamond(playerID int64, amount int64, adjustmentType string) (*domain.ClawPlayer, error)
 	AddGameHistory(playerID int64, gameRecord *domain.ClawMachineGameRecord) (int64, error)
+	StartFreeGame(gameRecord *domain.ClawMachineGameRecord, period string, dailyLimit int32) (int64, bool, error)
+	StartPaidGame(gameRecord *domain.ClawMachineGameRecord, currency string) (int64, error)
+	GetGameRecord(gameID int64) (*domain.ClawMachineGameRecord, error)
 	AddTouchedItemRecord(gameID int64, itemID int64, catched bool) error
 
+	// entitlements
+	GetFreePlaysUsed(playerID int64, period string) (int32, error)
+
 	// machine
 	CreateClawMachine(clawMachine *domain.ClawMachine) (*domain.ClawMachine, error)
 	UpdateClawMachineItems(clawMachineID int64, items []domain.ClawMachineItem) error
 	GetClawMachineInfo(machineID int64) (*domain.ClawMachine, error)
 	GetAllClawMachines() ([]*domain.ClawMachine, error)
+	ListClawMachines(query ClawMachineQuery) ([]*domain.ClawMachine, error)
 
 	// items
 	CreateClawItems(items *[]domain.Item) (*[]domain.Item, error)
+
+	// promotions
+	CreatePromotion(promotion *domain.Promotion) (*domain.Promotion, error)
+	GetActivePromotions(at time.Time) ([]domain.Promotion, error)
+
+	// vouchers
+	CreateVouchers(vouchers []domain.Voucher) ([]domain.Voucher, error)
+	RedeemVoucher(code string, playerID int64, at time.Time) (*domain.Voucher, error)
 }
 
 func NewClawMachineRepository(db *gorm.DB) ClawMachineRepository {
@@ -47,51 +65,80 @@ func (r *clawMachineRepository) GetClawPlayerInfo(playerID int64) (*domain.ClawP
 	var clawPlayer domain.ClawPlayer
 	err := r.db.Where("player_id = ?", playerID).First(&clawPlayer).Error
 	if err != nil {
-		return nil, err
+		return nil, notFound(err, "player")
 	}
 	return &clawPlayer, nil
 }
 
 func (r *clawMachineRepository) adjustPlayerBalance(playerID int64, amount int64, adjustmentType, field string) (*domain.ClawPlayer, error) {
 	if adjustmentType != "plus" && adjustmentType != "minus" {
-		return nil, fmt.Errorf("invalid adjustment type: %s", adjustmentType)
+		return nil, errcode.Newf(errcode.InvalidArgument, "invalid adjustment type: %s", adjustmentType)
 	}
 
 	if adjustmentType == "minus" {
 		amount = -amount
 	}
 
-	tx := r.db.Model(&domain.ClawPlayer{}).
+	var updatedPlayer domain.ClawPlayer
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		updatedPlayer, err = applyBalanceDelta(tx, playerID, amount, field)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedPlayer, nil
+}
+
+// applyBalanceDelta changes one balance column inside tx without letting it go
+// negative and records a BalanceChanged event alongside the update
+func applyBalanceDelta(tx *gorm.DB, playerID int64, amount int64, field string) (domain.ClawPlayer, error) {
+	var updatedPlayer domain.ClawPlayer
+
+	res := tx.Model(&domain.ClawPlayer{}).
 		Where("player_id = ?", playerID).
 		Where(fmt.Sprintf("%s + ? >= 0", field), amount).
 		UpdateColumn(field, gorm.Expr(fmt.Sprintf("%s + ?", field), amount))
 
-	if tx.Error != nil {
-		return nil, tx.Error
+	if res.Error != nil {
+		return updatedPlayer, res.Error
 	}
 
-	if tx.RowsAffected == 0 {
+	if res.RowsAffected == 0 {
 		var exists bool
-		if err := r.db.Model(&domain.ClawPlayer{}).
+		if err := tx.Model(&domain.ClawPlayer{}).
 			Select("1").
 			Where("player_id = ?", playerID).
 			Limit(1).
 			Scan(&exists).Error; err != nil {
-			return nil, err
+			return updatedPlayer, err
 		}
 
 		if !exists {
-			return nil, fmt.Errorf("player not found")
+			return updatedPlayer, errcode.New(errcode.NotFound, "player not found")
 		}
-		return nil, fmt.Errorf("not enough %s", field)
+		return updatedPlayer, errcode.Newf(errcode.InsufficientFunds, "not enough %s", field)
 	}
 
-	var updatedPlayer domain.ClawPlayer
-	if err := r.db.First(&updatedPlayer, "player_id = ?", playerID).Error; err != nil {
-		return nil, err
+	if err := tx.First(&updatedPlayer, "player_id = ?", playerID).Error; err != nil {
+		return updatedPlayer, err
 	}
 
-	return &updatedPlayer, nil
+	balance := updatedPlayer.Coin
+	if field == "diamond" {
+		balance = updatedPlayer.Diamond
+	}
+
+	err := appendEvents(tx, domain.BalanceChangedEvent{
+		PlayerID: playerID,
+		Currency: field,
+		Delta:    amount,
+		Balance:  balance,
+	})
+
+	return updatedPlayer, err
 }
 
 func (r *clawMachineRepository) AdjustPlayerCoin(playerID int64, amount int64, adjustmentType string) (*domain.ClawPlayer, error) {
@@ -103,32 +150,201 @@ func (r *clawMachineRepository) AdjustPlayerDiamond(playerID int64, amount int64
 }
 
 func (r *clawMachineRepository) AddGameHistory(playerID int64, gameRecord *domain.ClawMachineGameRecord) (int64, error) {
-	err := r.db.Create(gameRecord).Error
+	var gameID int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		gameID, err = createGameRecord(tx, gameRecord)
+		return err
+	})
 	if err != nil {
 		return 0, err
 	}
 
+	return gameID, nil
+}
+
+// StartFreeGame records a game paid for with a free play: one of the period's
+// daily free plays while fewer than dailyLimit are used, otherwise a bonus
+// free play. The free play is spent in the same transaction that creates the
+// record, so a game that fails to start keeps it. It reports false and records
+// nothing when the player has no free play left.
+func (r *clawMachineRepository) StartFreeGame(gameRecord *domain.ClawMachineGameRecord, period string, dailyLimit int32) (int64, bool, error) {
+	var gameID int64
+	consumed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		consumed, err = consumeFreePlay(tx, gameRecord.PlayerID, period, dailyLimit)
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			consumed, err = consumeBonusFreePlay(tx, gameRecord.PlayerID)
+			if err != nil || !consumed {
+				return err
+			}
+		}
+
+		gameRecord.FreePlay = true
+		gameID, err = createGameRecord(tx, gameRecord)
+		return err
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	return gameID, consumed, nil
+}
+
+// StartPaidGame records a game and charges its PricePaid in currency in the
+// same transaction, so a game that fails to start costs nothing
+func (r *clawMachineRepository) StartPaidGame(gameRecord *domain.ClawMachineGameRecord, currency string) (int64, error) {
+	var gameID int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := applyBalanceDelta(tx, gameRecord.PlayerID, -gameRecord.PricePaid, currency); err != nil {
+			return err
+		}
+
+		var err error
+		gameID, err = createGameRecord(tx, gameRecord)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return gameID, nil
+}
+
+// createGameRecord inserts gameRecord inside tx, counts the play on its
+// machine and records a GameStarted event
+func createGameRecord(tx *gorm.DB, gameRecord *domain.ClawMachineGameRecord) (int64, error) {
+	if err := tx.Create(gameRecord).Error; err != nil {
+		return 0, err
+	}
+
 	// Get the created record with the generated ID
 	var createdRecord domain.ClawMachineGameRecord
-	err = r.db.First(&createdRecord, gameRecord.ID).Error
-	if err != nil {
+	if err := tx.First(&createdRecord, gameRecord.ID).Error; err != nil {
 		return 0, err
 	}
 
-	return createdRecord.ID, nil
+	if err := tx.Model(&domain.ClawMachine{}).
+		Where("id = ?", createdRecord.ClawMachineID).
+		UpdateColumn("play_count", gorm.Expr("play_count + 1")).Error; err != nil {
+		return 0, err
+	}
+
+	err := appendEvents(tx, domain.GameStartedEvent{
+		GameID:      createdRecord.ID,
+		PlayerID:    createdRecord.PlayerID,
+		MachineID:   createdRecord.ClawMachineID,
+		FreePlay:    createdRecord.FreePlay,
+		PricePaid:   createdRecord.PricePaid,
+		PromotionID: createdRecord.PromotionID,
+	})
+	return createdRecord.ID, err
+}
+
+func (r *clawMachineRepository) GetGameRecord(gameID int64) (*domain.ClawMachineGameRecord, error) {
+	var record domain.ClawMachineGameRecord
+	err := r.db.First(&record, gameID).Error
+	if err != nil {
+		return nil, notFound(err, "game")
+	}
+	return &record, nil
 }
 
+// AddTouchedItemRecord settles a game with the item the claw reached. A game
+// is settled once: settling it again changes nothing and appends no events,
+// so a retried drop cannot announce the outcome twice.
 func (r *clawMachineRepository) AddTouchedItemRecord(gameID int64, itemID int64, catched bool) error {
-	err := r.db.Model(&domain.ClawMachineGameRecord{}).
-		Where("id = ?", gameID).
-		Updates(map[string]any{
-			"touched_item_id": itemID,
-			"catched":         catched,
-		}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var record domain.ClawMachineGameRecord
+		if err := tx.First(&record, gameID).Error; err != nil {
+			return notFound(err, "game")
+		}
+
+		result := tx.Model(&domain.ClawMachineGameRecord{}).
+			Where("id = ? AND settled = ?", gameID, false).
+			Updates(map[string]any{
+				"touched_item_id": itemID,
+				"catched":         catched,
+				"settled":         true,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return appendEvents(tx,
+			domain.ItemTouchedEvent{
+				GameID:   gameID,
+				PlayerID: record.PlayerID,
+				ItemID:   itemID,
+				Catched:  catched,
+			},
+			domain.GameSettledEvent{
+				GameID:    gameID,
+				PlayerID:  record.PlayerID,
+				MachineID: record.ClawMachineID,
+				ItemID:    itemID,
+				Catched:   catched,
+			},
+		)
+	})
+}
+
+// consumeFreePlay spends one free play of the given period inside tx if the
+// player still has one left. The check and the increment happen in a single
+// conditional update so concurrent games cannot overspend the allowance.
+func consumeFreePlay(tx *gorm.DB, playerID int64, period string, dailyLimit int32) (bool, error) {
+	if dailyLimit <= 0 {
+		return false, nil
+	}
+
+	entitlement := domain.ClawPlayerEntitlement{
+		PlayerID: playerID,
+		Period:   period,
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entitlement).Error; err != nil {
+		return false, err
+	}
+
+	res := tx.Model(&domain.ClawPlayerEntitlement{}).
+		Where("player_id = ? AND period = ?", playerID, period).
+		Where("free_plays_used < ?", dailyLimit).
+		UpdateColumn("free_plays_used", gorm.Expr("free_plays_used + 1"))
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+func (r *clawMachineRepository) GetFreePlaysUsed(playerID int64, period string) (int32, error) {
+	var entitlement domain.ClawPlayerEntitlement
+	err := r.db.Where("player_id = ? AND period = ?", playerID, period).
+		Limit(1).
+		Find(&entitlement).Error
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return entitlement.FreePlaysUsed, nil
+}
+
+// consumeBonusFreePlay spends one free play granted outside the daily
+// allowance, e.g. by a voucher, inside tx
+func consumeBonusFreePlay(tx *gorm.DB, playerID int64) (bool, error) {
+	res := tx.Model(&domain.ClawPlayer{}).
+		Where("player_id = ? AND bonus_free_plays > 0", playerID).
+		UpdateColumn("bonus_free_plays", gorm.Expr("bonus_free_plays - 1"))
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
 }
 
 func (r *clawMachineRepository) CreateClawMachine(
@@ -156,6 +372,7 @@ func (r *clawMachineRepository) CreateClawMachine(
 
 	if err := r.db.
 		Preload("Items.Item").
+		Preload("Tags").
 		First(clawMachine, clawMachine.ID).Error; err != nil {
 		return clawMachine, nil
 	}
@@ -188,22 +405,87 @@ func (r *clawMachineRepository) UpdateClawMachineItems(clawMachineID int64, item
 
 func (r *clawMachineRepository) GetClawMachineInfo(machineID int64) (*domain.ClawMachine, error) {
 	var clawMachine domain.ClawMachine
-	err := r.db.Preload("Items.Item").Where("id = ?", machineID).First(&clawMachine).Error
+	err := r.db.Preload("Items.Item").Preload("Tags").Where("id = ?", machineID).First(&clawMachine).Error
 	if err != nil {
-		return nil, err
+		return nil, notFound(err, "machine")
 	}
 	return &clawMachine, nil
 }
 
 func (r *clawMachineRepository) GetAllClawMachines() ([]*domain.ClawMachine, error) {
 	var clawMachines []*domain.ClawMachine
-	err := r.db.Preload("Items.Item").Find(&clawMachines).Error
+	err := r.db.Preload("Items.Item").Preload("Tags").Find(&clawMachines).Error
 	if err != nil {
 		return nil, err
 	}
 	return clawMachines, nil
 }
 
+// ListClawMachines returns up to query.Limit machines matching the query's
+// filters, in its sort order, starting after query.After
+func (r *clawMachineRepository) ListClawMachines(query ClawMachineQuery) ([]*domain.ClawMachine, error) {
+	column, descending, err := query.Sort.order()
+	if err != nil {
+		return nil, err
+	}
+
+	db := r.db.Model(&domain.ClawMachine{}).Preload("Tags")
+	if query.WithItems {
+		db = db.Preload("Items.Item")
+	}
+
+	if query.MinPrice > 0 {
+		db = db.Where("price >= ?", query.MinPrice)
+	}
+	if query.MaxPrice > 0 {
+		db = db.Where("price <= ?", query.MaxPrice)
+	}
+	if query.Currency != "" {
+		db = db.Where("currency = ?", query.Currency)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.Rarity != "" {
+		db = db.Where("id IN (?)", r.db.Model(&domain.ClawMachineItem{}).
+			Select("claw_machine_item.claw_machine_id").
+			Joins("JOIN claw_item ON claw_item.id = claw_machine_item.item_id").
+			Where("claw_item.rarity = ?", query.Rarity))
+	}
+	if query.Tag != "" {
+		db = db.Where("id IN (?)", r.db.Model(&domain.ClawMachineTag{}).
+			Select("claw_machine_id").
+			Where("tag = ?", query.Tag))
+	}
+
+	// Keyset pagination: continue strictly after the last machine of the
+	// previous page, with the ID breaking ties between equal sort keys
+	direction, compare := "ASC", ">"
+	if descending {
+		direction, compare = "DESC", "<"
+	}
+	if after := query.After; after != nil {
+		if column == "id" {
+			db = db.Where("id "+compare+" ?", after.ID)
+		} else {
+			db = db.Where(
+				fmt.Sprintf("%s %s ? OR (%s = ? AND id %s ?)", column, compare, column, compare),
+				after.Key, after.Key, after.ID,
+			)
+		}
+	}
+	if column != "id" {
+		db = db.Order(column + " " + direction)
+	}
+	db = db.Order("id " + direction)
+
+	var clawMachines []*domain.ClawMachine
+	if err := db.Limit(query.Limit).Find(&clawMachines).Error; err != nil {
+		return nil, err
+	}
+	return clawMachines, nil
+}
+
 func (r *clawMachineRepository) CreateClawItems(items *[]domain.Item) (*[]domain.Item, error) {
 	err := r.db.Create(items).Error
 	if err != nil {
@@ -211,3 +493,159 @@ func (r *clawMachineRepository) CreateClawItems(items *[]domain.Item) (*[]domain
 	}
 	return items, nil
 }
+
+func (r *clawMachineRepository) CreatePromotion(promotion *domain.Promotion) (*domain.Promotion, error) {
+	err := r.db.Create(promotion).Error
+	if err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// GetActivePromotions returns the promotions whose time window contains at
+func (r *clawMachineRepository) GetActivePromotions(at time.Time) ([]domain.Promotion, error) {
+	var promotions []domain.Promotion
+	err := r.db.Preload("Machines").
+		Where("starts_at <= ? AND ends_at > ?", at, at).
+		Find(&promotions).Error
+	if err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (r *clawMachineRepository) CreateVouchers(vouchers []domain.Voucher) ([]domain.Voucher, error) {
+	if len(vouchers) == 0 {
+		return vouchers, nil
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkRewardItems(tx, vouchers); err != nil {
+			return err
+		}
+		return tx.CreateInBatches(&vouchers, 500).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+// checkRewardItems makes sure every item a voucher grants exists, so no code
+// is handed out that fails when redeemed
+func checkRewardItems(tx *gorm.DB, vouchers []domain.Voucher) error {
+	itemIDs := make(map[int64]struct{})
+	for _, v := range vouchers {
+		if v.RewardType == domain.VoucherRewardItem {
+			itemIDs[v.RewardItemID] = struct{}{}
+		}
+	}
+
+	for itemID := range itemIDs {
+		var exists bool
+		if err := tx.Model(&domain.Item{}).
+			Select("1").
+			Where("id = ?", itemID).
+			Limit(1).
+			Scan(&exists).Error; err != nil {
+			return err
+		}
+		if !exists {
+			return errcode.Newf(errcode.InvalidArgument, "reward item %d not found", itemID)
+		}
+	}
+	return nil
+}
+
+// RedeemVoucher validates code for playerID and grants its reward. The voucher
+// row is locked for the whole transaction so concurrent redemptions of the
+// same code are serialized and cannot exceed its redemption limits.
+func (r *clawMachineRepository) RedeemVoucher(code string, playerID int64, at time.Time) (*domain.Voucher, error) {
+	var voucher domain.Voucher
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("code = ?", code).
+			Limit(1).
+			Find(&voucher).Error
+		if err != nil {
+			return err
+		}
+		if voucher.ID == 0 {
+			return errcode.New(errcode.NotFound, "voucher not found")
+		}
+		if !at.Before(voucher.ExpiresAt) {
+			return errcode.New(errcode.VoucherExpired, "voucher expired")
+		}
+		if voucher.RedeemedCount >= voucher.MaxRedemptions {
+			return errcode.New(errcode.VoucherExhausted, "voucher fully redeemed")
+		}
+
+		var exists bool
+		if err := tx.Model(&domain.ClawPlayer{}).
+			Select("1").
+			Where("player_id = ?", playerID).
+			Limit(1).
+			Scan(&exists).Error; err != nil {
+			return err
+		}
+		if !exists {
+			return errcode.New(errcode.NotFound, "player not found")
+		}
+
+		var redeemed int64
+		if err := tx.Model(&domain.VoucherRedemption{}).
+			Where("voucher_id = ? AND player_id = ?", voucher.ID, playerID).
+			Count(&redeemed).Error; err != nil {
+			return err
+		}
+		if redeemed >= voucher.PerPlayerLimit {
+			return errcode.New(errcode.VoucherRedeemed, "voucher already redeemed by player")
+		}
+
+		if err := tx.Create(&domain.VoucherRedemption{
+			VoucherID:  voucher.ID,
+			PlayerID:   playerID,
+			RedeemedAt: at,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&domain.Voucher{}).
+			Where("id = ?", voucher.ID).
+			UpdateColumn("redeemed_count", gorm.Expr("redeemed_count + 1")).Error; err != nil {
+			return err
+		}
+		voucher.RedeemedCount++
+
+		return grantVoucherReward(tx, playerID, &voucher)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &voucher, nil
+}
+
+func grantVoucherReward(tx *gorm.DB, playerID int64, voucher *domain.Voucher) error {
+	switch voucher.RewardType {
+	case domain.VoucherRewardCoin, domain.VoucherRewardDiamond:
+		_, err := applyBalanceDelta(tx, playerID, voucher.RewardAmount, voucher.RewardType)
+		return err
+	case domain.VoucherRewardFreePlay:
+		return tx.Model(&domain.ClawPlayer{}).
+			Where("player_id = ?", playerID).
+			UpdateColumn("bonus_free_plays", gorm.Expr("bonus_free_plays + ?", voucher.RewardAmount)).Error
+	case domain.VoucherRewardItem:
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]any{
+				"quantity": gorm.Expr("quantity + ?", voucher.RewardAmount),
+			}),
+		}).Create(&domain.ClawPlayerItem{
+			PlayerID: playerID,
+			ItemID:   voucher.RewardItemID,
+			Quantity: voucher.RewardAmount,
+		}).Error
+	default:
+		return fmt.Errorf("unsupported voucher reward type: %s", voucher.RewardType)
+	}
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/pkg/errcode"
+)
+
+// mockDB returns a gorm database over sqlmock. Expectations match statements
+// by regular expression; use sql to match a literal fragment.
+func mockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		conn.Close()
+	})
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      conn,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open mock database: %v", err)
+	}
+	return db, mock
+}
+
+// sql matches a statement containing fragment
+func sql(fragment string) string {
+	return regexp.QuoteMeta(fragment)
+}
+
+func TestAddTouchedItemRecord(t *testing.T) {
+	tests := []struct {
+		name       string
+		updated    int64 // rows the conditional update changes
+		wantEvents bool
+	}{
+		{"settles", 1, true},
+		// a retried drop, or one whose Redis cleanup failed, finds the game
+		// settled and must not announce the outcome again
+		{"already settled", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDB(t)
+			repo := &clawMachineRepository{db: db}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(sql("SELECT * FROM `claw_machine_game_record` WHERE `claw_machine_game_record`.`id` = ?")).
+				WithArgs(int64(7), 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "claw_machine_id", "player_id"}).AddRow(7, 3, 42))
+			mock.ExpectExec(sql("UPDATE `claw_machine_game_record` SET `catched`=?,`settled`=?,`touched_item_id`=? WHERE id = ? AND settled = ?")).
+				WithArgs(true, true, int64(5), int64(7), false).
+				WillReturnResult(sqlmock.NewResult(0, tt.updated))
+			if tt.wantEvents {
+				mock.ExpectExec(sql("INSERT INTO `outbox_event`")).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(sql("INSERT INTO `outbox_event`")).WillReturnResult(sqlmock.NewResult(2, 1))
+			}
+			mock.ExpectCommit()
+
+			if err := repo.AddTouchedItemRecord(7, 5, true); err != nil {
+				t.Fatalf("AddTouchedItemRecord() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStartFreeGame(t *testing.T) {
+	errInsert := errors.New("insert failed")
+
+	tests := []struct {
+		name         string
+		dailyUsed    int64 // rows the daily allowance update changes
+		bonusUsed    int64 // rows the bonus update changes, if it runs
+		insertErr    error
+		wantConsumed bool
+	}{
+		{"daily free play", 1, 0, nil, true},
+		{"bonus free play", 0, 1, nil, true},
+		{"no free play left", 0, 0, nil, false},
+		// the free play is only spent if the game is recorded
+		{"record fails", 1, 0, errInsert, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDB(t)
+			repo := &clawMachineRepository{db: db}
+
+			mock.ExpectBegin()
+			mock.ExpectExec(sql("INSERT INTO `claw_player_entitlement`")).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec(sql("UPDATE `claw_player_entitlement` SET `free_plays_used`=free_plays_used + 1")).
+				WithArgs(int64(42), "2026-10-18", int32(3)).
+				WillReturnResult(sqlmock.NewResult(0, tt.dailyUsed))
+			if tt.dailyUsed == 0 {
+				mock.ExpectExec(sql("UPDATE `claw_player` SET `bonus_free_plays`=bonus_free_plays - 1")).
+					WillReturnResult(sqlmock.NewResult(0, tt.bonusUsed))
+			}
+
+			consumes := tt.dailyUsed+tt.bonusUsed > 0
+			switch {
+			case consumes && tt.insertErr != nil:
+				mock.ExpectExec(sql("INSERT INTO `claw_machine_game_record`")).WillReturnError(tt.insertErr)
+				mock.ExpectRollback()
+			case consumes:
+				mock.ExpectExec(sql("INSERT INTO `claw_machine_game_record`")).WillReturnResult(sqlmock.NewResult(9, 1))
+				mock.ExpectQuery(sql("SELECT * FROM `claw_machine_game_record`")).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "claw_machine_id", "player_id", "free_play"}).AddRow(9, 3, 42, true))
+				mock.ExpectExec(sql("UPDATE `claw_machine` SET `play_count`=play_count + 1")).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(sql("INSERT INTO `outbox_event`")).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			default:
+				mock.ExpectCommit()
+			}
+
+			record := &domain.ClawMachineGameRecord{PlayerID: 42, ClawMachineID: 3}
+			gameID, consumed, err := repo.StartFreeGame(record, "2026-10-18", 3)
+			if !errors.Is(err, tt.insertErr) {
+				t.Fatalf("StartFreeGame() error = %v, want %v", err, tt.insertErr)
+			}
+			if consumed != tt.wantConsumed {
+				t.Errorf("consumed = %v, want %v", consumed, tt.wantConsumed)
+			}
+			if tt.wantConsumed && gameID != 9 {
+				t.Errorf("gameID = %d, want 9", gameID)
+			}
+		})
+	}
+}
+
+func TestStartPaidGameRollsBackCharge(t *testing.T) {
+	db, mock := mockDB(t)
+	repo := &clawMachineRepository{db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(sql("UPDATE `claw_player` SET `coin`=coin + ?")).
+		WithArgs(int64(-50), int64(42), int64(-50)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(sql("SELECT * FROM `claw_player`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "coin"}).AddRow(1, 42, 150))
+	mock.ExpectExec(sql("INSERT INTO `outbox_event`")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(sql("INSERT INTO `claw_machine_game_record`")).WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	record := &domain.ClawMachineGameRecord{PlayerID: 42, ClawMachineID: 3, PricePaid: 50}
+	if _, err := repo.StartPaidGame(record, domain.MachineCurrencyCoin); err == nil {
+		t.Fatal("StartPaidGame() succeeded after the record failed")
+	}
+}
+
+func TestRedeemVoucher(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	// voucher returns the locked voucher row
+	voucher := func(redeemedCount, perPlayerLimit int64, expiresAt time.Time) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "code", "reward_type", "reward_amount", "max_redemptions", "redeemed_count", "per_player_limit", "expires_at"}).
+			AddRow(5, "WELCOME", domain.VoucherRewardFreePlay, 2, 10, redeemedCount, perPlayerLimit, expiresAt)
+	}
+
+	tests := []struct {
+		name          string
+		voucher       *sqlmock.Rows // nil when no voucher has the code
+		playerExists  bool
+		redeemedByYou int64 // the player's earlier redemptions of the code
+		wantCode      errcode.Code
+	}{
+		{"redeems", voucher(3, 1, now.Add(time.Hour)), true, 0, ""},
+		{"second of two per player", voucher(3, 2, now.Add(time.Hour)), true, 1, ""},
+		{"unknown code", nil, false, 0, errcode.NotFound},
+		// a voucher stops working at its expiry instant
+		{"expired", voucher(3, 1, now), false, 0, errcode.VoucherExpired},
+		{"exhausted", voucher(10, 1, now.Add(time.Hour)), false, 0, errcode.VoucherExhausted},
+		{"unknown player", voucher(3, 1, now.Add(time.Hour)), false, 0, errcode.NotFound},
+		{"already redeemed by player", voucher(3, 1, now.Add(time.Hour)), true, 1, errcode.VoucherRedeemed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDB(t)
+			repo := &clawMachineRepository{db: db}
+
+			mock.ExpectBegin()
+			lookup := mock.ExpectQuery(sql("SELECT * FROM `claw_voucher` WHERE code = ? LIMIT ? FOR UPDATE")).
+				WithArgs("WELCOME", 1)
+			if tt.voucher == nil {
+				lookup.WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			} else {
+				lookup.WillReturnRows(tt.voucher)
+			}
+
+			// the player is only looked up for a code that can still be redeemed
+			redeemable := tt.voucher != nil && tt.wantCode != errcode.VoucherExpired && tt.wantCode != errcode.VoucherExhausted
+			if redeemable {
+				rows := sqlmock.NewRows([]string{"1"})
+				if tt.playerExists {
+					rows.AddRow(1)
+				}
+				mock.ExpectQuery(sql("SELECT 1 FROM `claw_player` WHERE player_id = ?")).WillReturnRows(rows)
+			}
+			if tt.playerExists {
+				mock.ExpectQuery(sql("SELECT count(*) FROM `claw_voucher_redemption` WHERE voucher_id = ? AND player_id = ?")).
+					WithArgs(int64(5), int64(42)).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.redeemedByYou))
+			}
+
+			if tt.wantCode == "" {
+				mock.ExpectExec(sql("INSERT INTO `claw_voucher_redemption`")).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(sql("UPDATE `claw_voucher` SET `redeemed_count`=redeemed_count + 1 WHERE id = ?")).
+					WithArgs(int64(5)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(sql("UPDATE `claw_player` SET `bonus_free_plays`=bonus_free_plays + ?")).
+					WithArgs(int64(2), int64(42)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			redeemed, err := repo.RedeemVoucher("WELCOME", 42, now)
+			if tt.wantCode != "" {
+				if code := errcode.From(err).Code; code != tt.wantCode {
+					t.Fatalf("RedeemVoucher() error = %v, want %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RedeemVoucher() error = %v", err)
+			}
+			if redeemed.RedeemedCount != 4 {
+				t.Errorf("RedeemedCount = %d, want 4", redeemed.RedeemedCount)
+			}
+		})
+	}
+}
+
+func TestCreateVouchersChecksRewardItems(t *testing.T) {
+	tests := []struct {
+		name       string
+		itemExists bool
+		wantCode   errcode.Code
+	}{
+		{"item exists", true, ""},
+		{"unknown item", false, errcode.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDB(t)
+			repo := &clawMachineRepository{db: db}
+
+			rows := sqlmock.NewRows([]string{"1"})
+			if tt.itemExists {
+				rows.AddRow(1)
+			}
+
+			mock.ExpectBegin()
+			// the two codes share the item, so it is looked up once
+			mock.ExpectQuery(sql("SELECT 1 FROM `claw_item` WHERE id = ?")).
+				WithArgs(int64(8), 1).
+				WillReturnRows(rows)
+			if tt.itemExists {
+				mock.ExpectExec(sql("INSERT INTO `claw_voucher`")).WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			template := domain.Voucher{RewardType: domain.VoucherRewardItem, RewardAmount: 1, RewardItemID: 8}
+			a, b := template, template
+			a.Code, b.Code = "A", "B"
+
+			_, err := repo.CreateVouchers([]domain.Voucher{a, b})
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("CreateVouchers() error = %v", err)
+				}
+				return
+			}
+			if code := errcode.From(err).Code; code != tt.wantCode {
+				t.Fatalf("CreateVouchers() error = %v, want %s", err, tt.wantCode)
+			}
+		})
+	}
+}
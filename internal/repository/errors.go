@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+)
+
+// notFound reports gorm's missing record error as a catalogue NotFound error
+// naming what was looked up; other errors are returned unchanged
+func notFound(err error, what string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errcode.Wrap(errcode.NotFound, err, what+" not found")
+	}
+	return err
+}
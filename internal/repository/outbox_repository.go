@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Richard-inter/game/internal/domain"
+)
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+type OutboxRepository interface {
+	RelayPendingEvents(limit int, publish func(event *domain.OutboxEvent) error) (int, error)
+	PrunePublishedEvents(before time.Time, limit int) (int64, error)
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// appendEvents writes domain events to the outbox using the caller's transaction
+func appendEvents(tx *gorm.DB, events ...domain.Event) error {
+	for _, e := range events {
+		row, err := domain.NewOutboxEvent(e)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(row).Error; err != nil {
+			return fmt.Errorf("failed to write %s event to outbox: %w", e.EventType(), err)
+		}
+	}
+	return nil
+}
+
+// RelayPendingEvents hands up to limit unpublished events to publish in
+// creation order and marks the ones that succeeded as published. Rows are
+// locked with SKIP LOCKED so several relays can run side by side without
+// handing out the same event twice.
+func (r *outboxRepository) RelayPendingEvents(limit int, publish func(event *domain.OutboxEvent) error) (int, error) {
+	published := 0
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var pending []domain.OutboxEvent
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL").
+			Order("id").
+			Limit(limit).
+			Find(&pending).Error
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int64, 0, len(pending))
+		for i := range pending {
+			// Stop at the first failure so events keep their order on the stream
+			if err := publish(&pending[i]); err != nil {
+				if len(ids) == 0 {
+					return err
+				}
+				break
+			}
+			ids = append(ids, pending[i].ID)
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		published = len(ids)
+		return tx.Model(&domain.OutboxEvent{}).
+			Where("id IN ?", ids).
+			UpdateColumn("published_at", time.Now().UTC()).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
+
+// PrunePublishedEvents deletes up to limit events published before the given
+// time and returns how many were removed. Unpublished events are never
+// deleted, however old.
+func (r *outboxRepository) PrunePublishedEvents(before time.Time, limit int) (int64, error) {
+	res := r.db.Where("published_at < ?", before).
+		Limit(limit).
+		Delete(&domain.OutboxEvent{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
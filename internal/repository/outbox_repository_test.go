@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPrunePublishedEvents(t *testing.T) {
+	db, mock := mockDB(t)
+	repo := NewOutboxRepository(db)
+	before := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	// rows still waiting to be published are never matched
+	mock.ExpectBegin()
+	mock.ExpectExec(sql("DELETE FROM `outbox_event` WHERE published_at < ? LIMIT ?")).
+		WithArgs(before, 500).
+		WillReturnResult(sqlmock.NewResult(0, 42))
+	mock.ExpectCommit()
+
+	pruned, err := repo.PrunePublishedEvents(before, 500)
+	if err != nil {
+		t.Fatalf("PrunePublishedEvents() error = %v", err)
+	}
+	if pruned != 42 {
+		t.Errorf("pruned = %d, want 42", pruned)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/pkg/errcode"
 )
 
 type playerRepository struct {
@@ -28,7 +29,7 @@ func validateUsernameUnique(db *gorm.DB, username string) error {
 		return fmt.Errorf("failed to check username uniqueness: %w", err)
 	}
 	if count > 0 {
-		return fmt.Errorf("username '%s' already exists", username)
+		return errcode.Newf(errcode.AlreadyExists, "username '%s' already exists", username)
 	}
 	return nil
 }
@@ -37,7 +38,7 @@ func (r *playerRepository) GetPlayerinfo(id int64) (*domain.Player, error) {
 	var player domain.Player
 	err := r.db.Where("id = ?", id).First(&player).Error
 	if err != nil {
-		return nil, err
+		return nil, notFound(err, "player")
 	}
 	return &player, nil
 }
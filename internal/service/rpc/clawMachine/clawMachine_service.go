@@ -3,10 +3,15 @@ package clawmachine
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Richard-inter/game/internal/cache"
 	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/internal/entitlement"
+	"github.com/Richard-inter/game/internal/pricing"
 	"github.com/Richard-inter/game/internal/repository"
+	"github.com/Richard-inter/game/internal/voucher"
+	"github.com/Richard-inter/game/pkg/errcode"
 	pb "github.com/Richard-inter/game/pkg/protocol/clawMachine"
 	"github.com/Richard-inter/game/pkg/protocol/player"
 )
@@ -14,15 +19,21 @@ import (
 // ClawMachineGRPCService implements the ClawMachineService gRPC service
 type ClawMachineGRPCServices struct {
 	pb.UnimplementedClawMachineServiceServer
-	repo  repository.ClawMachineRepository
-	redis *cache.RedisClient
+	repo         repository.ClawMachineRepository
+	redis        *cache.RedisClient
+	entitlements *entitlement.Policy
 }
 
 // NewClawMachineGRPCService creates a new ClawMachineGRPCService
-func NewClawMachineGRPCService(repo repository.ClawMachineRepository, redis *cache.RedisClient) *ClawMachineGRPCServices {
+func NewClawMachineGRPCService(
+	repo repository.ClawMachineRepository,
+	redis *cache.RedisClient,
+	entitlements *entitlement.Policy,
+) *ClawMachineGRPCServices {
 	return &ClawMachineGRPCServices{
-		repo:  repo,
-		redis: redis,
+		repo:         repo,
+		redis:        redis,
+		entitlements: entitlements,
 	}
 }
 
@@ -49,48 +60,11 @@ func (s *ClawMachineGRPCServices) GetClawPlayerInfo(
 	}, nil
 }
 
+// StartClawGame is kept so existing REST clients get a typed error: games
+// are started over the runtime protocol, which simulates the claw on the
+// server. Nothing is charged.
 func (s *ClawMachineGRPCServices) StartClawGame(ctx context.Context, req *pb.StartClawGameReq) (*pb.StartClawGameResp, error) {
-	if req.PlayerID <= 0 || req.MachineID <= 0 {
-		return nil, fmt.Errorf("invalid player ID or machine ID")
-	}
-
-	results, err := s.PreDetermineCatchResults(ctx, req.MachineID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to pre-determine catch results: %w", err)
-	}
-
-	err = s.PlayMachine(ctx, req.PlayerID, req.MachineID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to charge player: %w", err)
-	}
-
-	gameID, err := s.repo.AddGameHistory(req.PlayerID, &domain.ClawMachineGameRecord{
-		PlayerID:      req.PlayerID,
-		ClawMachineID: req.MachineID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create game history: %w", err)
-	}
-
-	protoResults := make([]*pb.ClawResult, 0, len(results))
-	for _, result := range results {
-		clawResult := &pb.ClawResult{
-			ItemID:  result.ItemID,
-			Catched: &result.Success,
-		}
-		protoResults = append(protoResults, clawResult)
-	}
-
-	err = s.redis.StoreGameResults(ctx, gameID, results)
-	if err != nil {
-		// Log error but don't fail the request
-		fmt.Printf("Warning: failed to store game results in Redis: %v\n", err)
-	}
-
-	return &pb.StartClawGameResp{
-		GameID:  gameID,
-		Results: protoResults,
-	}, nil
+	return nil, errcode.New(errcode.Deprecated, "games are played over the runtime protocol")
 }
 
 func (s *ClawMachineGRPCServices) GetClawMachineInfo(
@@ -99,6 +73,11 @@ func (s *ClawMachineGRPCServices) GetClawMachineInfo(
 ) (*pb.GetClawMachineInfoResp, error) {
 	var machines []*pb.ClawMachine
 
+	promotions, err := s.repo.GetActivePromotions(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active promotions: %w", err)
+	}
+
 	// get all machines
 	if req.MachineID == 0 {
 		machineDomainList, err := s.repo.GetAllClawMachines()
@@ -107,25 +86,7 @@ func (s *ClawMachineGRPCServices) GetClawMachineInfo(
 		}
 
 		for _, resp := range machineDomainList {
-			items := make([]*pb.Item, 0, len(resp.Items))
-			for _, it := range resp.Items {
-				items = append(items, &pb.Item{
-					ItemID:          it.Item.ID,
-					Name:            it.Item.Name,
-					Rarity:          it.Item.Rarity,
-					SpawnPercentage: it.Item.SpawnPercentage,
-					CatchPercentage: it.Item.CatchPercentage,
-					MaxItemSpawned:  it.Item.MaxItemSpawned,
-				})
-			}
-
-			machines = append(machines, &pb.ClawMachine{
-				MachineID: resp.ID,
-				Name:      resp.Name,
-				Price:     resp.Price,
-				MaxItem:   resp.MaxItem,
-				Items:     items,
-			})
+			machines = append(machines, toProtoMachine(resp, promotions))
 		}
 	} else {
 		resp, err := s.repo.GetClawMachineInfo(req.MachineID)
@@ -133,25 +94,7 @@ func (s *ClawMachineGRPCServices) GetClawMachineInfo(
 			return nil, err
 		}
 
-		items := make([]*pb.Item, 0, len(resp.Items))
-		for _, it := range resp.Items {
-			items = append(items, &pb.Item{
-				ItemID:          it.Item.ID,
-				Name:            it.Item.Name,
-				Rarity:          it.Item.Rarity,
-				SpawnPercentage: it.Item.SpawnPercentage,
-				CatchPercentage: it.Item.CatchPercentage,
-				MaxItemSpawned:  it.Item.MaxItemSpawned,
-			})
-		}
-
-		machines = append(machines, &pb.ClawMachine{
-			MachineID: resp.ID,
-			Name:      resp.Name,
-			Price:     resp.Price,
-			MaxItem:   resp.MaxItem,
-			Items:     items,
-		})
+		machines = append(machines, toProtoMachine(resp, promotions))
 	}
 
 	return &pb.GetClawMachineInfoResp{
@@ -159,12 +102,68 @@ func (s *ClawMachineGRPCServices) GetClawMachineInfo(
 	}, nil
 }
 
+// ListClawMachines returns one page of the machines matching the request's
+// filters, and a token for the next page while there are more
+func (s *ClawMachineGRPCServices) ListClawMachines(
+	ctx context.Context,
+	req *pb.ListClawMachinesReq,
+) (*pb.ListClawMachinesResp, error) {
+	query, err := machineQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := query.Limit
+	query.Limit++ // one more than the page tells whether another page follows
+	machineDomainList, err := s.repo.ListClawMachines(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextPageToken string
+	if len(machineDomainList) > pageSize {
+		machineDomainList = machineDomainList[:pageSize]
+		nextPageToken = encodePageToken(query.Sort, query.Sort.Cursor(machineDomainList[pageSize-1]))
+	}
+
+	promotions, err := s.repo.GetActivePromotions(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active promotions: %w", err)
+	}
+
+	machines := make([]*pb.ClawMachine, 0, len(machineDomainList))
+	for _, machine := range machineDomainList {
+		machines = append(machines, toProtoMachine(machine, promotions))
+	}
+
+	return &pb.ListClawMachinesResp{
+		Machines:      machines,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
 func (s *ClawMachineGRPCServices) CreateClawMachine(ctx context.Context, req *pb.CreateClawMachineReq) (*pb.CreateClawMachineResp, error) {
+	currency, err := machineCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	status, err := machineStatus(req.Status)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := machineTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &domain.ClawMachine{
-		Name:    req.Name,
-		Price:   req.Price,
-		MaxItem: req.MaxItem,
-		Items:   make([]domain.ClawMachineItem, 0, len(req.Items)),
+		Name:     req.Name,
+		Price:    req.Price,
+		MaxItem:  req.MaxItem,
+		Currency: currency,
+		Status:   status,
+		Items:    make([]domain.ClawMachineItem, 0, len(req.Items)),
+		Tags:     tags,
 	}
 
 	for _, item := range req.Items {
@@ -178,26 +177,13 @@ func (s *ClawMachineGRPCServices) CreateClawMachine(ctx context.Context, req *pb
 		return nil, err
 	}
 
-	items := make([]*pb.Item, 0, len(created.Items))
-	for _, it := range created.Items {
-		items = append(items, &pb.Item{
-			ItemID:          it.Item.ID,
-			Name:            it.Item.Name,
-			Rarity:          it.Item.Rarity,
-			SpawnPercentage: it.Item.SpawnPercentage,
-			CatchPercentage: it.Item.CatchPercentage,
-			MaxItemSpawned:  it.Item.MaxItemSpawned,
-		})
+	promotions, err := s.repo.GetActivePromotions(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active promotions: %w", err)
 	}
 
 	return &pb.CreateClawMachineResp{
-		Machine: &pb.ClawMachine{
-			MachineID: created.ID,
-			Name:      created.Name,
-			Price:     created.Price,
-			MaxItem:   created.MaxItem,
-			Items:     items,
-		},
+		Machine: toProtoMachine(created, promotions),
 	}, nil
 }
 
@@ -287,43 +273,240 @@ func (s *ClawMachineGRPCServices) AdjustPlayerDiamond(ctx context.Context, req *
 	}, nil
 }
 
-func (s *ClawMachineGRPCServices) AddTouchedItemRecord(ctx context.Context, req *pb.AddTouchedItemRecordReq) (*pb.AddTouchedItemRecordResp, error) {
-	var storedResults []CatchResult
-	err := s.redis.GetGameResults(ctx, req.GameID, &storedResults)
+func (s *ClawMachineGRPCServices) GetEntitlements(ctx context.Context, req *pb.GetEntitlementsReq) (*pb.GetEntitlementsResp, error) {
+	if req.PlayerID <= 0 {
+		return nil, errcode.New(errcode.InvalidArgument, "invalid player ID")
+	}
+
+	player, err := s.repo.GetClawPlayerInfo(req.PlayerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load game results from Redis: %w", err)
+		return nil, err
 	}
 
-	var foundItem *CatchResult
-	for _, result := range storedResults {
-		if result.ItemID == req.ItemID {
-			foundItem = &result
-			break
+	now := time.Now()
+	resp := &pb.GetEntitlementsResp{
+		PlayerID:       req.PlayerID,
+		BonusFreePlays: player.BonusFreePlays,
+	}
+	if !s.entitlements.Enabled() {
+		return resp, nil
+	}
+
+	used, err := s.repo.GetFreePlaysUsed(req.PlayerID, s.entitlements.Period(now))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get free plays used: %w", err)
+	}
+
+	resp.DailyFreePlays = s.entitlements.DailyFreePlays
+	resp.FreePlaysUsed = used
+	resp.FreePlaysRemaining = max(s.entitlements.DailyFreePlays-used, 0)
+	resp.NextResetAt = s.entitlements.NextReset(now).Unix()
+
+	return resp, nil
+}
+
+func (s *ClawMachineGRPCServices) CreatePromotion(ctx context.Context, req *pb.CreatePromotionReq) (*pb.CreatePromotionResp, error) {
+	if req.Promotion == nil {
+		return nil, errcode.New(errcode.InvalidArgument, "promotion is required")
+	}
+
+	promotion := &domain.Promotion{
+		Name:          req.Promotion.Name,
+		DiscountType:  req.Promotion.DiscountType,
+		DiscountValue: req.Promotion.DiscountValue,
+		StartsAt:      time.Unix(req.Promotion.StartsAt, 0).UTC(),
+		EndsAt:        time.Unix(req.Promotion.EndsAt, 0).UTC(),
+		Machines:      make([]domain.PromotionMachine, 0, len(req.Promotion.MachineIDs)),
+	}
+	for _, machineID := range req.Promotion.MachineIDs {
+		promotion.Machines = append(promotion.Machines, domain.PromotionMachine{
+			ClawMachineID: machineID,
+		})
+	}
+
+	if err := pricing.ValidatePromotion(promotion); err != nil {
+		return nil, errcode.Wrap(errcode.InvalidArgument, err, err.Error())
+	}
+
+	created, err := s.repo.CreatePromotion(promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreatePromotionResp{
+		Promotion: toProtoPromotion(created),
+	}, nil
+}
+
+func (s *ClawMachineGRPCServices) GetActivePromotions(ctx context.Context, req *pb.GetActivePromotionsReq) (*pb.GetActivePromotionsResp, error) {
+	active, err := s.repo.GetActivePromotions(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	promotions := make([]*pb.Promotion, 0, len(active))
+	for i := range active {
+		if req.MachineID != 0 && !pricing.AppliesTo(&active[i], req.MachineID) {
+			continue
 		}
+		promotions = append(promotions, toProtoPromotion(&active[i]))
 	}
 
-	if foundItem.Success != *req.Catched {
-		err := s.redis.DeleteGameResults(ctx, req.GameID)
-		if err != nil {
-			fmt.Printf("Warning: failed to delete game results from Redis: %v\n", err)
+	return &pb.GetActivePromotionsResp{
+		Promotions: promotions,
+	}, nil
+}
+
+func (s *ClawMachineGRPCServices) CreateVouchers(ctx context.Context, req *pb.CreateVouchersReq) (*pb.CreateVouchersResp, error) {
+	template := domain.Voucher{
+		RewardType:     req.RewardType,
+		RewardAmount:   req.RewardAmount,
+		RewardItemID:   req.RewardItemID,
+		MaxRedemptions: req.MaxRedemptions,
+		PerPlayerLimit: req.PerPlayerLimit,
+		ExpiresAt:      time.Unix(req.ExpiresAt, 0).UTC(),
+	}
+	if template.PerPlayerLimit == 0 {
+		template.PerPlayerLimit = 1
+	}
+	if err := voucher.Validate(&template, time.Now()); err != nil {
+		return nil, errcode.Wrap(errcode.InvalidArgument, err, err.Error())
+	}
+
+	var vouchers []domain.Voucher
+	if req.Code != "" {
+		template.Code = voucher.NormalizeCode(req.Code)
+		vouchers = append(vouchers, template)
+	} else {
+		if req.Count <= 0 || req.Count > voucher.MaxBulkCount {
+			return nil, errcode.Newf(errcode.InvalidArgument, "count must be between 1 and %d", voucher.MaxBulkCount)
+		}
+
+		vouchers = make([]domain.Voucher, 0, req.Count)
+		for i := int32(0); i < req.Count; i++ {
+			code, err := voucher.GenerateCode(req.CodePrefix)
+			if err != nil {
+				return nil, err
+			}
+			v := template
+			v.Code = code
+			vouchers = append(vouchers, v)
 		}
-		return nil, fmt.Errorf("catched value mismatch: expected %t, got %t", foundItem.Success, *req.Catched)
 	}
 
-	err = s.repo.AddTouchedItemRecord(req.GameID, req.ItemID, *req.Catched)
+	created, err := s.repo.CreateVouchers(vouchers)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update touched item record: %w", err)
+		return nil, fmt.Errorf("failed to create vouchers: %w", err)
 	}
 
-	err = s.redis.DeleteGameResults(ctx, req.GameID)
+	resp := &pb.CreateVouchersResp{
+		Vouchers: make([]*pb.Voucher, 0, len(created)),
+	}
+	for i := range created {
+		resp.Vouchers = append(resp.Vouchers, toProtoVoucher(&created[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *ClawMachineGRPCServices) RedeemVoucher(ctx context.Context, req *pb.RedeemVoucherReq) (*pb.RedeemVoucherResp, error) {
+	if req.PlayerID <= 0 || req.Code == "" {
+		return nil, errcode.New(errcode.InvalidArgument, "invalid player ID or voucher code")
+	}
+
+	redeemed, err := s.repo.RedeemVoucher(voucher.NormalizeCode(req.Code), req.PlayerID, time.Now())
 	if err != nil {
-		// Log error but don't fail the request since validation passed
-		fmt.Printf("Warning: failed to delete game results from Redis: %v\n", err)
+		return nil, fmt.Errorf("failed to redeem voucher: %w", err)
 	}
 
-	return &pb.AddTouchedItemRecordResp{
-		GameID:  req.GameID,
-		ItemID:  req.ItemID,
-		Catched: req.Catched,
+	return &pb.RedeemVoucherResp{
+		PlayerID:     req.PlayerID,
+		Code:         redeemed.Code,
+		RewardType:   redeemed.RewardType,
+		RewardAmount: redeemed.RewardAmount,
+		RewardItemID: redeemed.RewardItemID,
 	}, nil
 }
+
+// AddTouchedItemRecord is kept so existing REST clients get a typed error:
+// the runtime protocol settles a game when the claw drops, and results are
+// never taken from the client.
+func (s *ClawMachineGRPCServices) AddTouchedItemRecord(ctx context.Context, req *pb.AddTouchedItemRecordReq) (*pb.AddTouchedItemRecordResp, error) {
+	return nil, errcode.New(errcode.Deprecated, "games are settled by the runtime protocol")
+}
+
+// currentMachinePrice returns the price a player pays right now and the
+// promotion responsible for it, if any
+func currentMachinePrice(machine *domain.ClawMachine, promotions []domain.Promotion) (int64, int64) {
+	price, promotion := pricing.EffectivePrice(machine.Price, machine.ID, promotions)
+	if promotion == nil {
+		return price, 0
+	}
+	return price, promotion.ID
+}
+
+// toProtoMachine converts a machine with whichever of its items were loaded
+func toProtoMachine(m *domain.ClawMachine, promotions []domain.Promotion) *pb.ClawMachine {
+	items := make([]*pb.Item, 0, len(m.Items))
+	for _, it := range m.Items {
+		items = append(items, &pb.Item{
+			ItemID:          it.Item.ID,
+			Name:            it.Item.Name,
+			Rarity:          it.Item.Rarity,
+			SpawnPercentage: it.Item.SpawnPercentage,
+			CatchPercentage: it.Item.CatchPercentage,
+			MaxItemSpawned:  it.Item.MaxItemSpawned,
+		})
+	}
+
+	tags := make([]string, 0, len(m.Tags))
+	for _, t := range m.Tags {
+		tags = append(tags, t.Tag)
+	}
+
+	currentPrice, promotionID := currentMachinePrice(m, promotions)
+	return &pb.ClawMachine{
+		MachineID:    m.ID,
+		Name:         m.Name,
+		Price:        m.Price,
+		MaxItem:      m.MaxItem,
+		Items:        items,
+		CurrentPrice: currentPrice,
+		PromotionID:  promotionID,
+		Currency:     m.Currency,
+		Status:       m.Status,
+		Tags:         tags,
+		PlayCount:    m.PlayCount,
+	}
+}
+
+func toProtoPromotion(p *domain.Promotion) *pb.Promotion {
+	machineIDs := make([]int64, 0, len(p.Machines))
+	for _, m := range p.Machines {
+		machineIDs = append(machineIDs, m.ClawMachineID)
+	}
+
+	return &pb.Promotion{
+		PromotionID:   p.ID,
+		Name:          p.Name,
+		MachineIDs:    machineIDs,
+		DiscountType:  p.DiscountType,
+		DiscountValue: p.DiscountValue,
+		StartsAt:      p.StartsAt.Unix(),
+		EndsAt:        p.EndsAt.Unix(),
+	}
+}
+
+func toProtoVoucher(v *domain.Voucher) *pb.Voucher {
+	return &pb.Voucher{
+		VoucherID:      v.ID,
+		Code:           v.Code,
+		RewardType:     v.RewardType,
+		RewardAmount:   v.RewardAmount,
+		RewardItemID:   v.RewardItemID,
+		MaxRedemptions: v.MaxRedemptions,
+		RedeemedCount:  v.RedeemedCount,
+		PerPlayerLimit: v.PerPlayerLimit,
+		ExpiresAt:      v.ExpiresAt.Unix(),
+	}
+}
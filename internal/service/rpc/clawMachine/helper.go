@@ -12,12 +12,6 @@ type SpawnConfig struct {
 	MaxOutput int // max items in machine output
 }
 
-type CatchResult struct {
-	ItemID  int64  `json:"itemID"`
-	Name    string `json:"name"`
-	Success bool   `json:"success"`
-}
-
 type SpawnItem struct {
 	ID           int64
 	SpawnPercent int // absolute probability (0-100)
@@ -148,58 +142,3 @@ func (s *ClawMachineGRPCServices) SpawnMachineItems(
 
 	return spawnedIDs, nil
 }
-
-// PreDetermineCatchResults generates a list of pre-determined catch results for all items
-func (s *ClawMachineGRPCServices) PreDetermineCatchResults(
-	ctx context.Context,
-	machineID int64,
-) ([]*CatchResult, error) {
-	// Get machine info to access items and their catch percentages
-	clawMachine, err := s.repo.GetClawMachineInfo(machineID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get machine info: %w", err)
-	}
-
-	if len(clawMachine.Items) == 0 {
-		return nil, fmt.Errorf("no items in machine to catch from")
-	}
-
-	results := make([]*CatchResult, 0, len(clawMachine.Items))
-
-	// Generate pre-determined result for each item in the machine
-	for _, item := range clawMachine.Items {
-		catchWeight := item.Item.CatchPercentage
-		if catchWeight == 0 {
-			return nil, fmt.Errorf("database error: item %s (ID: %d) has zero catch percentage", item.Item.Name, item.Item.ID)
-		}
-
-		// Determine if catch is successful based on the item's catch percentage
-		catchSuccess := Roll(int(catchWeight))
-
-		results = append(results, &CatchResult{
-			ItemID:  item.ID,
-			Name:    item.Item.Name,
-			Success: catchSuccess,
-		})
-	}
-
-	return results, nil
-}
-
-func (s *ClawMachineGRPCServices) PlayMachine(
-	ctx context.Context,
-	playerID int64,
-	machineID int64,
-) error {
-	clawMachine, err := s.repo.GetClawMachineInfo(machineID)
-	if err != nil {
-		return fmt.Errorf("failed to get machine info: %w", err)
-	}
-
-	_, err = s.repo.AdjustPlayerCoin(playerID, int64(clawMachine.Price), "minus")
-	if err != nil {
-		return fmt.Errorf("failed to adjust player coin: %w", err)
-	}
-
-	return nil
-}
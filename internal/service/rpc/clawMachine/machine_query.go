@@ -0,0 +1,150 @@
+package clawmachine
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/internal/repository"
+	"github.com/Richard-inter/game/pkg/errcode"
+	pb "github.com/Richard-inter/game/pkg/protocol/clawMachine"
+)
+
+const (
+	defaultMachinePageSize = 20
+	maxMachinePageSize     = 100
+	maxMachineTagLength    = 32
+)
+
+// pageToken is the decoded form of a ListClawMachines page token. It names
+// its sort so a token cannot be replayed against a different order.
+type pageToken struct {
+	Sort repository.MachineSort `json:"s"`
+	Key  int64                  `json:"k"`
+	ID   int64                  `json:"i"`
+}
+
+// machineQuery validates a ListClawMachines request and turns it into a
+// repository query for one page
+func machineQuery(req *pb.ListClawMachinesReq) (repository.ClawMachineQuery, error) {
+	query := repository.ClawMachineQuery{
+		MinPrice:  req.MinPrice,
+		MaxPrice:  req.MaxPrice,
+		Rarity:    req.Rarity,
+		Tag:       strings.ToLower(strings.TrimSpace(req.Tag)),
+		Sort:      repository.MachineSort(req.SortBy),
+		Limit:     int(req.PageSize),
+		WithItems: !req.OmitItems,
+	}
+
+	if query.Sort == "" {
+		query.Sort = repository.MachineSortNewest
+	}
+	if !query.Sort.Valid() {
+		return query, errcode.Newf(errcode.InvalidArgument, "invalid sortBy: %s", req.SortBy)
+	}
+
+	switch {
+	case query.Limit < 0:
+		return query, errcode.New(errcode.InvalidArgument, "pageSize must not be negative")
+	case query.Limit == 0:
+		query.Limit = defaultMachinePageSize
+	case query.Limit > maxMachinePageSize:
+		query.Limit = maxMachinePageSize
+	}
+
+	if query.MinPrice < 0 || query.MaxPrice < 0 {
+		return query, errcode.New(errcode.InvalidArgument, "price bounds must not be negative")
+	}
+	if query.MaxPrice > 0 && query.MinPrice > query.MaxPrice {
+		return query, errcode.New(errcode.InvalidArgument, "minPrice is above maxPrice")
+	}
+
+	if req.Currency != "" {
+		currency, err := machineCurrency(req.Currency)
+		if err != nil {
+			return query, err
+		}
+		query.Currency = currency
+	}
+	if req.Status != "" {
+		status, err := machineStatus(req.Status)
+		if err != nil {
+			return query, err
+		}
+		query.Status = status
+	}
+
+	if req.PageToken != "" {
+		cursor, err := decodePageToken(req.PageToken, query.Sort)
+		if err != nil {
+			return query, err
+		}
+		query.After = cursor
+	}
+
+	return query, nil
+}
+
+func encodePageToken(sort repository.MachineSort, cursor repository.MachineCursor) string {
+	data, _ := json.Marshal(pageToken{Sort: sort, Key: cursor.Key, ID: cursor.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(token string, sort repository.MachineSort) (*repository.MachineCursor, error) {
+	var decoded pageToken
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &decoded)
+	}
+	if err != nil || decoded.ID <= 0 {
+		return nil, errcode.New(errcode.InvalidArgument, "invalid pageToken")
+	}
+	if decoded.Sort != sort {
+		return nil, errcode.New(errcode.InvalidArgument, "pageToken belongs to a different sortBy")
+	}
+	return &repository.MachineCursor{Key: decoded.Key, ID: decoded.ID}, nil
+}
+
+// machineCurrency validates a machine currency, defaulting to coin
+func machineCurrency(currency string) (string, error) {
+	switch currency {
+	case "":
+		return domain.MachineCurrencyCoin, nil
+	case domain.MachineCurrencyCoin, domain.MachineCurrencyDiamond:
+		return currency, nil
+	default:
+		return "", errcode.Newf(errcode.InvalidArgument, "invalid currency: %s", currency)
+	}
+}
+
+// machineStatus validates a machine status, defaulting to active
+func machineStatus(status string) (string, error) {
+	switch status {
+	case "":
+		return domain.MachineStatusActive, nil
+	case domain.MachineStatusActive, domain.MachineStatusMaintenance, domain.MachineStatusRetired:
+		return status, nil
+	default:
+		return "", errcode.Newf(errcode.InvalidArgument, "invalid status: %s", status)
+	}
+}
+
+// machineTags lower-cases and de-duplicates tags, dropping empty ones
+func machineTags(tags []string) ([]domain.ClawMachineTag, error) {
+	seen := make(map[string]bool, len(tags))
+	result := make([]domain.ClawMachineTag, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > maxMachineTagLength {
+			return nil, errcode.Newf(errcode.InvalidArgument, "tag longer than %d characters: %s", maxMachineTagLength, tag)
+		}
+		seen[tag] = true
+		result = append(result, domain.ClawMachineTag{Tag: tag})
+	}
+	return result, nil
+}
@@ -0,0 +1,180 @@
+package clawmachine
+
+import (
+	"encoding/base64"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/internal/repository"
+	"github.com/Richard-inter/game/pkg/errcode"
+	pb "github.com/Richard-inter/game/pkg/protocol/clawMachine"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	tests := []struct {
+		sort   repository.MachineSort
+		cursor repository.MachineCursor
+	}{
+		{repository.MachineSortNewest, repository.MachineCursor{Key: 31, ID: 31}},
+		{repository.MachineSortPriceAsc, repository.MachineCursor{Key: 0, ID: 4}},
+		{repository.MachineSortPriceDesc, repository.MachineCursor{Key: 1 << 40, ID: 1<<53 + 1}},
+		{repository.MachineSortPopularity, repository.MachineCursor{Key: 12, ID: 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.sort), func(t *testing.T) {
+			token := encodePageToken(tt.sort, tt.cursor)
+			got, err := decodePageToken(token, tt.sort)
+			if err != nil {
+				t.Fatalf("decodePageToken(%q) error = %v", token, err)
+			}
+			if *got != tt.cursor {
+				t.Errorf("decoded %+v, want %+v", *got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodePageTokenRejects(t *testing.T) {
+	encode := func(json string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(json))
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		sort  repository.MachineSort
+	}{
+		{"another sort", encodePageToken(repository.MachineSortPriceAsc, repository.MachineCursor{Key: 100, ID: 3}), repository.MachineSortPriceDesc},
+		{"newest token for popularity", encodePageToken(repository.MachineSortNewest, repository.MachineCursor{Key: 3, ID: 3}), repository.MachineSortPopularity},
+		{"not base64", "%%%", repository.MachineSortNewest},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"s":"newest","k":3,"i":3}`)), repository.MachineSortNewest},
+		{"not JSON", encode("newest:3"), repository.MachineSortNewest},
+		{"no ID", encode(`{"s":"newest","k":3}`), repository.MachineSortNewest},
+		{"negative ID", encode(`{"s":"newest","k":3,"i":-3}`), repository.MachineSortNewest},
+		{"no sort", encode(`{"k":3,"i":3}`), repository.MachineSortNewest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := decodePageToken(tt.token, tt.sort)
+			if err == nil {
+				t.Fatalf("decodePageToken() = %+v, want an error", cursor)
+			}
+			if code := errcode.FromGRPC(err).Code; code != errcode.InvalidArgument {
+				t.Errorf("error code = %s, want %s", code, errcode.InvalidArgument)
+			}
+		})
+	}
+}
+
+func TestMachineQuery(t *testing.T) {
+	priceToken := encodePageToken(repository.MachineSortPriceAsc, repository.MachineCursor{Key: 100, ID: 8})
+
+	tests := []struct {
+		name    string
+		req     *pb.ListClawMachinesReq
+		want    repository.ClawMachineQuery
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			req:  &pb.ListClawMachinesReq{},
+			want: repository.ClawMachineQuery{Sort: repository.MachineSortNewest, Limit: defaultMachinePageSize, WithItems: true},
+		},
+		{
+			name: "filters",
+			req: &pb.ListClawMachinesReq{
+				MinPrice: 10, MaxPrice: 50, Currency: domain.MachineCurrencyDiamond, Status: domain.MachineStatusActive,
+				Rarity: "rare", Tag: "  Holiday ", SortBy: "popularity", PageSize: 5, OmitItems: true,
+			},
+			want: repository.ClawMachineQuery{
+				MinPrice: 10, MaxPrice: 50, Currency: domain.MachineCurrencyDiamond, Status: domain.MachineStatusActive,
+				Rarity: "rare", Tag: "holiday", Sort: repository.MachineSortPopularity, Limit: 5,
+			},
+		},
+		{
+			name: "page size capped",
+			req:  &pb.ListClawMachinesReq{PageSize: maxMachinePageSize + 1},
+			want: repository.ClawMachineQuery{Sort: repository.MachineSortNewest, Limit: maxMachinePageSize, WithItems: true},
+		},
+		{
+			name: "next page",
+			req:  &pb.ListClawMachinesReq{SortBy: "price_asc", PageToken: priceToken},
+			want: repository.ClawMachineQuery{
+				Sort: repository.MachineSortPriceAsc, After: &repository.MachineCursor{Key: 100, ID: 8},
+				Limit: defaultMachinePageSize, WithItems: true,
+			},
+		},
+		{"token from another sort", &pb.ListClawMachinesReq{SortBy: "price_desc", PageToken: priceToken}, repository.ClawMachineQuery{}, true},
+		{"token from another sort by default", &pb.ListClawMachinesReq{PageToken: priceToken}, repository.ClawMachineQuery{}, true},
+		{"unknown sort", &pb.ListClawMachinesReq{SortBy: "cheapest"}, repository.ClawMachineQuery{}, true},
+		{"negative page size", &pb.ListClawMachinesReq{PageSize: -1}, repository.ClawMachineQuery{}, true},
+		{"negative price", &pb.ListClawMachinesReq{MinPrice: -1}, repository.ClawMachineQuery{}, true},
+		{"inverted price range", &pb.ListClawMachinesReq{MinPrice: 50, MaxPrice: 10}, repository.ClawMachineQuery{}, true},
+		{"unknown currency", &pb.ListClawMachinesReq{Currency: "gold"}, repository.ClawMachineQuery{}, true},
+		{"unknown status", &pb.ListClawMachinesReq{Status: "broken"}, repository.ClawMachineQuery{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := machineQuery(tt.req)
+			if tt.wantErr {
+				if code := errcode.FromGRPC(err).Code; code != errcode.InvalidArgument {
+					t.Errorf("error = %v, want %s", err, errcode.InvalidArgument)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("machineQuery() error = %v", err)
+			}
+
+			after := got.After
+			got.After = nil
+			want := tt.want
+			wantAfter := want.After
+			want.After = nil
+			if got != want {
+				t.Errorf("machineQuery() = %+v, want %+v", got, want)
+			}
+			if (after == nil) != (wantAfter == nil) || (after != nil && *after != *wantAfter) {
+				t.Errorf("After = %v, want %v", after, wantAfter)
+			}
+		})
+	}
+}
+
+func TestMachineTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr bool
+	}{
+		{"none", nil, []string{}, false},
+		{"normalised and de-duplicated", []string{" Holiday", "holiday", "", "NEW "}, []string{"holiday", "new"}, false},
+		{"longest tag", []string{strings.Repeat("x", maxMachineTagLength)}, []string{strings.Repeat("x", maxMachineTagLength)}, false},
+		{"too long", []string{strings.Repeat("x", maxMachineTagLength+1)}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := machineTags(tt.tags)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("machineTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				return
+			}
+			names := make([]string, len(got))
+			for i, tag := range got {
+				names[i] = tag.Tag
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("machineTags() = %q, want %q", names, tt.want)
+			}
+		})
+	}
+}
@@ -2,27 +2,48 @@ package clawmachine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	flatbuffers "github.com/google/flatbuffers/go"
+	"go.uber.org/zap"
 
+	"github.com/Richard-inter/game/internal/auth"
 	"github.com/Richard-inter/game/internal/cache"
+	"github.com/Richard-inter/game/internal/clawsim"
 	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/internal/entitlement"
+	"github.com/Richard-inter/game/internal/pricing"
 	"github.com/Richard-inter/game/internal/repository"
+	"github.com/Richard-inter/game/internal/voucher"
+	"github.com/Richard-inter/game/pkg/errcode"
 	pb "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket"
 	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
 )
 
+// clawGameTTL is how long a started game can be played before it expires
+const clawGameTTL = 5 * time.Minute
+
 type ClawMachineWebsocketService struct {
 	pb.UnimplementedClawMachineRuntimeServiceServer
-	repo  repository.ClawMachineRepository
-	redis *cache.RedisClient
+	repo         repository.ClawMachineRepository
+	redis        *cache.RedisClient
+	entitlements *entitlement.Policy
+	logger       *zap.SugaredLogger
 }
 
-func NewClawMachineWebsocketService(repo repository.ClawMachineRepository, redis *cache.RedisClient) *ClawMachineWebsocketService {
+func NewClawMachineWebsocketService(
+	repo repository.ClawMachineRepository,
+	redis *cache.RedisClient,
+	entitlements *entitlement.Policy,
+	logger *zap.SugaredLogger,
+) *ClawMachineWebsocketService {
 	return &ClawMachineWebsocketService{
-		repo:  repo,
-		redis: redis,
+		repo:         repo,
+		redis:        redis,
+		entitlements: entitlements,
+		logger:       logger,
 	}
 }
 
@@ -30,16 +51,18 @@ func (s *ClawMachineWebsocketService) StartClawGameWs(
 	ctx context.Context,
 	req *pb.RuntimeRequest,
 ) (*pb.RuntimeResponse, error) {
+	playerID, err := authenticatedPlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	startReq := fbs.GetRootAsStartClawGameReq(req.Payload, 0)
-	playerID := startReq.PlayerId()
 	machineID := startReq.MachineId()
 
-	fmt.Println("StartClawGameReq received")
-	fmt.Println("  PlayerID :", playerID)
-	fmt.Println("  MachineID:", machineID)
+	s.logger.Debugw("StartClawGameReq received", "player_id", playerID, "machine_id", machineID)
 
-	if playerID <= 0 || machineID <= 0 {
-		return nil, fmt.Errorf("invalid player ID or machine ID")
+	if machineID <= 0 {
+		return nil, errcode.New(errcode.InvalidArgument, "invalid machine ID")
 	}
 
 	results, err := s.PreDetermineCatchResults(ctx, int64(machineID))
@@ -47,43 +70,33 @@ func (s *ClawMachineWebsocketService) StartClawGameWs(
 		return nil, fmt.Errorf("failed to pre-determine catch results: %w", err)
 	}
 
-	err = s.PlayMachine(ctx, int64(playerID), int64(machineID))
+	gameID, charge, err := s.StartGame(ctx, playerID, int64(machineID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to charge player: %w", err)
+		return nil, fmt.Errorf("failed to start game: %w", err)
 	}
 
-	gameID, err := s.repo.AddGameHistory(int64(playerID), &domain.ClawMachineGameRecord{
-		PlayerID:      int64(playerID),
-		ClawMachineID: int64(machineID),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create game history: %w", err)
+	items := make([]clawsim.Item, len(results))
+	for i, result := range results {
+		items[i] = clawsim.Item{ID: result.ItemID, Catch: result.Success}
 	}
+	game := clawsim.NewGame(gameID, playerID, int64(machineID), items)
 
-	err = s.redis.StoreGameResults(ctx, gameID, results)
+	err = s.redis.StoreClawGame(ctx, gameID, game, clawGameTTL)
 	if err != nil {
-		// Log error but don't fail the request
-		fmt.Printf("Warning: failed to store game results in Redis: %v\n", err)
+		return nil, fmt.Errorf("failed to store claw game: %w", err)
 	}
 
 	builder := flatbuffers.NewBuilder(1024)
-	resultOffsets := make([]flatbuffers.UOffsetT, len(results))
-	for i := len(results) - 1; i >= 0; i-- {
-		fbs.ClawResultStart(builder)
-		fbs.ClawResultAddItemId(builder, uint64(results[i].ItemID))
-		fbs.ClawResultAddCatched(builder, results[i].Success)
-		resultOffsets[i] = fbs.ClawResultEnd(builder)
-	}
-
-	fbs.StartClawGameRespStartResultsVector(builder, len(resultOffsets))
-	for i := len(resultOffsets) - 1; i >= 0; i-- {
-		builder.PrependUOffsetT(resultOffsets[i])
-	}
-	resultsVector := builder.EndVector(len(resultOffsets))
+	boardOffset := buildClawBoard(builder, game)
+	clawOffset := buildClawState(builder, game)
 
 	fbs.StartClawGameRespStart(builder)
 	fbs.StartClawGameRespAddGameId(builder, uint64(gameID))
-	fbs.StartClawGameRespAddResults(builder, resultsVector)
+	fbs.StartClawGameRespAddFreePlay(builder, charge.FreePlay)
+	fbs.StartClawGameRespAddPricePaid(builder, charge.PricePaid)
+	fbs.StartClawGameRespAddPromotionId(builder, uint64(charge.PromotionID))
+	fbs.StartClawGameRespAddBoard(builder, boardOffset)
+	fbs.StartClawGameRespAddClaw(builder, clawOffset)
 	respOffset := fbs.StartClawGameRespEnd(builder)
 
 	builder.Finish(respOffset)
@@ -107,10 +120,12 @@ func (s *ClawMachineWebsocketService) GetPlayerInfoWs(
 	ctx context.Context,
 	req *pb.RuntimeRequest,
 ) (*pb.RuntimeResponse, error) {
-	startReq := fbs.GetRootAsGetPlayerInfoWsReq(req.Payload, 0)
-	playerID := startReq.PlayerId()
+	playerID, err := authenticatedPlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	domainPlayer, err := s.repo.GetClawPlayerInfo(int64(playerID))
+	domainPlayer, err := s.repo.GetClawPlayerInfo(playerID)
 	if err != nil {
 		return nil, err
 	}
@@ -143,55 +158,112 @@ func (s *ClawMachineWebsocketService) GetPlayerInfoWs(
 	}, nil
 }
 
-func (s *ClawMachineWebsocketService) AddTouchedItemRecordWs(
+// ClawInputWs applies a move or drop to the claw of a running game. The
+// drop is resolved on the server's copy of the board and settles the game.
+// A retried drop answers with the same outcome; the game record is settled
+// only once, so a retry after a failed settlement completes it without
+// announcing the outcome twice.
+func (s *ClawMachineWebsocketService) ClawInputWs(
 	ctx context.Context,
 	req *pb.RuntimeRequest,
 ) (*pb.RuntimeResponse, error) {
-	startReq := fbs.GetRootAsAddTouchedItemRecordReq(req.Payload, 0)
-	gameID := startReq.GameId()
-	itemID := startReq.ItemId()
-	catched := startReq.Catched()
-
-	var storedResults []CatchResult
-	err := s.redis.GetGameResults(ctx, int64(gameID), &storedResults)
+	playerID, err := authenticatedPlayer(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load game results from Redis: %w", err)
+		return nil, err
+	}
+
+	input := fbs.GetRootAsClawInputReq(req.Payload, 0)
+	gameID := int64(input.GameId())
+	if gameID <= 0 {
+		return nil, errcode.New(errcode.InvalidArgument, "invalid game ID")
 	}
 
-	var foundItem *CatchResult
-	for _, result := range storedResults {
-		if result.ItemID == int64(itemID) {
-			foundItem = &result
-			break
+	var game clawsim.Game
+	err = s.redis.UpdateClawGame(ctx, gameID, &game, func() error {
+		if game.PlayerID != playerID {
+			return errcode.Newf(errcode.PermissionDenied, "game %d does not belong to the player", gameID)
 		}
+
+		switch input.Action() {
+		case fbs.ClawActionMove:
+			return simError(game.Move(input.Dx(), input.Dy()))
+		case fbs.ClawActionDrop:
+			if game.Phase == clawsim.PhaseSettled {
+				return nil
+			}
+			_, err := game.Drop()
+			return simError(err)
+		default:
+			return simError(clawsim.ErrInvalidInput)
+		}
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if foundItem.Success != catched {
-		err := s.redis.DeleteGameResults(ctx, int64(gameID))
+	if game.Phase == clawsim.PhaseSettled {
+		err = s.repo.AddTouchedItemRecord(gameID, game.Result.ItemID, game.Result.Catched)
+		if err != nil {
+			return nil, fmt.Errorf("failed to settle game: %w", err)
+		}
+
+		err = s.redis.DeleteClawGame(ctx, gameID)
 		if err != nil {
-			fmt.Printf("Warning: failed to delete game results from Redis: %v\n", err)
+			// the game is settled and expires on its own
+			s.logger.Warnw("Failed to delete settled claw game", "game_id", gameID, "error", err)
 		}
-		return nil, fmt.Errorf("catched value mismatch: expected %t, got %t", foundItem.Success, catched)
 	}
 
-	err = s.repo.AddTouchedItemRecord(int64(gameID), int64(itemID), catched)
+	builder := flatbuffers.NewBuilder(128)
+	builder.Finish(buildClawState(builder, &game))
+	respBytes := builder.FinishedBytes()
+
+	envBuilder := flatbuffers.NewBuilder(256)
+	payloadOffset := envBuilder.CreateByteVector(respBytes)
+
+	fbs.EnvelopeStart(envBuilder)
+	fbs.EnvelopeAddType(envBuilder, fbs.MessageTypeClawStateResp)
+	fbs.EnvelopeAddPayload(envBuilder, payloadOffset)
+	envOffset := fbs.EnvelopeEnd(envBuilder)
+	envBuilder.Finish(envOffset)
+
+	return &pb.RuntimeResponse{
+		Payload: envBuilder.FinishedBytes(),
+	}, nil
+}
+
+func (s *ClawMachineWebsocketService) RedeemVoucherWs(
+	ctx context.Context,
+	req *pb.RuntimeRequest,
+) (*pb.RuntimeResponse, error) {
+	playerID, err := authenticatedPlayer(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update touched item record: %w", err)
+		return nil, err
 	}
 
-	err = s.redis.DeleteGameResults(ctx, int64(gameID))
+	redeemReq := fbs.GetRootAsRedeemVoucherReq(req.Payload, 0)
+	code := voucher.NormalizeCode(string(redeemReq.Code()))
+
+	if code == "" {
+		return nil, errcode.New(errcode.InvalidArgument, "invalid voucher code")
+	}
+
+	redeemed, err := s.repo.RedeemVoucher(code, playerID, time.Now())
 	if err != nil {
-		// Log error but don't fail the request since validation passed
-		fmt.Printf("Warning: failed to delete game results from Redis: %v\n", err)
+		return nil, fmt.Errorf("failed to redeem voucher: %w", err)
 	}
 
 	builder := flatbuffers.NewBuilder(256)
-
-	fbs.AddTouchedItemRecordRespStart(builder)
-	fbs.AddTouchedItemRecordRespAddGameId(builder, gameID)
-	fbs.AddTouchedItemRecordRespAddItemId(builder, itemID)
-	fbs.AddTouchedItemRecordRespAddCatched(builder, catched)
-	respOffset := fbs.AddTouchedItemRecordRespEnd(builder)
+	codeOffset := builder.CreateString(redeemed.Code)
+	rewardTypeOffset := builder.CreateString(redeemed.RewardType)
+
+	fbs.RedeemVoucherRespStart(builder)
+	fbs.RedeemVoucherRespAddPlayerId(builder, uint64(playerID))
+	fbs.RedeemVoucherRespAddCode(builder, codeOffset)
+	fbs.RedeemVoucherRespAddRewardType(builder, rewardTypeOffset)
+	fbs.RedeemVoucherRespAddRewardAmount(builder, redeemed.RewardAmount)
+	fbs.RedeemVoucherRespAddRewardItemId(builder, uint64(redeemed.RewardItemID))
+	respOffset := fbs.RedeemVoucherRespEnd(builder)
 	builder.Finish(respOffset)
 	respBytes := builder.FinishedBytes()
 
@@ -199,7 +271,70 @@ func (s *ClawMachineWebsocketService) AddTouchedItemRecordWs(
 	payloadOffset := envBuilder.CreateByteVector(respBytes)
 
 	fbs.EnvelopeStart(envBuilder)
-	fbs.EnvelopeAddType(envBuilder, fbs.MessageTypeAddTouchedItemRecordResp)
+	fbs.EnvelopeAddType(envBuilder, fbs.MessageTypeRedeemVoucherResp)
+	fbs.EnvelopeAddPayload(envBuilder, payloadOffset)
+	envOffset := fbs.EnvelopeEnd(envBuilder)
+	envBuilder.Finish(envOffset)
+
+	return &pb.RuntimeResponse{
+		Payload: envBuilder.FinishedBytes(),
+	}, nil
+}
+
+func (s *ClawMachineWebsocketService) GetMachineInfoWs(
+	ctx context.Context,
+	req *pb.RuntimeRequest,
+) (*pb.RuntimeResponse, error) {
+	_, err := authenticatedPlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	infoReq := fbs.GetRootAsGetMachineInfoWsReq(req.Payload, 0)
+	machineID := infoReq.MachineId()
+
+	var machines []*domain.ClawMachine
+	if machineID == 0 {
+		machines, err = s.repo.GetAllClawMachines()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get machines: %w", err)
+		}
+	} else {
+		machine, err := s.repo.GetClawMachineInfo(int64(machineID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get machine info: %w", err)
+		}
+		machines = append(machines, machine)
+	}
+
+	promotions, err := s.repo.GetActivePromotions(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active promotions: %w", err)
+	}
+
+	builder := flatbuffers.NewBuilder(1024)
+	machineOffsets := make([]flatbuffers.UOffsetT, len(machines))
+	for i, machine := range machines {
+		machineOffsets[i] = buildMachineInfo(builder, machine, promotions)
+	}
+
+	fbs.GetMachineInfoWsRespStartMachinesVector(builder, len(machineOffsets))
+	for i := len(machineOffsets) - 1; i >= 0; i-- {
+		builder.PrependUOffsetT(machineOffsets[i])
+	}
+	machinesVector := builder.EndVector(len(machineOffsets))
+
+	fbs.GetMachineInfoWsRespStart(builder)
+	fbs.GetMachineInfoWsRespAddMachines(builder, machinesVector)
+	respOffset := fbs.GetMachineInfoWsRespEnd(builder)
+	builder.Finish(respOffset)
+	respBytes := builder.FinishedBytes()
+
+	envBuilder := flatbuffers.NewBuilder(len(respBytes) + 64)
+	payloadOffset := envBuilder.CreateByteVector(respBytes)
+
+	fbs.EnvelopeStart(envBuilder)
+	fbs.EnvelopeAddType(envBuilder, fbs.MessageTypeGetMachineInfoWsResp)
 	fbs.EnvelopeAddPayload(envBuilder, payloadOffset)
 	envOffset := fbs.EnvelopeEnd(envBuilder)
 	envBuilder.Finish(envOffset)
@@ -208,3 +343,110 @@ func (s *ClawMachineWebsocketService) AddTouchedItemRecordWs(
 		Payload: envBuilder.FinishedBytes(),
 	}, nil
 }
+
+// buildMachineInfo writes one machine with its item odds and the price a
+// game costs right now
+func buildMachineInfo(builder *flatbuffers.Builder, machine *domain.ClawMachine, promotions []domain.Promotion) flatbuffers.UOffsetT {
+	itemOffsets := make([]flatbuffers.UOffsetT, len(machine.Items))
+	for i, it := range machine.Items {
+		nameOffset := builder.CreateString(it.Item.Name)
+		rarityOffset := builder.CreateString(it.Item.Rarity)
+
+		fbs.MachineItemStart(builder)
+		fbs.MachineItemAddItemId(builder, uint64(it.Item.ID))
+		fbs.MachineItemAddName(builder, nameOffset)
+		fbs.MachineItemAddRarity(builder, rarityOffset)
+		fbs.MachineItemAddSpawnPercentage(builder, it.Item.SpawnPercentage)
+		fbs.MachineItemAddCatchPercentage(builder, it.Item.CatchPercentage)
+		fbs.MachineItemAddMaxItemSpawned(builder, it.Item.MaxItemSpawned)
+		itemOffsets[i] = fbs.MachineItemEnd(builder)
+	}
+
+	fbs.MachineInfoStartItemsVector(builder, len(itemOffsets))
+	for i := len(itemOffsets) - 1; i >= 0; i-- {
+		builder.PrependUOffsetT(itemOffsets[i])
+	}
+	itemsVector := builder.EndVector(len(itemOffsets))
+
+	nameOffset := builder.CreateString(machine.Name)
+
+	currentPrice, promotion := pricing.EffectivePrice(machine.Price, machine.ID, promotions)
+	var promotionID int64
+	if promotion != nil {
+		promotionID = promotion.ID
+	}
+
+	fbs.MachineInfoStart(builder)
+	fbs.MachineInfoAddMachineId(builder, uint64(machine.ID))
+	fbs.MachineInfoAddName(builder, nameOffset)
+	fbs.MachineInfoAddPrice(builder, machine.Price)
+	fbs.MachineInfoAddMaxItem(builder, machine.MaxItem)
+	fbs.MachineInfoAddItems(builder, itemsVector)
+	fbs.MachineInfoAddCurrentPrice(builder, currentPrice)
+	fbs.MachineInfoAddPromotionId(builder, uint64(promotionID))
+	return fbs.MachineInfoEnd(builder)
+}
+
+// buildClawBoard writes the board of a game without the catch rolls
+func buildClawBoard(builder *flatbuffers.Builder, game *clawsim.Game) flatbuffers.UOffsetT {
+	itemOffsets := make([]flatbuffers.UOffsetT, len(game.Items))
+	for i, item := range game.Items {
+		fbs.BoardItemStart(builder)
+		fbs.BoardItemAddItemId(builder, uint64(item.ID))
+		fbs.BoardItemAddX(builder, item.X)
+		fbs.BoardItemAddY(builder, item.Y)
+		itemOffsets[i] = fbs.BoardItemEnd(builder)
+	}
+
+	fbs.ClawBoardStartItemsVector(builder, len(itemOffsets))
+	for i := len(itemOffsets) - 1; i >= 0; i-- {
+		builder.PrependUOffsetT(itemOffsets[i])
+	}
+	itemsVector := builder.EndVector(len(itemOffsets))
+
+	fbs.ClawBoardStart(builder)
+	fbs.ClawBoardAddWidth(builder, clawsim.BoardWidth)
+	fbs.ClawBoardAddHeight(builder, clawsim.BoardHeight)
+	fbs.ClawBoardAddGrabRadius(builder, clawsim.GrabRadius)
+	fbs.ClawBoardAddMaxStep(builder, clawsim.MaxStep)
+	fbs.ClawBoardAddMaxMoves(builder, clawsim.MaxMoves)
+	fbs.ClawBoardAddItems(builder, itemsVector)
+	return fbs.ClawBoardEnd(builder)
+}
+
+// buildClawState writes the claw position, and the outcome once settled
+func buildClawState(builder *flatbuffers.Builder, game *clawsim.Game) flatbuffers.UOffsetT {
+	fbs.ClawStateRespStart(builder)
+	fbs.ClawStateRespAddGameId(builder, uint64(game.GameID))
+	fbs.ClawStateRespAddX(builder, game.X)
+	fbs.ClawStateRespAddY(builder, game.Y)
+	fbs.ClawStateRespAddMovesLeft(builder, int32(game.MovesLeft()))
+	if game.Phase == clawsim.PhaseSettled && game.Result != nil {
+		fbs.ClawStateRespAddSettled(builder, true)
+		fbs.ClawStateRespAddItemId(builder, uint64(game.Result.ItemID))
+		fbs.ClawStateRespAddCatched(builder, game.Result.Catched)
+	}
+	return fbs.ClawStateRespEnd(builder)
+}
+
+// simError maps simulation errors to the catalogue
+func simError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, clawsim.ErrSettled):
+		return errcode.Wrap(errcode.GameExpired, err, "game already ended")
+	default:
+		return errcode.Wrap(errcode.InvalidArgument, err, err.Error())
+	}
+}
+
+// authenticatedPlayer returns the player the gateway authenticated for this
+// call. Player IDs sent in request payloads are ignored.
+func authenticatedPlayer(ctx context.Context) (int64, error) {
+	playerID, ok := auth.PlayerIDFromContext(ctx)
+	if !ok {
+		return 0, errcode.New(errcode.Unauthenticated, "missing authenticated player")
+	}
+	return playerID, nil
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"math/rand/v2"
+	"time"
+
+	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/internal/pricing"
 )
 
 type SpawnConfig struct {
@@ -16,6 +20,13 @@ type CatchResult struct {
 	Success bool   `json:"success"`
 }
 
+// GameCharge describes what the player paid to start a game
+type GameCharge struct {
+	FreePlay    bool
+	PricePaid   int64
+	PromotionID int64
+}
+
 type SpawnItem struct {
 	ID           int64
 	SpawnPercent int // absolute probability (0-100)
@@ -127,20 +138,86 @@ func (s *ClawMachineWebsocketService) PreDetermineCatchResults(
 	return results, nil
 }
 
+// PlayMachine records a game charged at the machine's current price in its
+// currency
 func (s *ClawMachineWebsocketService) PlayMachine(
+	ctx context.Context,
+	record *domain.ClawMachineGameRecord,
+) (int64, *GameCharge, error) {
+	clawMachine, err := s.repo.GetClawMachineInfo(record.ClawMachineID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get machine info: %w", err)
+	}
+
+	promotions, err := s.repo.GetActivePromotions(time.Now())
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get active promotions: %w", err)
+	}
+
+	price, promotion := pricing.EffectivePrice(clawMachine.Price, record.ClawMachineID, promotions)
+	record.PricePaid = price
+	if promotion != nil {
+		record.PromotionID = promotion.ID
+	}
+	charge := &GameCharge{
+		PricePaid:   record.PricePaid,
+		PromotionID: record.PromotionID,
+	}
+
+	if price == 0 {
+		if _, err := s.repo.GetClawPlayerInfo(record.PlayerID); err != nil {
+			return 0, nil, fmt.Errorf("failed to get player info: %w", err)
+		}
+		gameID, err := s.repo.AddGameHistory(record.PlayerID, record)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to create game history: %w", err)
+		}
+		return gameID, charge, nil
+	}
+
+	currency := domain.MachineCurrencyCoin
+	if clawMachine.Currency == domain.MachineCurrencyDiamond {
+		currency = domain.MachineCurrencyDiamond
+	}
+	gameID, err := s.repo.StartPaidGame(record, currency)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to charge player %s: %w", currency, err)
+	}
+
+	return gameID, charge, nil
+}
+
+// StartGame records a new game for the player and charges for it in the same
+// transaction: one of the player's daily free plays when available, then any
+// bonus free plays, and otherwise the machine's current price in its
+// currency.
+func (s *ClawMachineWebsocketService) StartGame(
 	ctx context.Context,
 	playerID int64,
 	machineID int64,
-) error {
-	clawMachine, err := s.repo.GetClawMachineInfo(machineID)
-	if err != nil {
-		return fmt.Errorf("failed to get machine info: %w", err)
+) (int64, *GameCharge, error) {
+	record := &domain.ClawMachineGameRecord{
+		PlayerID:      playerID,
+		ClawMachineID: machineID,
 	}
 
-	_, err = s.repo.AdjustPlayerCoin(playerID, int64(clawMachine.Price), "minus")
+	var period string
+	var dailyLimit int32
+	if s.entitlements.Enabled() {
+		if _, err := s.repo.GetClawPlayerInfo(playerID); err != nil {
+			return 0, nil, fmt.Errorf("failed to get player info: %w", err)
+		}
+		period = s.entitlements.Period(time.Now())
+		dailyLimit = s.entitlements.DailyFreePlays
+	}
+
+	gameID, consumed, err := s.repo.StartFreeGame(record, period, dailyLimit)
 	if err != nil {
-		return fmt.Errorf("failed to adjust player coin: %w", err)
+		return 0, nil, fmt.Errorf("failed to consume free play: %w", err)
+	}
+	if consumed {
+		return gameID, &GameCharge{FreePlay: true}, nil
 	}
 
-	return nil
+	return s.PlayMachine(ctx, record)
 }
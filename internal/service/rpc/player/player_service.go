@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Richard-inter/game/internal/auth"
 	"github.com/Richard-inter/game/internal/domain"
 	"github.com/Richard-inter/game/internal/repository"
+	"github.com/Richard-inter/game/pkg/errcode"
 	pb "github.com/Richard-inter/game/pkg/protocol/player"
 )
 
@@ -13,12 +15,15 @@ import (
 type PlayerGRPCService struct {
 	pb.UnimplementedPlayerServiceServer
 	repo repository.PlayerRepository
+	auth *auth.Authenticator
 }
 
-// NewPlayerGRPCService creates a new PlayerGRPCService
-func NewPlayerGRPCService(repo repository.PlayerRepository) *PlayerGRPCService {
+// NewPlayerGRPCService creates a new PlayerGRPCService. authenticator signs
+// the tokens IssueToken hands out.
+func NewPlayerGRPCService(repo repository.PlayerRepository, authenticator *auth.Authenticator) *PlayerGRPCService {
 	return &PlayerGRPCService{
 		repo: repo,
+		auth: authenticator,
 	}
 }
 
@@ -57,3 +62,25 @@ func (s *PlayerGRPCService) CreatePlayer(ctx context.Context, req *pb.CreatePlay
 		},
 	}, nil
 }
+
+// IssueToken signs a gateway token for an existing player. The caller is
+// trusted to have authenticated the player; the service token guarding this
+// method is checked by the server's interceptor.
+func (s *PlayerGRPCService) IssueToken(ctx context.Context, req *pb.IssueTokenReq) (*pb.IssueTokenResp, error) {
+	if req.PlayerID <= 0 {
+		return nil, errcode.New(errcode.InvalidArgument, "playerID must be positive")
+	}
+	if _, err := s.repo.GetPlayerinfo(req.PlayerID); err != nil {
+		return nil, err
+	}
+
+	token, expiresAt, err := s.auth.IssueTokenWithExpiry(req.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.IssueTokenResp{
+		Token:     token,
+		ExpiresAt: expiresAt.Unix(),
+	}, nil
+}
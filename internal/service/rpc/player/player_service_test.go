@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/internal/domain"
+	"github.com/Richard-inter/game/internal/repository"
+	"github.com/Richard-inter/game/pkg/errcode"
+	pb "github.com/Richard-inter/game/pkg/protocol/player"
+)
+
+// fakePlayerRepository knows the players in players
+type fakePlayerRepository struct {
+	repository.PlayerRepository
+	players map[int64]*domain.Player
+}
+
+func (r *fakePlayerRepository) GetPlayerinfo(id int64) (*domain.Player, error) {
+	player, ok := r.players[id]
+	if !ok {
+		return nil, errcode.New(errcode.NotFound, "player not found")
+	}
+	return player, nil
+}
+
+func TestIssueToken(t *testing.T) {
+	authenticator, err := auth.NewAuthenticator(config.JWTConfig{Secret: "test-secret", ExpirationTime: 3600})
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+	repo := &fakePlayerRepository{players: map[int64]*domain.Player{7: {ID: 7, UserName: "alice"}}}
+	s := NewPlayerGRPCService(repo, authenticator)
+
+	tests := []struct {
+		name     string
+		playerID int64
+		wantCode errcode.Code // "" when a token is issued
+	}{
+		{"existing player", 7, ""},
+		{"unknown player", 8, errcode.NotFound},
+		{"zero player ID", 0, errcode.InvalidArgument},
+		{"negative player ID", -7, errcode.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.IssueToken(context.Background(), &pb.IssueTokenReq{PlayerID: tt.playerID})
+			if tt.wantCode != "" {
+				if code := errcode.CodeOf(err); code != tt.wantCode {
+					t.Fatalf("IssueToken() error = %v, want %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("IssueToken() error = %v", err)
+			}
+
+			playerID, err := authenticator.Authenticate(resp.Token)
+			if err != nil || playerID != tt.playerID {
+				t.Errorf("token authenticates as %d (%v), want %d", playerID, err, tt.playerID)
+			}
+			if lifetime := time.Until(time.Unix(resp.ExpiresAt, 0)); lifetime < 59*time.Minute || lifetime > time.Hour {
+				t.Errorf("token expires in %s, want about 1h", lifetime)
+			}
+		})
+	}
+}
@@ -27,6 +27,11 @@ func NewClawMachineClient(address string) (*ClawMachineClient, error) {
 	}, nil
 }
 
+// Service returns the generated client, which the REST gateway calls directly
+func (c *ClawMachineClient) Service() clawmachinepb.ClawMachineServiceClient {
+	return c.client
+}
+
 func (c *ClawMachineClient) GetClawPlayerInfo(ctx context.Context, req *clawmachinepb.GetClawPlayerInfoReq) (*clawmachinepb.GetClawPlayerInfoResp, error) {
 	return c.client.GetClawPlayerInfo(ctx, req)
 }
@@ -39,6 +44,10 @@ func (c *ClawMachineClient) GetClawMachineInfo(ctx context.Context, req *clawmac
 	return c.client.GetClawMachineInfo(ctx, req)
 }
 
+func (c *ClawMachineClient) ListClawMachines(ctx context.Context, req *clawmachinepb.ListClawMachinesReq) (*clawmachinepb.ListClawMachinesResp, error) {
+	return c.client.ListClawMachines(ctx, req)
+}
+
 func (c *ClawMachineClient) CreateClawMachine(ctx context.Context, req *clawmachinepb.CreateClawMachineReq) (*clawmachinepb.CreateClawMachineResp, error) {
 	return c.client.CreateClawMachine(ctx, req)
 }
@@ -59,10 +68,30 @@ func (c *ClawMachineClient) AdjustPlayerDiamond(ctx context.Context, req *clawma
 	return c.client.AdjustPlayerDiamond(ctx, req)
 }
 
+func (c *ClawMachineClient) GetEntitlements(ctx context.Context, req *clawmachinepb.GetEntitlementsReq) (*clawmachinepb.GetEntitlementsResp, error) {
+	return c.client.GetEntitlements(ctx, req)
+}
+
 func (c *ClawMachineClient) AddTouchedItemRecord(ctx context.Context, req *clawmachinepb.AddTouchedItemRecordReq) (*clawmachinepb.AddTouchedItemRecordResp, error) {
 	return c.client.AddTouchedItemRecord(ctx, req)
 }
 
+func (c *ClawMachineClient) CreatePromotion(ctx context.Context, req *clawmachinepb.CreatePromotionReq) (*clawmachinepb.CreatePromotionResp, error) {
+	return c.client.CreatePromotion(ctx, req)
+}
+
+func (c *ClawMachineClient) GetActivePromotions(ctx context.Context, req *clawmachinepb.GetActivePromotionsReq) (*clawmachinepb.GetActivePromotionsResp, error) {
+	return c.client.GetActivePromotions(ctx, req)
+}
+
 func (c *ClawMachineClient) Close() error {
 	return c.conn.Close()
 }
+
+func (c *ClawMachineClient) CreateVouchers(ctx context.Context, req *clawmachinepb.CreateVouchersReq) (*clawmachinepb.CreateVouchersResp, error) {
+	return c.client.CreateVouchers(ctx, req)
+}
+
+func (c *ClawMachineClient) RedeemVoucher(ctx context.Context, req *clawmachinepb.RedeemVoucherReq) (*clawmachinepb.RedeemVoucherResp, error) {
+	return c.client.RedeemVoucher(ctx, req)
+}
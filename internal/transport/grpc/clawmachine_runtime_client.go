@@ -31,8 +31,8 @@ func (c *ClawMachineRuntimeClient) StartClawGameWs(ctx context.Context, req *run
 	return c.client.StartClawGameWs(ctx, req)
 }
 
-func (c *ClawMachineRuntimeClient) AddTouchedItemRecordWs(ctx context.Context, req *runtimepb.RuntimeRequest) (*runtimepb.RuntimeResponse, error) {
-	return c.client.AddTouchedItemRecordWs(ctx, req)
+func (c *ClawMachineRuntimeClient) ClawInputWs(ctx context.Context, req *runtimepb.RuntimeRequest) (*runtimepb.RuntimeResponse, error) {
+	return c.client.ClawInputWs(ctx, req)
 }
 
 func (c *ClawMachineRuntimeClient) GetPlayerSnapshotWs(ctx context.Context, req *runtimepb.RuntimeRequest) (*runtimepb.RuntimeResponse, error) {
@@ -42,3 +42,7 @@ func (c *ClawMachineRuntimeClient) GetPlayerSnapshotWs(ctx context.Context, req
 func (c *ClawMachineRuntimeClient) GetMachineSnapshotWs(ctx context.Context, req *runtimepb.RuntimeRequest) (*runtimepb.RuntimeResponse, error) {
 	return c.client.GetMachineInfoWs(ctx, req)
 }
+
+func (c *ClawMachineRuntimeClient) RedeemVoucherWs(ctx context.Context, req *runtimepb.RuntimeRequest) (*runtimepb.RuntimeResponse, error) {
+	return c.client.RedeemVoucherWs(ctx, req)
+}
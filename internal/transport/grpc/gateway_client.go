@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/Richard-inter/game/internal/auth"
+	gatewaypb "github.com/Richard-inter/game/pkg/protocol/gateway"
+)
+
+type GatewayClient struct {
+	client gatewaypb.GatewayServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewGatewayClient connects to the push API of a gateway, authenticating
+// with the gateway's service token
+func NewGatewayClient(address, serviceToken string) (*GatewayClient, error) {
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(auth.ServiceUnaryClientInterceptor(serviceToken)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to gateway service: %w", err)
+	}
+
+	return &GatewayClient{
+		client: gatewaypb.NewGatewayServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+func (c *GatewayClient) PushToPlayer(ctx context.Context, req *gatewaypb.PushToPlayerReq) (*gatewaypb.PushResp, error) {
+	return c.client.PushToPlayer(ctx, req)
+}
+
+func (c *GatewayClient) PushToRoom(ctx context.Context, req *gatewaypb.PushToRoomReq) (*gatewaypb.PushResp, error) {
+	return c.client.PushToRoom(ctx, req)
+}
+
+func (c *GatewayClient) Close() error {
+	return c.conn.Close()
+}
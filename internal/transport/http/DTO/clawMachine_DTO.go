@@ -1,31 +1,6 @@
 package dto
 
-// CreateClawMachineRequest represents the HTTP request for creating a claw machine
-type CreateClawMachineRequest struct {
-	Name    string                         `json:"name" binding:"required"`
-	Price   int64                          `json:"price" binding:"required"`
-	MaxItem int32                          `json:"maxItem" binding:"required"`
-	Items   []CreateClawMachineItemRequest `json:"items"`
-}
-
-// CreateClawMachineItemRequest represents an item in the claw machine creation request
-type CreateClawMachineItemRequest struct {
-	ItemID int64 `json:"itemID" binding:"required"`
-}
-
-// CreateClawItemsRequest represents the HTTP request for creating claw items
-type CreateClawItemsRequest struct {
-	ClawItems []CreateClawItemRequest `json:"clawItems" binding:"required"`
-}
-
-// CreateClawItemRequest represents the HTTP request for creating a single claw item
-type CreateClawItemRequest struct {
-	Name            string `json:"name" binding:"required"`
-	Rarity          string `json:"rarity" binding:"required"`
-	SpawnPercentage int64  `json:"spawnPercentage" binding:"required"`
-	CatchPercentage int64  `json:"catchPercentage" binding:"required"`
-	MaxItemSpawned  int64  `json:"maxItemSpawned" binding:"required"`
-}
+import "time"
 
 type CreateClawPlayerRequest struct {
 	PlayerID int64  `json:"playerID" binding:"required"`
@@ -34,26 +9,26 @@ type CreateClawPlayerRequest struct {
 	Diamond  int64  `json:"diamond" binding:"required"`
 }
 
-type AdjustPlayerCoinRequest struct {
-	PlayerID int64  `json:"playerID" binding:"required"`
-	Amount   int64  `json:"amount" binding:"required"`
-	Type     string `json:"type" binding:"required,oneof=plus minus"`
-}
-
-type AdjustPlayerDiamondRequest struct {
-	PlayerID int64  `json:"playerID" binding:"required"`
-	Amount   int64  `json:"amount" binding:"required"`
-	Type     string `json:"type" binding:"required,oneof=plus minus"`
-}
-
-type StartClawGameRequest struct {
-	PlayerID  int64 `json:"playerID" binding:"required"`
-	MachineID int64 `json:"machineID" binding:"required"`
-	// TouchedItemID int64 `json:"touchedItemID" binding:"required"`
-}
-
-type AddTouchedItemRecordRequest struct {
-	GameID  int64 `json:"gameID" binding:"required"`
-	ItemID  int64 `json:"itemID" binding:"required"`
-	Catched *bool `json:"catched" binding:"required"`
+// CreatePromotionRequest represents the HTTP request for creating a time-window promotion
+type CreatePromotionRequest struct {
+	Name          string    `json:"name" binding:"required"`
+	MachineIDs    []int64   `json:"machineIDs"`
+	DiscountType  string    `json:"discountType" binding:"required,oneof=percentage fixed"`
+	DiscountValue int64     `json:"discountValue" binding:"required"`
+	StartsAt      time.Time `json:"startsAt" binding:"required"`
+	EndsAt        time.Time `json:"endsAt" binding:"required"`
+}
+
+// CreateVouchersRequest represents the HTTP request for creating one named
+// voucher or a batch of generated codes
+type CreateVouchersRequest struct {
+	Count          int32     `json:"count"`
+	CodePrefix     string    `json:"codePrefix"`
+	Code           string    `json:"code"`
+	RewardType     string    `json:"rewardType" binding:"required,oneof=coin diamond free_play item"`
+	RewardAmount   int64     `json:"rewardAmount" binding:"required"`
+	RewardItemID   int64     `json:"rewardItemID"`
+	MaxRedemptions int64     `json:"maxRedemptions" binding:"required"`
+	PerPlayerLimit int64     `json:"perPlayerLimit"`
+	ExpiresAt      time.Time `json:"expiresAt" binding:"required"`
 }
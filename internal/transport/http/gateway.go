@@ -0,0 +1,146 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Richard-inter/game/pkg/common"
+	"github.com/Richard-inter/game/pkg/protocol/clawMachine"
+)
+
+// gatewayRoute is a gin route declared by the google.api.http option of an
+// RPC, such as GET /api/v1/clawMachine/getClawMachineInfo/:machineID
+type gatewayRoute struct {
+	method string
+	path   string
+}
+
+// newGateway returns the REST gateway generated from ClawMachineService,
+// forwarding to client. Responses and errors use the same envelope, status
+// codes and JSON encoding as the hand-written handlers.
+func newGateway(logger *zap.SugaredLogger, client clawMachine.ClawMachineServiceClient) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &gatewayMarshaler{
+			JSONPb: runtime.JSONPb{
+				UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+			},
+		}),
+		// gRPC response headers are not passed on as Grpc-Metadata-* headers
+		runtime.WithOutgoingHeaderMatcher(func(string) (string, bool) {
+			return "", false
+		}),
+		runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+			if created(ctx) {
+				w.WriteHeader(http.StatusCreated)
+			}
+			return nil
+		}),
+		runtime.WithForwardResponseRewriter(func(ctx context.Context, resp proto.Message) (any, error) {
+			if created(ctx) {
+				return common.Created(resp), nil
+			}
+			return common.Success(resp), nil
+		}),
+		runtime.WithErrorHandler(func(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+			method, _ := runtime.RPCMethod(ctx)
+			logger.Errorw("Gateway call failed", "method", method, "error", err)
+
+			status, resp := common.ErrorFrom(err)
+			body, _ := json.Marshal(resp)
+			w.Header().Set("Content-Type", gatewayContentType)
+			w.WriteHeader(status)
+			_, _ = w.Write(body)
+		}),
+	)
+
+	if err := clawMachine.RegisterClawMachineServiceHandlerClient(context.Background(), mux, client); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// created reports whether the call is a Create RPC, which answers 201 like
+// the hand-written create handlers
+func created(ctx context.Context) bool {
+	method, ok := runtime.RPCMethod(ctx)
+	if !ok {
+		return false
+	}
+	_, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return strings.HasPrefix(name, "Create")
+}
+
+const gatewayContentType = "application/json; charset=utf-8"
+
+// gatewayMarshaler decodes requests with protojson, accepting the proto
+// field names the routes have always taken, and encodes responses with
+// encoding/json so int64 stay numbers and zero values are left out
+type gatewayMarshaler struct {
+	runtime.JSONPb
+}
+
+func (gatewayMarshaler) ContentType(any) string {
+	return gatewayContentType
+}
+
+func (gatewayMarshaler) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (gatewayMarshaler) NewEncoder(w io.Writer) runtime.Encoder {
+	return json.NewEncoder(w)
+}
+
+// gatewayRoutes lists the routes of every ClawMachineService method with a
+// google.api.http option, turning {name} path variables into :name
+func gatewayRoutes() []gatewayRoute {
+	service := clawMachine.File_clawMachine_clawMachine_proto.Services().ByName("ClawMachineService")
+	methods := service.Methods()
+
+	var routes []gatewayRoute
+	for i := 0; i < methods.Len(); i++ {
+		rule, ok := proto.GetExtension(methods.Get(i).Options(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || rule == nil {
+			continue
+		}
+		if route, ok := routeOf(rule); ok {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+func routeOf(rule *annotations.HttpRule) (gatewayRoute, bool) {
+	var method, path string
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		method, path = http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Post:
+		method, path = http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Put:
+		method, path = http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Delete:
+		method, path = http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Patch:
+		method, path = http.MethodPatch, pattern.Patch
+	default:
+		return gatewayRoute{}, false
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		}
+	}
+	return gatewayRoute{method: method, path: strings.Join(segments, "/")}, true
+}
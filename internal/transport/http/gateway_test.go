@@ -0,0 +1,212 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+	"github.com/Richard-inter/game/pkg/protocol/clawMachine"
+)
+
+// fakeClawMachineClient answers the calls the tests make and records the
+// requests it got; the other methods panic
+type fakeClawMachineClient struct {
+	clawMachine.ClawMachineServiceClient
+	requests []any
+}
+
+func (f *fakeClawMachineClient) GetClawMachineInfo(_ context.Context, in *clawMachine.GetClawMachineInfoReq, _ ...grpc.CallOption) (*clawMachine.GetClawMachineInfoResp, error) {
+	f.requests = append(f.requests, in)
+	if in.MachineID == 404 {
+		return nil, errcode.Newf(errcode.NotFound, "claw machine %d not found", in.MachineID).GRPCStatus().Err()
+	}
+	if in.MachineID == 500 {
+		return nil, errors.New("connection refused by 10.0.0.7")
+	}
+	return &clawMachine.GetClawMachineInfoResp{
+		Machine: []*clawMachine.ClawMachine{{MachineID: in.MachineID, Name: "lucky", Price: 10}},
+	}, nil
+}
+
+func (f *fakeClawMachineClient) CreateClawMachine(_ context.Context, in *clawMachine.CreateClawMachineReq, _ ...grpc.CallOption) (*clawMachine.CreateClawMachineResp, error) {
+	f.requests = append(f.requests, in)
+	return &clawMachine.CreateClawMachineResp{Machine: &clawMachine.ClawMachine{MachineID: 1, Name: in.Name}}, nil
+}
+
+func (f *fakeClawMachineClient) AdjustPlayerCoin(_ context.Context, in *clawMachine.AdjustPlayerCoinReq, _ ...grpc.CallOption) (*clawMachine.AdjustPlayerCoinResp, error) {
+	f.requests = append(f.requests, in)
+	return &clawMachine.AdjustPlayerCoinResp{PlayerID: in.PlayerID, AdjustedAmount: in.Amount}, nil
+}
+
+func (f *fakeClawMachineClient) StartClawGame(_ context.Context, in *clawMachine.StartClawGameReq, _ ...grpc.CallOption) (*clawMachine.StartClawGameResp, error) {
+	f.requests = append(f.requests, in)
+	return nil, errcode.New(errcode.Deprecated, "games are played over the runtime protocol").GRPCStatus().Err()
+}
+
+func TestGatewayRoutes(t *testing.T) {
+	routes := gatewayRoutes()
+
+	for _, want := range []gatewayRoute{
+		{http.MethodGet, "/api/v1/clawMachine/getClawMachineInfo/:machineID"},
+		{http.MethodGet, "/api/v1/clawMachine/listClawMachines"},
+		{http.MethodPost, "/api/v1/clawMachine/adjustPlayerCoin"},
+		{http.MethodPost, "/api/v1/clawMachine/redeemVoucher"},
+	} {
+		if !slices.Contains(routes, want) {
+			t.Errorf("gatewayRoutes() is missing %s %s", want.method, want.path)
+		}
+	}
+
+	// served by hand-written handlers instead
+	for _, route := range routes {
+		if strings.HasSuffix(route.path, "/createClawPlayer") || strings.HasSuffix(route.path, "/createVouchers") {
+			t.Errorf("gatewayRoutes() includes %s %s, which has no google.api.http option", route.method, route.path)
+		}
+	}
+}
+
+func TestGateway(t *testing.T) {
+	client := &fakeClawMachineClient{}
+	gateway, err := newGateway(zap.NewNop().Sugar(), client)
+	if err != nil {
+		t.Fatalf("newGateway() error = %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	registerRoutes(engine, zap.NewNop().Sugar(), nil, nil, gin.WrapH(gateway))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+		wantReq    string // the request the service got, as JSON
+	}{
+		{
+			name:       "path variable",
+			method:     http.MethodGet,
+			path:       "/api/v1/clawMachine/getClawMachineInfo/3",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"data":{"machine":[{"machineID":3,"name":"lucky","price":10}]}}`,
+			wantReq:    `{"machineID":3}`,
+		},
+		{
+			name:       "body with unknown fields",
+			method:     http.MethodPost,
+			path:       "/api/v1/clawMachine/adjustPlayerCoin",
+			body:       `{"playerID":7,"amount":5,"type":"plus","reason":"refund"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"data":{"playerID":7,"adjustedAmount":5}}`,
+			wantReq:    `{"playerID":7,"amount":5,"type":"plus"}`,
+		},
+		{
+			name:       "create",
+			method:     http.MethodPost,
+			path:       "/api/v1/clawMachine/createClawMachine",
+			body:       `{"name":"lucky","price":10,"maxItem":3}`,
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"success":true,"message":"Created successfully","data":{"machine":{"machineID":1,"name":"lucky"}}}`,
+			wantReq:    `{"name":"lucky","price":10,"maxItem":3}`,
+		},
+		{
+			name:       "catalogue error",
+			method:     http.MethodGet,
+			path:       "/api/v1/clawMachine/getClawMachineInfo/404",
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"success":false,"error":"claw machine 404 not found","code":"NOT_FOUND"}`,
+			wantReq:    `{"machineID":404}`,
+		},
+		{
+			name:       "deprecated",
+			method:     http.MethodPost,
+			path:       "/api/v1/clawMachine/startClawGame",
+			body:       `{"playerID":7,"machineID":3}`,
+			wantStatus: http.StatusGone,
+			wantBody:   `{"success":false,"error":"games are played over the runtime protocol","code":"DEPRECATED"}`,
+			wantReq:    `{"playerID":7,"machineID":3}`,
+		},
+		{
+			name:       "internal error hides details",
+			method:     http.MethodGet,
+			path:       "/api/v1/clawMachine/getClawMachineInfo/500",
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"success":false,"error":"internal error","code":"INTERNAL"}`,
+			wantReq:    `{"machineID":500}`,
+		},
+		{
+			name:       "malformed path variable",
+			method:     http.MethodGet,
+			path:       "/api/v1/clawMachine/getClawMachineInfo/abc",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.requests = nil
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if got := w.Header().Get("Content-Type"); got != gatewayContentType {
+				t.Errorf("Content-Type = %q, want %q", got, gatewayContentType)
+			}
+
+			if tt.wantBody != "" {
+				assertJSON(t, "body", w.Body.String(), tt.wantBody)
+			} else {
+				var resp map[string]any
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp["code"] != string(errcode.InvalidArgument) {
+					t.Errorf("body = %s, want an INVALID_ARGUMENT error", w.Body)
+				}
+			}
+
+			if tt.wantReq == "" {
+				if len(client.requests) != 0 {
+					t.Errorf("service called with %v, want no call", client.requests)
+				}
+				return
+			}
+			if len(client.requests) != 1 {
+				t.Fatalf("service called %d times, want once", len(client.requests))
+			}
+			got, _ := json.Marshal(client.requests[0])
+			assertJSON(t, "request", string(got), tt.wantReq)
+		})
+	}
+}
+
+func assertJSON(t *testing.T, what, got, want string) {
+	t.Helper()
+
+	var gotValue, wantValue any
+	if err := json.Unmarshal([]byte(got), &gotValue); err != nil {
+		t.Fatalf("%s %s is not JSON: %v", what, got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("want %s is not JSON: %v", want, err)
+	}
+	gotJSON, _ := json.Marshal(gotValue)
+	wantJSON, _ := json.Marshal(wantValue)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("%s = %s, want %s", what, gotJSON, wantJSON)
+	}
+}
@@ -1,8 +1,6 @@
 package handler
 
 import (
-	"fmt"
-
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
@@ -33,121 +31,6 @@ func NewClawMachineHandler(
 	}, nil
 }
 
-func (h *ClawMachineHandler) HandleCreateClawMachine(c *gin.Context) {
-	var req dto.CreateClawMachineRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Errorw("Invalid request body", "error", err)
-		common.SendError(c, 400, "Invalid request body")
-		return
-	}
-
-	// Convert DTO to gRPC request
-	grpcReq := &clawMachine.CreateClawMachineReq{
-		Name:    req.Name,
-		Price:   req.Price,
-		MaxItem: req.MaxItem,
-	}
-
-	for _, item := range req.Items {
-		grpcReq.Items = append(grpcReq.Items, &clawMachine.Items{
-			ItemID: item.ItemID,
-		})
-	}
-
-	resp, err := h.clawMachineClient.CreateClawMachine(c, grpcReq)
-	if err != nil {
-		h.logger.Errorw("Failed to create claw machine", "error", err)
-		common.SendError(c, 500, err.Error())
-		return
-	}
-
-	h.logger.Infow("Successfully created claw machine", "machine_name", req.Name)
-	common.SendCreated(c, resp)
-}
-
-func (h *ClawMachineHandler) HandleGetClawMachineInfo(c *gin.Context) {
-	machineIDParam := c.Param("machineID")
-	var machineID int64
-	_, err := fmt.Sscan(machineIDParam, &machineID)
-	if err != nil {
-		h.logger.Errorw("Invalid machine ID", "error", err)
-		common.SendError(c, 400, "Invalid machine ID")
-		return
-	}
-
-	grpcReq := &clawMachine.GetClawMachineInfoReq{
-		MachineID: machineID,
-	}
-
-	resp, err := h.clawMachineClient.GetClawMachineInfo(c, grpcReq)
-	if err != nil {
-		h.logger.Errorw("Failed to get claw machine info", "error", err)
-		common.SendError(c, 500, err.Error())
-		return
-	}
-
-	h.logger.Infow("Successfully retrieved claw machine info", "machine_id", machineID)
-	common.SendSuccess(c, resp)
-}
-
-func (h *ClawMachineHandler) HandleCreateClawItems(c *gin.Context) {
-	var req dto.CreateClawItemsRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Errorw("Invalid request body", "error", err)
-		common.SendError(c, 400, "Invalid request body")
-		return
-	}
-
-	fmt.Printf("ClawItems: %+v\n", req.ClawItems)
-
-	// Convert DTO to gRPC request
-	grpcReq := &clawMachine.CreateClawItemsReq{}
-	for _, item := range req.ClawItems {
-		grpcReq.ClawItems = append(grpcReq.ClawItems, &clawMachine.CreateItemReq{
-			Name:            item.Name,
-			Rarity:          item.Rarity,
-			SpawnPercentage: item.SpawnPercentage,
-			CatchPercentage: item.CatchPercentage,
-			MaxItemSpawned:  item.MaxItemSpawned,
-		})
-	}
-
-	resp, err := h.clawMachineClient.CreateClawItems(c, grpcReq)
-	if err != nil {
-		h.logger.Errorw("Failed to create claw items", "error", err)
-		common.SendError(c, 500, err.Error())
-		return
-	}
-
-	h.logger.Infow("Successfully created claw items", "item_count", len(req.ClawItems))
-	common.SendCreated(c, resp)
-}
-
-func (h *ClawMachineHandler) HandleGetClawPlayerInfo(c *gin.Context) {
-	playerIDParam := c.Param("playerID")
-	var playerID int64
-	_, err := fmt.Sscan(playerIDParam, &playerID)
-	if err != nil {
-		h.logger.Errorw("Invalid player ID", "error", err)
-		common.SendError(c, 400, "Invalid player ID")
-		return
-	}
-
-	grpcReq := &clawMachine.GetClawPlayerInfoReq{
-		PlayerID: playerID,
-	}
-
-	resp, err := h.clawMachineClient.GetClawPlayerInfo(c, grpcReq)
-	if err != nil {
-		h.logger.Errorw("Failed to get claw player info", "error", err)
-		common.SendError(c, 500, err.Error())
-		return
-	}
-
-	h.logger.Infow("Successfully retrieved claw player info", "player_id", playerID)
-	common.SendSuccess(c, resp)
-}
-
 func (h *ClawMachineHandler) HandleCreateClawPlayer(c *gin.Context) {
 	var req dto.CreateClawPlayerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -171,7 +54,7 @@ func (h *ClawMachineHandler) HandleCreateClawPlayer(c *gin.Context) {
 	resp, err := h.clawMachineClient.CreateClawPlayer(c, grpcReq)
 	if err != nil {
 		h.logger.Errorw("Failed to create claw player", "error", err)
-		common.SendError(c, 500, err.Error())
+		common.SendErrorFrom(c, err)
 		return
 	}
 
@@ -179,100 +62,63 @@ func (h *ClawMachineHandler) HandleCreateClawPlayer(c *gin.Context) {
 	common.SendCreated(c, resp)
 }
 
-func (h *ClawMachineHandler) HandleAdjustPlayerCoin(c *gin.Context) {
-	var req dto.AdjustPlayerCoinRequest
+func (h *ClawMachineHandler) HandleCreatePromotion(c *gin.Context) {
+	var req dto.CreatePromotionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Errorw("Invalid request body", "error", err)
 		common.SendError(c, 400, "Invalid request body")
 		return
 	}
 
-	grpcReq := &clawMachine.AdjustPlayerCoinReq{
-		PlayerID: req.PlayerID,
-		Amount:   req.Amount,
-		Type:     req.Type,
-	}
-
-	resp, err := h.clawMachineClient.AdjustPlayerCoin(c, grpcReq)
-	if err != nil {
-		h.logger.Errorw("Failed to adjust player coin", "error", err)
-		common.SendError(c, 500, err.Error())
-		return
-	}
-
-	h.logger.Infow("Successfully adjusted player coin", "player_id", req.PlayerID, "amount", req.Amount, "type", req.Type)
-	common.SendSuccess(c, resp)
-}
-
-func (h *ClawMachineHandler) HandleAdjustPlayerDiamond(c *gin.Context) {
-	var req dto.AdjustPlayerDiamondRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Errorw("Invalid request body", "error", err)
-		common.SendError(c, 400, "Invalid request body")
-		return
-	}
-
-	grpcReq := &clawMachine.AdjustPlayerDiamondReq{
-		PlayerID: req.PlayerID,
-		Amount:   req.Amount,
-		Type:     req.Type,
-	}
-
-	resp, err := h.clawMachineClient.AdjustPlayerDiamond(c, grpcReq)
-	if err != nil {
-		h.logger.Errorw("Failed to adjust player diamond", "error", err)
-		common.SendError(c, 500, err.Error())
-		return
-	}
-
-	h.logger.Infow("Successfully adjusted player diamond", "player_id", req.PlayerID, "amount", req.Amount, "type", req.Type)
-	common.SendSuccess(c, resp)
-}
-
-func (h *ClawMachineHandler) HandleStartClawGame(c *gin.Context) {
-	var req dto.StartClawGameRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Errorw("Invalid request body", "error", err)
-		common.SendError(c, 400, "Invalid request body")
-		return
+	grpcReq := &clawMachine.CreatePromotionReq{
+		Promotion: &clawMachine.Promotion{
+			Name:          req.Name,
+			MachineIDs:    req.MachineIDs,
+			DiscountType:  req.DiscountType,
+			DiscountValue: req.DiscountValue,
+			StartsAt:      req.StartsAt.Unix(),
+			EndsAt:        req.EndsAt.Unix(),
+		},
 	}
 
-	grpcReq := &clawMachine.StartClawGameReq{
-		PlayerID:  req.PlayerID,
-		MachineID: req.MachineID,
-	}
-	resp, err := h.clawMachineClient.StartClawGame(c, grpcReq)
+	resp, err := h.clawMachineClient.CreatePromotion(c, grpcReq)
 	if err != nil {
-		h.logger.Errorw("Failed to start claw game", "error", err)
-		common.SendError(c, 500, err.Error())
+		h.logger.Errorw("Failed to create promotion", "error", err)
+		common.SendErrorFrom(c, err)
 		return
 	}
 
-	h.logger.Infow("Successfully started claw game", "player_id", req.PlayerID, "machine_id", req.MachineID)
-	common.SendSuccess(c, resp)
+	h.logger.Infow("Successfully created promotion", "name", req.Name, "discount_type", req.DiscountType)
+	common.SendCreated(c, resp)
 }
 
-func (h *ClawMachineHandler) HandleAddTouchedItemRecord(c *gin.Context) {
-	var req dto.AddTouchedItemRecordRequest
+func (h *ClawMachineHandler) HandleCreateVouchers(c *gin.Context) {
+	var req dto.CreateVouchersRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Errorw("Invalid request body", "error", err)
 		common.SendError(c, 400, "Invalid request body")
 		return
 	}
 
-	grpcReq := &clawMachine.AddTouchedItemRecordReq{
-		GameID:  req.GameID,
-		ItemID:  req.ItemID,
-		Catched: req.Catched,
+	grpcReq := &clawMachine.CreateVouchersReq{
+		Count:          req.Count,
+		CodePrefix:     req.CodePrefix,
+		Code:           req.Code,
+		RewardType:     req.RewardType,
+		RewardAmount:   req.RewardAmount,
+		RewardItemID:   req.RewardItemID,
+		MaxRedemptions: req.MaxRedemptions,
+		PerPlayerLimit: req.PerPlayerLimit,
+		ExpiresAt:      req.ExpiresAt.Unix(),
 	}
 
-	resp, err := h.clawMachineClient.AddTouchedItemRecord(c, grpcReq)
+	resp, err := h.clawMachineClient.CreateVouchers(c, grpcReq)
 	if err != nil {
-		h.logger.Errorw("Failed to add touched item record", "error", err)
-		common.SendError(c, 500, err.Error())
+		h.logger.Errorw("Failed to create vouchers", "error", err)
+		common.SendErrorFrom(c, err)
 		return
 	}
 
-	h.logger.Infow("Successfully added touched item record", "game_id", req.GameID, "item_id", req.ItemID, "catched", req.Catched)
-	common.SendSuccess(c, resp)
+	h.logger.Infow("Successfully created vouchers", "count", len(resp.Vouchers), "reward_type", req.RewardType)
+	common.SendCreated(c, resp)
 }
@@ -49,7 +49,7 @@ func (h *PlayerHandler) HandleCreatePlayer(c *gin.Context) {
 	resp, err := h.playerClient.CreatePlayer(c, grpcReq)
 	if err != nil {
 		h.logger.Errorw("Failed to create player", "error", err)
-		common.SendError(c, http.StatusInternalServerError, err.Error())
+		common.SendErrorFrom(c, err)
 		return
 	}
 
@@ -71,7 +71,7 @@ func (h *PlayerHandler) HandleGetPlayerInfo(c *gin.Context) {
 	})
 	if err != nil {
 		h.logger.Errorw("Failed to get player info", "error", err)
-		common.SendError(c, http.StatusInternalServerError, err.Error())
+		common.SendErrorFrom(c, err)
 		return
 	}
 
@@ -0,0 +1,313 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+)
+
+// protoMessageExtension names the protobuf message a schema documents, such
+// as clawMachine.ClawMachine
+const protoMessageExtension = "x-proto-message"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Check compares the document with what the server actually serves and
+// returns every difference:
+//   - each gin route under BasePath must be documented and each documented
+//     operation must have a route
+//   - each request body schema must match the DTO of the same name in
+//     requests, field by field, including which fields are required, unless
+//     it documents a protobuf message for the gateway
+//   - each schema marked with x-proto-message must match the fields of that
+//     protobuf message, which must be registered
+//   - the ErrorCode enum must list the errcode catalogue
+func Check(doc *openapi3.T, routes gin.RoutesInfo, requests []any) []string {
+	c := &checker{doc: doc}
+	c.routes(routes)
+	c.requestBodies(requests)
+	c.protoMessages()
+	c.errorCodes()
+
+	slices.Sort(c.problems)
+	return c.problems
+}
+
+type checker struct {
+	doc      *openapi3.T
+	problems []string
+}
+
+func (c *checker) problemf(format string, args ...any) {
+	c.problems = append(c.problems, fmt.Sprintf(format, args...))
+}
+
+func (c *checker) routes(routes gin.RoutesInfo) {
+	served := make(map[string]bool)
+	for _, route := range routes {
+		path, ok := PathOf(route.Path)
+		if !ok {
+			continue
+		}
+		served[route.Method+" "+path] = true
+
+		if _, _, operation := Operation(c.doc, route.Method, route.Path); operation == nil {
+			c.problemf("route %s %s is not documented", route.Method, route.Path)
+		}
+	}
+
+	for path, item := range c.doc.Paths.Map() {
+		for method := range item.Operations() {
+			if !served[method+" "+path] {
+				c.problemf("documented operation %s %s has no route", method, path)
+			}
+		}
+	}
+}
+
+func (c *checker) requestBodies(requests []any) {
+	types := make(map[string]reflect.Type, len(requests))
+	for _, request := range requests {
+		t := reflect.TypeOf(request)
+		types[t.Name()] = t
+	}
+
+	used := make(map[string]bool)
+	for path, item := range c.doc.Paths.Map() {
+		for method, operation := range item.Operations() {
+			if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+				continue
+			}
+			media := operation.RequestBody.Value.Content.Get("application/json")
+			if media == nil || media.Schema == nil {
+				c.problemf("%s %s: request body is not application/json", method, path)
+				continue
+			}
+
+			name := schemaName(media.Schema)
+			if protoMessage(media.Schema.Value) != "" {
+				continue
+			}
+			t, ok := types[name]
+			if !ok {
+				c.problemf("%s %s: request body schema %q has no DTO or x-proto-message", method, path, name)
+				continue
+			}
+			used[name] = true
+			c.compareStruct(name, media.Schema.Value, t)
+		}
+	}
+
+	for name := range types {
+		if !used[name] {
+			c.problemf("DTO %s is not the request body of any operation", name)
+		}
+	}
+}
+
+// compareStruct compares the JSON fields of a DTO with the properties of
+// its schema, recursing into nested DTOs
+func (c *checker) compareStruct(name string, schema *openapi3.Schema, t reflect.Type) {
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || jsonName == "-" {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		fields[jsonName] = true
+
+		property := schema.Properties[jsonName]
+		if property == nil || property.Value == nil {
+			c.problemf("%s.%s is not documented", name, jsonName)
+			continue
+		}
+
+		required := slices.Contains(strings.Split(field.Tag.Get("binding"), ","), "required")
+		if documented := slices.Contains(schema.Required, jsonName); required != documented {
+			c.problemf("%s.%s: DTO required=%t, document required=%t", name, jsonName, required, documented)
+		}
+
+		c.compareType(name+"."+jsonName, property, field.Type)
+	}
+
+	for jsonName := range schema.Properties {
+		if !fields[jsonName] {
+			c.problemf("%s.%s is documented but not in the DTO", name, jsonName)
+		}
+	}
+}
+
+func (c *checker) compareType(name string, ref *openapi3.SchemaRef, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	schema := ref.Value
+
+	want := jsonType(t)
+	if !schema.Type.Is(want) {
+		c.problemf("%s: DTO is %s, document is %v", name, want, schema.Type.Slice())
+		return
+	}
+
+	switch {
+	case t.Kind() == reflect.Slice && schema.Items != nil:
+		c.compareType(name+"[]", schema.Items, t.Elem())
+	case t.Kind() == reflect.Struct && t != timeType:
+		c.compareStruct(t.Name(), schema, t)
+	}
+}
+
+// jsonType is the JSON schema type encoding/json produces for t
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return openapi3.TypeBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return openapi3.TypeInteger
+	case reflect.Float32, reflect.Float64:
+		return openapi3.TypeNumber
+	case reflect.String:
+		return openapi3.TypeString
+	case reflect.Slice, reflect.Array:
+		return openapi3.TypeArray
+	case reflect.Struct:
+		if t == timeType {
+			return openapi3.TypeString
+		}
+		return openapi3.TypeObject
+	default:
+		return openapi3.TypeObject
+	}
+}
+
+func (c *checker) protoMessages() {
+	for name, ref := range c.doc.Components.Schemas {
+		message := protoMessage(ref.Value)
+		if message == "" {
+			continue
+		}
+
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(message))
+		if err != nil {
+			c.problemf("schema %s: protobuf message %s is not registered", name, message)
+			continue
+		}
+		md, ok := desc.(protoreflect.MessageDescriptor)
+		if !ok {
+			c.problemf("schema %s: %s is not a protobuf message", name, message)
+			continue
+		}
+		c.compareMessage(name, ref.Value, md)
+	}
+}
+
+// compareMessage compares the fields of a protobuf message with the
+// properties of its schema. Handlers encode messages with encoding/json, so
+// properties are named after the proto fields.
+func (c *checker) compareMessage(name string, schema *openapi3.Schema, md protoreflect.MessageDescriptor) {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fieldName := string(fd.Name())
+
+		property := schema.Properties[fieldName]
+		if property == nil || property.Value == nil {
+			c.problemf("%s.%s is not documented", name, fieldName)
+			continue
+		}
+
+		value := property.Value
+		if fd.IsList() {
+			if !value.Type.Is(openapi3.TypeArray) || value.Items == nil {
+				c.problemf("%s.%s: message field is repeated, document is %v", name, fieldName, value.Type.Slice())
+				continue
+			}
+			property, value = value.Items, value.Items.Value
+		}
+
+		want := protoType(fd)
+		if !value.Type.Is(want) {
+			c.problemf("%s.%s: message field is %s, document is %v", name, fieldName, want, value.Type.Slice())
+			continue
+		}
+		if fd.Kind() == protoreflect.MessageKind {
+			if got := protoMessage(value); got != string(fd.Message().FullName()) {
+				c.problemf("%s.%s: message field is %s, document schema %s is %q", name, fieldName, fd.Message().FullName(), schemaName(property), got)
+			}
+		}
+	}
+
+	for fieldName := range schema.Properties {
+		if fields.ByName(protoreflect.Name(fieldName)) == nil {
+			c.problemf("%s.%s is documented but not in %s", name, fieldName, md.FullName())
+		}
+	}
+}
+
+// protoType is the JSON schema type encoding/json produces for a field of
+// a generated protobuf struct
+func protoType(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return openapi3.TypeBoolean
+	case protoreflect.StringKind:
+		return openapi3.TypeString
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return openapi3.TypeNumber
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return openapi3.TypeObject
+	case protoreflect.BytesKind:
+		return openapi3.TypeString
+	default:
+		return openapi3.TypeInteger
+	}
+}
+
+func (c *checker) errorCodes() {
+	ref := c.doc.Components.Schemas["ErrorCode"]
+	if ref == nil || ref.Value == nil {
+		c.problemf("schema ErrorCode is missing")
+		return
+	}
+
+	documented := make(map[string]bool, len(ref.Value.Enum))
+	for _, value := range ref.Value.Enum {
+		documented[fmt.Sprint(value)] = true
+	}
+	for _, code := range errcode.Codes() {
+		if !documented[string(code)] {
+			c.problemf("ErrorCode does not list %s", code)
+		}
+		delete(documented, string(code))
+	}
+	for value := range documented {
+		c.problemf("ErrorCode lists %s, which is not in the catalogue", value)
+	}
+}
+
+// schemaName is the component name a schema reference points to
+func schemaName(ref *openapi3.SchemaRef) string {
+	return strings.TrimPrefix(ref.Ref, "#/components/schemas/")
+}
+
+func protoMessage(schema *openapi3.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	message, _ := schema.Extensions[protoMessageExtension].(string)
+	return message
+}
@@ -0,0 +1,158 @@
+// Package openapi embeds the OpenAPI document of the api-service, validates
+// requests against it and serves it with an interactive viewer.
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+
+	"github.com/Richard-inter/game/pkg/common"
+)
+
+// BasePath is the server URL of the document, which its paths are relative to
+const BasePath = "/api/v1"
+
+//go:embed openapi.yaml
+var spec []byte
+
+//go:embed index.html
+var viewer []byte
+
+// Spec returns the OpenAPI document as YAML
+func Spec() []byte {
+	return spec
+}
+
+// Load parses and validates the OpenAPI document
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	return doc, nil
+}
+
+// Operation returns the operation documented for a gin route, such as
+// GET /api/v1/player/info/:id, or nil when the document has none
+func Operation(doc *openapi3.T, method, route string) (string, *openapi3.PathItem, *openapi3.Operation) {
+	path, ok := PathOf(route)
+	if !ok {
+		return "", nil, nil
+	}
+	item := doc.Paths.Value(path)
+	if item == nil {
+		return path, nil, nil
+	}
+	return path, item, item.GetOperation(method)
+}
+
+// PathOf converts a gin route under BasePath to its OpenAPI path, turning
+// :name and *name segments into {name}
+func PathOf(route string) (string, bool) {
+	if !strings.HasPrefix(route, BasePath+"/") {
+		return "", false
+	}
+
+	segments := strings.Split(strings.TrimPrefix(route, BasePath), "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), true
+}
+
+// Validator rejects requests whose parameters or body do not match the
+// document with a 400 INVALID_ARGUMENT. Routes the document does not cover
+// are passed through.
+func Validator(doc *openapi3.T) gin.HandlerFunc {
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(c *gin.Context) {
+		path, item, operation := Operation(doc, c.Request.Method, c.FullPath())
+		if operation == nil {
+			c.Next()
+			return
+		}
+
+		params := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			params[param.Key] = param.Value
+		}
+
+		err := openapi3filter.ValidateRequest(c.Request.Context(), &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: params,
+			Route: &routers.Route{
+				Spec:      doc,
+				Path:      path,
+				PathItem:  item,
+				Method:    c.Request.Method,
+				Operation: operation,
+			},
+			Options: options,
+		})
+		if err != nil {
+			common.SendError(c, http.StatusBadRequest, describe(err))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// describe turns a validation error into a message for the client, without
+// the schema dump kin-openapi appends
+func describe(err error) string {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return "Invalid request"
+	}
+
+	reason := reqErr.Reason
+	var schemaErr *openapi3.SchemaError
+	if errors.As(reqErr.Err, &schemaErr) {
+		reason = schemaErr.Reason
+		if field := strings.Join(schemaErr.JSONPointer(), "."); field != "" {
+			reason = field + ": " + reason
+		}
+	} else if reason == "" && reqErr.Err != nil {
+		reason = reqErr.Err.Error()
+	}
+
+	if reqErr.Parameter != nil {
+		return fmt.Sprintf("Invalid parameter %s: %s", reqErr.Parameter.Name, reason)
+	}
+	return fmt.Sprintf("Invalid request body: %s", reason)
+}
+
+// Docs serves the viewer at / and the document at /openapi.yaml under the
+// wildcard parameter file; every other file comes from the Swagger UI
+// distribution compiled into the binary
+func Docs(c *gin.Context) {
+	switch file := c.Param("file"); file {
+	case "", "/", "/index.html":
+		c.Data(http.StatusOK, "text/html; charset=utf-8", viewer)
+	case "/openapi.yaml":
+		c.Data(http.StatusOK, "application/yaml", spec)
+	default:
+		c.Request.URL.Path = file
+		http.FileServer(swaggerFiles.HTTP).ServeHTTP(c.Writer, c.Request)
+	}
+}
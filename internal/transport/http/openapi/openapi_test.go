@@ -0,0 +1,186 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Richard-inter/game/pkg/common"
+	"github.com/Richard-inter/game/pkg/errcode"
+)
+
+func TestPathOf(t *testing.T) {
+	tests := []struct {
+		route  string
+		want   string
+		wantOK bool
+	}{
+		{"/api/v1/player/create", "/player/create", true},
+		{"/api/v1/player/info/:id", "/player/info/{id}", true},
+		{"/api/v1/clawMachine/getClawMachineInfo/:machineID", "/clawMachine/getClawMachineInfo/{machineID}", true},
+		{"/api/v1/docs/*file", "/docs/{file}", true},
+		{"/api/v2/player/create", "", false},
+		{"/api/v1", "", false},
+		{"/health", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := PathOf(tt.route)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("PathOf(%q) = %q, %v, want %q, %v", tt.route, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestValidator(t *testing.T) {
+	doc, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Validator(doc))
+	// echoes the body, which must survive validation
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	}
+	router.POST(BasePath+"/clawMachine/adjustPlayerCoin", echo)
+	router.GET(BasePath+"/clawMachine/getClawMachineInfo/:machineID", echo)
+	router.GET(BasePath+"/undocumented/:id", echo)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		wantStatus  int
+		wantMessage string // part of the error message
+	}{
+		{
+			name:       "valid body",
+			method:     http.MethodPost,
+			path:       "/clawMachine/adjustPlayerCoin",
+			body:       `{"playerID":1,"amount":10,"type":"plus"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "missing field",
+			method:      http.MethodPost,
+			path:        "/clawMachine/adjustPlayerCoin",
+			body:        `{"playerID":1,"type":"plus"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "Invalid request body",
+		},
+		{
+			name:        "value out of range",
+			method:      http.MethodPost,
+			path:        "/clawMachine/adjustPlayerCoin",
+			body:        `{"playerID":1,"amount":0,"type":"plus"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "amount",
+		},
+		{
+			name:        "value not in enum",
+			method:      http.MethodPost,
+			path:        "/clawMachine/adjustPlayerCoin",
+			body:        `{"playerID":1,"amount":10,"type":"times"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "type",
+		},
+		{
+			name:        "no body",
+			method:      http.MethodPost,
+			path:        "/clawMachine/adjustPlayerCoin",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "Invalid request body",
+		},
+		{
+			name:       "valid parameter",
+			method:     http.MethodGet,
+			path:       "/clawMachine/getClawMachineInfo/0",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "parameter not an integer",
+			method:      http.MethodGet,
+			path:        "/clawMachine/getClawMachineInfo/abc",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "Invalid parameter machineID",
+		},
+		{
+			name:        "parameter out of range",
+			method:      http.MethodGet,
+			path:        "/clawMachine/getClawMachineInfo/-1",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "Invalid parameter machineID",
+		},
+		{
+			name:       "undocumented route",
+			method:     http.MethodGet,
+			path:       "/undocumented/abc",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, BasePath+tt.path, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus == http.StatusOK {
+				if w.Body.String() != tt.body {
+					t.Errorf("handler read body %q, want %q", w.Body, tt.body)
+				}
+				return
+			}
+
+			var resp common.Response
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Code != string(errcode.InvalidArgument) {
+				t.Errorf("code = %s, want %s", resp.Code, errcode.InvalidArgument)
+			}
+			if !strings.Contains(resp.Error, tt.wantMessage) {
+				t.Errorf("error = %q, want it to mention %q", resp.Error, tt.wantMessage)
+			}
+			if strings.Contains(resp.Error, "Schema:") {
+				t.Errorf("error = %q leaks the schema", resp.Error)
+			}
+		})
+	}
+}
+
+func TestDocs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/docs/*file", Docs)
+
+	tests := []struct {
+		path            string
+		wantContentType string
+	}{
+		{"/docs/", "text/html; charset=utf-8"},
+		{"/docs/openapi.yaml", "application/yaml"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != tt.wantContentType {
+			t.Errorf("GET %s = %d %s, want 200 %s", tt.path, w.Code, w.Header().Get("Content-Type"), tt.wantContentType)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +13,7 @@ import (
 	"github.com/Richard-inter/game/internal/config"
 	"github.com/Richard-inter/game/internal/transport/grpc"
 	"github.com/Richard-inter/game/internal/transport/http/handler"
+	"github.com/Richard-inter/game/internal/transport/http/openapi"
 )
 
 const (
@@ -41,6 +43,15 @@ func (s *Server) Start() error {
 	// Add middleware
 	s.setupMiddleware()
 
+	// Validate requests against the OpenAPI document
+	if s.config.OpenAPI.ValidateRequests {
+		doc, err := openapi.Load()
+		if err != nil {
+			return err
+		}
+		s.engine.Use(openapi.Validator(doc))
+	}
+
 	// Setup routes
 	s.setupRoutes()
 
@@ -116,11 +127,42 @@ func (s *Server) setupRoutes() {
 		s.logger.Fatalw("Failed to create claw machine handler", "error", err)
 	}
 
+	clawMachineClient, err := s.grpcClient.GetClawMachineClient()
+	if err != nil {
+		s.logger.Fatalw("Failed to get claw machine client", "error", err)
+	}
+	gateway, err := newGateway(s.logger, clawMachineClient.Service())
+	if err != nil {
+		s.logger.Fatalw("Failed to create claw machine gateway", "error", err)
+	}
+
+	registerRoutes(s.engine, s.logger, playerHandler, clawMachineHandler, gin.WrapH(gateway))
+
+	if docsPath := s.config.OpenAPI.DocsPath; docsPath != "" {
+		s.engine.GET(strings.TrimSuffix(docsPath, "/")+"/*file", openapi.Docs)
+	}
+}
+
+// Routes returns the routes the server serves, without connecting to the
+// services behind them, for checking them against the OpenAPI document
+func Routes() gin.RoutesInfo {
+	engine := gin.New()
+	registerRoutes(engine, zap.NewNop().Sugar(), nil, nil, nil)
+	return engine.Routes()
+}
+
+func registerRoutes(
+	engine *gin.Engine,
+	logger *zap.SugaredLogger,
+	playerHandler *handler.PlayerHandler,
+	clawMachineHandler *handler.ClawMachineHandler,
+	gateway gin.HandlerFunc,
+) {
 	// Health check
-	s.engine.GET("/health", handler.HealthCheck(s.logger.Desugar()))
+	engine.GET("/health", handler.HealthCheck(logger.Desugar()))
 
 	// API version 1
-	v1 := s.engine.Group("/api/v1")
+	v1 := engine.Group("/api/v1")
 	{
 		player := v1.Group("/player")
 		{
@@ -128,24 +170,20 @@ func (s *Server) setupRoutes() {
 			player.GET("/info/:id", playerHandler.HandleGetPlayerInfo)
 		}
 
+		// ClawMachineService methods without a google.api.http option
 		clawMachine := v1.Group("/clawMachine")
 		{
-			// items
-			clawMachine.POST("/createClawItems", clawMachineHandler.HandleCreateClawItems)
-
-			// machine
-			clawMachine.POST("/createClawMachine", clawMachineHandler.HandleCreateClawMachine)
-			clawMachine.GET("/getClawMachineInfo/:machineID", clawMachineHandler.HandleGetClawMachineInfo)
-
 			// player
-			clawMachine.GET("/getClawPlayerInfo/:playerID", clawMachineHandler.HandleGetClawPlayerInfo)
 			clawMachine.POST("/createClawPlayer", clawMachineHandler.HandleCreateClawPlayer)
-			clawMachine.POST("/adjustPlayerCoin", clawMachineHandler.HandleAdjustPlayerCoin)
-			clawMachine.POST("/adjustPlayerDiamond", clawMachineHandler.HandleAdjustPlayerDiamond)
 
-			// game
-			clawMachine.POST("/startClawGame", clawMachineHandler.HandleStartClawGame)
-			clawMachine.POST("/addTouchedItemRecord", clawMachineHandler.HandleAddTouchedItemRecord)
+			// promotions
+			clawMachine.POST("/createPromotion", clawMachineHandler.HandleCreatePromotion)
+			clawMachine.POST("/createVouchers", clawMachineHandler.HandleCreateVouchers)
 		}
 	}
+
+	// Every other ClawMachineService method, on the route its option declares
+	for _, route := range gatewayRoutes() {
+		engine.Handle(route.method, route.path, gateway)
+	}
 }
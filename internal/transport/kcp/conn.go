@@ -0,0 +1,91 @@
+package kcp
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	kcpgo "github.com/xtaci/kcp-go/v5"
+)
+
+// listener hands out tuned KCP sessions and reports errors the way the net
+// package does, so the stream gateway treats them like TCP connections
+type listener struct {
+	*kcpgo.Listener
+	tune func(session *kcpgo.UDPSession)
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	session, err := l.AcceptKCP()
+	if err != nil {
+		return nil, netError(err)
+	}
+
+	l.tune(session)
+	c := &conn{UDPSession: session}
+	_ = c.SetReadDeadline(time.Time{})
+	return c, nil
+}
+
+func (l *listener) Close() error {
+	return netError(l.Listener.Close())
+}
+
+// noDeadline stands in for "no read deadline". A kcp-go Read that starts
+// without a deadline ignores deadlines set while it waits, so Shutdown could
+// not stop an idle session's read loop.
+var noDeadline = time.Unix(1<<62, 0)
+
+// conn is a KCP session. kcp-go reports expired deadlines and closed
+// sessions with errors of its own, which conn translates.
+type conn struct {
+	*kcpgo.UDPSession
+	readDeadline atomic.Int64
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	n, err := c.UDPSession.Read(b)
+	if err != nil {
+		if deadline := c.readDeadline.Load(); deadline != 0 && !time.Now().Before(time.Unix(0, deadline)) {
+			return n, os.ErrDeadlineExceeded
+		}
+		return n, netError(err)
+	}
+	return n, nil
+}
+
+func (c *conn) Write(b []byte) (int, error) {
+	n, err := c.UDPSession.Write(b)
+	return n, netError(err)
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	if t.IsZero() {
+		c.readDeadline.Store(0)
+		t = noDeadline
+	} else {
+		c.readDeadline.Store(t.UnixNano())
+	}
+	return c.UDPSession.SetReadDeadline(t)
+}
+
+func (c *conn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.UDPSession.SetWriteDeadline(t)
+}
+
+func (c *conn) Close() error {
+	return netError(c.UDPSession.Close())
+}
+
+func netError(err error) error {
+	if errors.Is(err, io.ErrClosedPipe) {
+		return net.ErrClosed
+	}
+	return err
+}
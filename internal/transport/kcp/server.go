@@ -0,0 +1,145 @@
+package kcp
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	kcpgo "github.com/xtaci/kcp-go/v5"
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/internal/gateway"
+	"github.com/Richard-inter/game/internal/transport/grpc"
+	"github.com/Richard-inter/game/internal/transport/tcp"
+)
+
+const (
+	defaultInterval  = 10 // ms
+	defaultResend    = 2
+	defaultWindow    = 256
+	defaultMTU       = 1350
+	socketBufferSize = 4 * 1024 * 1024
+)
+
+// Server speaks the game protocol over KCP, a reliable protocol on top of
+// UDP that retransmits lost packets sooner than TCP, so claw inputs are not
+// held up by a single lost packet on lossy mobile networks. Frames and the
+// handshake are those of the TCP gateway; clients authenticate with the
+// same JWT they use for the WebSocket gateway.
+type Server struct {
+	config *config.ServiceConfig
+	logger *zap.SugaredLogger
+	stream *tcp.Server
+	socket *net.UDPConn
+}
+
+func NewServer(
+	cfg *config.ServiceConfig,
+	logger *zap.SugaredLogger,
+	grpcManager *grpc.ClientManager,
+	authenticator *auth.Authenticator,
+) *Server {
+	return &Server{
+		config: cfg,
+		logger: logger,
+		stream: tcp.NewStreamServer("kcp", cfg.KCP.TCPConfig, logger, grpcManager, authenticator),
+	}
+}
+
+// SetBuild sets the server build reported to clients in WelcomeResp
+func (s *Server) SetBuild(build string) {
+	s.stream.SetBuild(build)
+}
+
+// Sessions returns the registry of authenticated connections
+func (s *Server) Sessions() *gateway.Registry {
+	return s.stream.Sessions()
+}
+
+func (s *Server) Start() error {
+	cfg := s.config.KCP
+
+	addr, err := net.ResolveUDPAddr("udp", s.config.GetKCPAddr())
+	if err != nil {
+		return fmt.Errorf("invalid KCP address: %w", err)
+	}
+
+	// every session shares the socket, which stays open after the listener
+	// is closed until the sessions are drained
+	s.socket, err = net.ListenUDP("udp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on KCP port: %w", err)
+	}
+
+	if err := s.socket.SetReadBuffer(socketBufferSize); err != nil {
+		s.logger.Warnw("Failed to set KCP read buffer", "error", err)
+	}
+	if err := s.socket.SetWriteBuffer(socketBufferSize); err != nil {
+		s.logger.Warnw("Failed to set KCP write buffer", "error", err)
+	}
+
+	l, err := kcpgo.ServeConn(nil, cfg.DataShards, cfg.ParityShards, s.socket)
+	if err != nil {
+		s.socket.Close()
+		return fmt.Errorf("failed to serve KCP: %w", err)
+	}
+
+	return s.stream.Serve(&listener{Listener: l, tune: s.tune})
+}
+
+// tune applies the configured KCP settings to an accepted session
+func (s *Server) tune(session *kcpgo.UDPSession) {
+	cfg := s.config.KCP
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	resend := cfg.Resend
+	if resend <= 0 {
+		resend = defaultResend
+	}
+	sendWindow := cfg.SendWindow
+	if sendWindow <= 0 {
+		sendWindow = defaultWindow
+	}
+	receiveWindow := cfg.ReceiveWindow
+	if receiveWindow <= 0 {
+		receiveWindow = defaultWindow
+	}
+	mtu := cfg.MTU
+	if mtu <= 0 {
+		mtu = defaultMTU
+	}
+
+	// frames carry their own length, so KCP may merge and split them
+	session.SetStreamMode(true)
+	session.SetWriteDelay(false)
+	session.SetNoDelay(boolInt(cfg.NoDelay), interval, resend, boolInt(cfg.NoCongestion))
+	session.SetWindowSize(sendWindow, receiveWindow)
+	session.SetMtu(mtu)
+	session.SetACKNoDelay(cfg.NoDelay)
+}
+
+// Shutdown stops accepting sessions and drains the open ones until ctx is
+// done
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.stream.Shutdown(ctx)
+
+	if s.socket != nil {
+		if closeErr := s.socket.Close(); closeErr != nil {
+			s.logger.Errorw("Failed to close KCP socket", "error", closeErr)
+		}
+	}
+
+	return err
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,173 @@
+package kcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	kcpgo "github.com/xtaci/kcp-go/v5"
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/internal/gateway"
+	"github.com/Richard-inter/game/internal/transport/grpc"
+	"github.com/Richard-inter/game/internal/transport/tcp"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+const testMaxFrameSize = 1024
+
+// startServer serves the KCP gateway on a loopback port, as Start does on
+// the configured one
+func startServer(t *testing.T) (string, *auth.Authenticator) {
+	t.Helper()
+
+	authenticator, err := auth.NewAuthenticator(config.JWTConfig{Secret: "test-secret", ExpirationTime: 60})
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+	grpcManager, err := grpc.NewClientManager(&grpc.ClientManagerConfig{RuntimeAddr: "127.0.0.1:1"})
+	if err != nil {
+		t.Fatalf("failed to create client manager: %v", err)
+	}
+
+	cfg := &config.ServiceConfig{KCP: config.KCPConfig{
+		TCPConfig: config.TCPConfig{
+			MaxFrameSize:     testMaxFrameSize,
+			HandshakeTimeout: 5,
+			SendQueueSize:    16,
+			WriteTimeout:     5,
+		},
+		NoDelay: true,
+	}}
+	server := NewServer(cfg, zap.NewNop().Sugar(), grpcManager, authenticator)
+
+	server.socket, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	l, err := kcpgo.ServeConn(nil, 0, 0, server.socket)
+	if err != nil {
+		t.Fatalf("failed to serve KCP: %v", err)
+	}
+
+	served := make(chan error, 1)
+	go func() { served <- server.stream.Serve(&listener{Listener: l, tune: server.tune}) }()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		// KCP has no FIN, so sessions of clients that went away are still
+		// open; Shutdown must interrupt their reads to drain them in time
+		if err := server.Shutdown(ctx); err != nil {
+			t.Errorf("Shutdown() error = %v", err)
+		}
+		// a closed listener must read as net.ErrClosed for Serve to return
+		// cleanly
+		if err := <-served; err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	})
+
+	return server.socket.LocalAddr().String(), authenticator
+}
+
+func dial(t *testing.T, addr string) *kcpgo.UDPSession {
+	t.Helper()
+
+	session, err := kcpgo.DialWithOptions(addr, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	session.SetStreamMode(true)
+	session.SetNoDelay(1, defaultInterval, defaultResend, 1)
+	_ = session.SetDeadline(time.Now().Add(5 * time.Second))
+	return session
+}
+
+func hello(token string) []byte {
+	builder := flatbuffers.NewBuilder(128)
+	builder.Finish((&fbs.HelloReqT{ProtocolVersion: gateway.ProtocolVersion, ClientBuild: "test", Token: token}).Pack(builder))
+	return gateway.BuildEnvelope(fbs.EnvelopeKindRequest, fbs.MessageTypeHelloReq, 1, builder.FinishedBytes())
+}
+
+func subscribeReq(requestID uint64, room string) []byte {
+	builder := flatbuffers.NewBuilder(64)
+	builder.Finish((&fbs.SubscribeReqT{Room: room}).Pack(builder))
+	return gateway.BuildEnvelope(fbs.EnvelopeKindRequest, fbs.MessageTypeSubscribeReq, requestID, builder.FinishedBytes())
+}
+
+func TestSession(t *testing.T) {
+	addr, authenticator := startServer(t)
+	token, err := authenticator.IssueToken(7)
+	if err != nil {
+		t.Fatalf("failed to issue token: %v", err)
+	}
+
+	session := dial(t, addr)
+	reader := bufio.NewReader(session)
+	read := func() *fbs.Envelope {
+		t.Helper()
+		frame, err := tcp.ReadFrame(reader, testMaxFrameSize)
+		if err != nil {
+			t.Fatalf("failed to read frame: %v", err)
+		}
+		return fbs.GetRootAsEnvelope(frame, 0)
+	}
+
+	if err := tcp.WriteFrame(session, hello(token)); err != nil {
+		t.Fatalf("failed to write hello: %v", err)
+	}
+	if resp := read(); resp.Type() != fbs.MessageTypeWelcomeResp || resp.RequestId() != 1 {
+		t.Fatalf("got %s #%d, want WelcomeResp #1", resp.Type(), resp.RequestId())
+	}
+
+	// frames written back to back may share a KCP segment
+	var frames bytes.Buffer
+	for id := uint64(2); id <= 4; id++ {
+		_ = tcp.WriteFrame(&frames, subscribeReq(id, "lobby"))
+	}
+	if _, err := session.Write(frames.Bytes()); err != nil {
+		t.Fatalf("failed to write requests: %v", err)
+	}
+	for id := uint64(2); id <= 4; id++ {
+		if resp := read(); resp.Type() != fbs.MessageTypeSubscribeResp || resp.RequestId() != id {
+			t.Fatalf("got %s #%d, want SubscribeResp #%d", resp.Type(), resp.RequestId(), id)
+		}
+	}
+}
+
+func TestConnErrors(t *testing.T) {
+	addr, _ := startServer(t)
+	c := &conn{UDPSession: dial(t, addr)}
+
+	if err := c.SetReadDeadline(time.Now().Add(10 * time.Millisecond)); err != nil {
+		t.Fatalf("SetReadDeadline() error = %v", err)
+	}
+	if _, err := c.Read(make([]byte, 16)); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Read() past the deadline error = %v, want os.ErrDeadlineExceeded", err)
+	}
+
+	if err := c.SetReadDeadline(time.Time{}); err != nil {
+		t.Fatalf("SetReadDeadline() error = %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := c.Read(make([]byte, 16)); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Read() after Close error = %v, want net.ErrClosed", err)
+	}
+	if _, err := c.Write([]byte{1}); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Write() after Close error = %v, want net.ErrClosed", err)
+	}
+	if err := c.Close(); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("second Close() error = %v, want net.ErrClosed", err)
+	}
+}
@@ -0,0 +1,58 @@
+package tcp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// FrameHeaderSize is the length of the big-endian uint32 prefix carrying
+	// the size of the Envelope that follows it
+	FrameHeaderSize = 4
+
+	// DefaultMaxFrameSize bounds frames when the config sets no limit
+	DefaultMaxFrameSize = 64 * 1024
+)
+
+var (
+	ErrFrameTooLarge = errors.New("frame exceeds the maximum size")
+	ErrEmptyFrame    = errors.New("empty frame")
+)
+
+// ReadFrame reads one length-prefixed frame. Frames larger than maxSize are
+// rejected before their body is read.
+func ReadFrame(r io.Reader, maxSize int) ([]byte, error) {
+	var header [FrameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size == 0 {
+		return nil, ErrEmptyFrame
+	}
+	if uint64(size) > uint64(maxSize) {
+		return nil, fmt.Errorf("%w: %d > %d bytes", ErrFrameTooLarge, size, maxSize)
+	}
+
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return frame, nil
+}
+
+// WriteFrame writes payload with its length prefix in a single write
+func WriteFrame(w io.Writer, payload []byte) error {
+	frame := make([]byte, FrameHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload)))
+	copy(frame[FrameHeaderSize:], payload)
+
+	_, err := w.Write(frame)
+	return err
+}
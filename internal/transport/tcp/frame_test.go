@@ -0,0 +1,71 @@
+package tcp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestReadFrame(t *testing.T) {
+	const maxSize = 8
+
+	tests := []struct {
+		name    string
+		wire    []byte
+		want    []byte
+		wantErr error
+	}{
+		{"frame", []byte{0, 0, 0, 3, 'a', 'b', 'c'}, []byte("abc"), nil},
+		{"largest frame", append([]byte{0, 0, 0, maxSize}, bytes.Repeat([]byte{'x'}, maxSize)...), bytes.Repeat([]byte{'x'}, maxSize), nil},
+		{"next frame left unread", []byte{0, 0, 0, 1, 'a', 0, 0, 0, 1, 'b'}, []byte("a"), nil},
+		{"empty frame", []byte{0, 0, 0, 0}, nil, ErrEmptyFrame},
+		{"too large", []byte{0, 0, 0, maxSize + 1}, nil, ErrFrameTooLarge},
+		// the high bit must not wrap the size around to a small one
+		{"size with the high bit set", []byte{0x80, 0, 0, 1}, nil, ErrFrameTooLarge},
+		{"closed between frames", nil, nil, io.EOF},
+		{"closed in the header", []byte{0, 0}, nil, io.ErrUnexpectedEOF},
+		{"closed before the body", []byte{0, 0, 0, 3}, nil, io.ErrUnexpectedEOF},
+		{"closed in the body", []byte{0, 0, 0, 3, 'a'}, nil, io.ErrUnexpectedEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadFrame(bytes.NewReader(tt.wire), maxSize)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadFrame() error = %v, want %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("ReadFrame() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFrame(t *testing.T) {
+	payloads := [][]byte{[]byte("a"), bytes.Repeat([]byte{0xff}, 300), []byte("last")}
+
+	var wire bytes.Buffer
+	for _, payload := range payloads {
+		if err := WriteFrame(&wire, payload); err != nil {
+			t.Fatalf("WriteFrame() error = %v", err)
+		}
+	}
+
+	if got := wire.Bytes()[:FrameHeaderSize+1]; !bytes.Equal(got, []byte{0, 0, 0, 1, 'a'}) {
+		t.Errorf("first frame on the wire = %v", got)
+	}
+
+	for i, want := range payloads {
+		got, err := ReadFrame(&wire, DefaultMaxFrameSize)
+		if err != nil {
+			t.Fatalf("frame %d: ReadFrame() error = %v", i, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("frame %d = %q, want %q", i, got, want)
+		}
+	}
+	if _, err := ReadFrame(&wire, DefaultMaxFrameSize); !errors.Is(err, io.EOF) {
+		t.Errorf("after the last frame: error = %v, want %v", err, io.EOF)
+	}
+}
@@ -0,0 +1,50 @@
+package tcp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Richard-inter/game/internal/gateway"
+	"github.com/Richard-inter/game/pkg/errcode"
+)
+
+var errHandshakeRejected = errors.New("handshake rejected")
+
+// runHandshake reads the HelloReq opening a connection, authenticates the
+// token it carries and answers with WelcomeResp. TCP clients speak the same
+// protocol versions as WebSocket clients. Rejected clients get an ErrorResp
+// before the connection is closed.
+func (s *Server) runHandshake(c *Conn, reader *bufio.Reader) (int64, *gateway.ClientInfo, error) {
+	_ = c.conn.SetReadDeadline(time.Now().Add(s.handshake.Timeout))
+
+	frame, err := ReadFrame(reader, s.maxFrameSize())
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read hello: %w", err)
+	}
+
+	hello, rejection, err := s.handshake.Accept(frame)
+	if err != nil {
+		return 0, nil, reject(c, rejection, err)
+	}
+
+	playerID, err := s.auth.Authenticate(hello.Token)
+	if err != nil {
+		authErr := errcode.Wrap(errcode.Unauthenticated, err, "invalid or missing token")
+		return 0, nil, reject(c, gateway.ErrorResponse(authErr, hello.RequestID), authErr)
+	}
+
+	if err := c.Send(s.handshake.Welcome(hello, s.router)); err != nil {
+		return 0, nil, fmt.Errorf("failed to send welcome: %w", err)
+	}
+
+	return playerID, &hello.Client, nil
+}
+
+// reject queues the ErrorResp; the caller drains the connection so it is
+// delivered before the close
+func reject(c *Conn, response []byte, err error) error {
+	_ = c.Send(response)
+	return fmt.Errorf("%w: %v", errHandshakeRejected, err)
+}
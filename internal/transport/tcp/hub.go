@@ -0,0 +1,254 @@
+package tcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultSendQueueSize = 64
+	defaultWriteTimeout  = 10 * time.Second
+)
+
+var (
+	ErrSendQueueFull = errors.New("send queue full")
+	ErrConnClosed    = errors.New("connection closed")
+	ErrHubClosed     = errors.New("hub closed")
+)
+
+// ConnOptions controls the outbound queue of every connection
+type ConnOptions struct {
+	SendQueueSize int
+	WriteTimeout  time.Duration
+}
+
+func (o ConnOptions) withDefaults() ConnOptions {
+	if o.SendQueueSize <= 0 {
+		o.SendQueueSize = defaultSendQueueSize
+	}
+	if o.WriteTimeout <= 0 {
+		o.WriteTimeout = defaultWriteTimeout
+	}
+	return o
+}
+
+// Conn is one client connection. Outgoing frames are queued by Send and
+// written by a single writer goroutine, so responses and broadcasts never
+// interleave within a frame and a slow client cannot block its senders.
+type Conn struct {
+	ID uint64
+
+	conn      net.Conn
+	opts      ConnOptions
+	send      chan []byte
+	draining  chan struct{}
+	done      chan struct{}
+	drainOnce sync.Once
+	closeOnce sync.Once
+}
+
+func newConn(id uint64, conn net.Conn, opts ConnOptions) *Conn {
+	c := &Conn{
+		ID:       id,
+		conn:     conn,
+		opts:     opts,
+		send:     make(chan []byte, opts.SendQueueSize),
+		draining: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go c.writeLoop()
+
+	return c
+}
+
+// RemoteAddr returns the client's address
+func (c *Conn) RemoteAddr() string {
+	return c.conn.RemoteAddr().String()
+}
+
+// Send queues one Envelope for the client without blocking. A client whose
+// queue is full is too slow to keep up and is disconnected.
+func (c *Conn) Send(envelope []byte) error {
+	select {
+	case <-c.draining:
+		return ErrConnClosed
+	case <-c.done:
+		return ErrConnClosed
+	default:
+	}
+
+	select {
+	case c.send <- envelope:
+		return nil
+	default:
+	}
+
+	c.Close()
+	return ErrSendQueueFull
+}
+
+// Done is closed once the connection is closed
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Drain stops accepting frames, writes those already queued and then closes
+// the connection
+func (c *Conn) Drain() {
+	c.drainOnce.Do(func() {
+		close(c.draining)
+	})
+}
+
+// Disconnect flushes the frames already queued, such as the error telling
+// the client why, and closes the connection
+func (c *Conn) Disconnect(_ string) error {
+	c.Drain()
+	return nil
+}
+
+// Close closes the connection right away, discarding queued frames
+func (c *Conn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.done)
+		err = c.conn.Close()
+	})
+	return err
+}
+
+// stopReading ends the connection's read loop without cutting off the
+// responses still being written
+func (c *Conn) stopReading() {
+	if tcpConn, ok := c.conn.(*net.TCPConn); ok {
+		if tcpConn.CloseRead() == nil {
+			return
+		}
+	}
+	_ = c.conn.SetReadDeadline(time.Now())
+}
+
+func (c *Conn) write(envelope []byte) error {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.opts.WriteTimeout))
+	return WriteFrame(c.conn, envelope)
+}
+
+func (c *Conn) writeLoop() {
+	for {
+		select {
+		case envelope := <-c.send:
+			if err := c.write(envelope); err != nil {
+				c.Close()
+				return
+			}
+		case <-c.draining:
+			for {
+				select {
+				case envelope := <-c.send:
+					if err := c.write(envelope); err != nil {
+						c.Close()
+						return
+					}
+				default:
+					c.Close()
+					return
+				}
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// Hub owns the state of every connection of the TCP server
+type Hub struct {
+	nextID atomic.Uint64
+	opts   ConnOptions
+
+	mu     sync.RWMutex
+	conns  map[*Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func NewHub(opts ConnOptions) *Hub {
+	return &Hub{
+		opts:  opts.withDefaults(),
+		conns: make(map[*Conn]struct{}),
+	}
+}
+
+// Register tracks a freshly accepted connection. It fails once the hub is
+// shutting down.
+func (h *Hub) Register(conn net.Conn) (*Conn, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+
+	c := newConn(h.nextID.Add(1), conn, h.opts)
+	h.conns[c] = struct{}{}
+	h.wg.Add(1)
+
+	return c, nil
+}
+
+// Unregister forgets the connection
+func (h *Hub) Unregister(c *Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.conns[c]; ok {
+		delete(h.conns, c)
+		h.wg.Done()
+	}
+}
+
+// Len returns the number of live connections
+func (h *Hub) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.conns)
+}
+
+// Shutdown stops accepting connections and drains the live ones: they stop
+// reading requests, finish the request in flight and flush their queued
+// responses. Connections still open when ctx is done are closed.
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	h.closed = true
+	conns := make([]*Conn, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	h.mu.Unlock()
+
+	for _, c := range conns {
+		c.stopReading()
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		h.mu.RLock()
+		for c := range h.conns {
+			c.Close()
+		}
+		h.mu.RUnlock()
+		return ctx.Err()
+	}
+}
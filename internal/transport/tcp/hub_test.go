@@ -0,0 +1,146 @@
+package tcp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// pipeConn registers one end of an in-memory connection and returns the
+// client's end. Writes to a pipe block until the client reads, which makes
+// a client that never reads easy to fake.
+func pipeConn(t *testing.T, hub *Hub) (*Conn, net.Conn) {
+	t.Helper()
+
+	server, client := net.Pipe()
+	t.Cleanup(func() { client.Close() })
+
+	c, err := hub.Register(server)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c, client
+}
+
+// waitClosed fails the test unless c closes soon
+func waitClosed(t *testing.T, c *Conn) {
+	t.Helper()
+	select {
+	case <-c.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("connection still open")
+	}
+}
+
+func TestConnSendQueueOverflow(t *testing.T) {
+	hub := NewHub(ConnOptions{SendQueueSize: 2, WriteTimeout: time.Minute})
+	c, _ := pipeConn(t, hub)
+
+	// the writer picks up the first frame and blocks on the client, which
+	// never reads
+	if err := c.Send([]byte("1")); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	for deadline := time.Now().Add(2 * time.Second); len(c.send) > 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("writer never picked up the first frame")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, frame := range []string{"2", "3"} {
+		if err := c.Send([]byte(frame)); err != nil {
+			t.Fatalf("Send(%s) error = %v, the queue has room", frame, err)
+		}
+	}
+
+	// a full queue disconnects the client instead of blocking the sender
+	if err := c.Send([]byte("4")); !errors.Is(err, ErrSendQueueFull) {
+		t.Fatalf("Send() error = %v, want %v", err, ErrSendQueueFull)
+	}
+	waitClosed(t, c)
+
+	if err := c.Send([]byte("5")); !errors.Is(err, ErrConnClosed) {
+		t.Errorf("Send() after close error = %v, want %v", err, ErrConnClosed)
+	}
+}
+
+func TestConnDrain(t *testing.T) {
+	hub := NewHub(ConnOptions{SendQueueSize: 4, WriteTimeout: time.Minute})
+	c, client := pipeConn(t, hub)
+
+	frames := []string{"a", "b", "c"}
+	for _, frame := range frames {
+		if err := c.Send([]byte(frame)); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	c.Drain()
+
+	if err := c.Send([]byte("late")); !errors.Is(err, ErrConnClosed) {
+		t.Errorf("Send() while draining error = %v, want %v", err, ErrConnClosed)
+	}
+
+	// the queued frames are flushed in order before the connection closes
+	reader := bufio.NewReader(client)
+	for _, want := range frames {
+		got, err := ReadFrame(reader, testMaxFrameSize)
+		if err != nil {
+			t.Fatalf("ReadFrame() error = %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("frame = %q, want %q", got, want)
+		}
+	}
+	if _, err := ReadFrame(reader, testMaxFrameSize); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadFrame() after the flush error = %v, want EOF", err)
+	}
+	waitClosed(t, c)
+}
+
+func TestHubShutdown(t *testing.T) {
+	t.Run("drained", func(t *testing.T) {
+		hub := NewHub(ConnOptions{})
+		c, _ := pipeConn(t, hub)
+		if hub.Len() != 1 {
+			t.Fatalf("Len() = %d, want 1", hub.Len())
+		}
+
+		// the server unregisters a connection once its read loop ends
+		go func() {
+			c.Close()
+			hub.Unregister(c)
+		}()
+
+		if err := hub.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown() error = %v", err)
+		}
+		if hub.Len() != 0 {
+			t.Errorf("Len() = %d, want 0", hub.Len())
+		}
+
+		server, _ := net.Pipe()
+		defer server.Close()
+		if _, err := hub.Register(server); !errors.Is(err, ErrHubClosed) {
+			t.Errorf("Register() after shutdown error = %v, want %v", err, ErrHubClosed)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		hub := NewHub(ConnOptions{})
+		c, _ := pipeConn(t, hub)
+
+		// nothing unregisters the connection, so it is closed at the deadline
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if err := hub.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		waitClosed(t, c)
+	})
+}
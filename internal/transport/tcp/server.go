@@ -3,169 +3,294 @@ package tcp
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"os"
+	"sync"
 	"time"
 
-	"github.com/Richard-inter/game/internal/config"
 	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/internal/gateway"
+	"github.com/Richard-inter/game/internal/transport/grpc"
+	"github.com/Richard-inter/game/pkg/errcode"
 )
 
 const (
 	connectionRetryDelay = 100 * time.Millisecond
 )
 
+// Server speaks the game protocol over TCP. Every frame is a big-endian
+// uint32 length followed by an Envelope, the same Envelope WebSocket clients
+// exchange, so native clients and hardware bridges need no HTTP upgrade.
+// Requests are served by the same gateway router as WebSocket requests.
+//
+// Other stream transports, such as KCP, reuse the framing by handing their
+// listener to Serve.
 type Server struct {
-	config     *config.Config
-	logger     *zap.SugaredLogger
-	listener   net.Listener
-	clients    map[net.Conn]bool
-	clientsCh  chan net.Conn
-	disconnect chan net.Conn
-	messages   chan []byte
+	transport   string
+	config      config.TCPConfig
+	addr        string
+	logger      *zap.SugaredLogger
+	grpcManager *grpc.ClientManager
+	auth        *auth.Authenticator
+	router      *gateway.Router
+	sessions    *gateway.Registry
+	handshake   *gateway.Handshake
+	hub         *Hub
+
+	mu       sync.Mutex
+	listener net.Listener
+	stopped  bool // set by Shutdown
 }
 
-func NewServer(cfg *config.Config, logger *zap.SugaredLogger) *Server {
+func NewServer(
+	cfg *config.ServiceConfig,
+	logger *zap.SugaredLogger,
+	grpcManager *grpc.ClientManager,
+	authenticator *auth.Authenticator,
+) *Server {
+	s := NewStreamServer("tcp", cfg.TCP, logger, grpcManager, authenticator)
+	s.addr = cfg.GetTCPAddr()
+	return s
+}
+
+// NewStreamServer creates a server for the frames of any stream transport.
+// transport names its sessions in the gateway and in logs.
+func NewStreamServer(
+	transport string,
+	cfg config.TCPConfig,
+	logger *zap.SugaredLogger,
+	grpcManager *grpc.ClientManager,
+	authenticator *auth.Authenticator,
+) *Server {
 	return &Server{
-		config:     cfg,
-		logger:     logger,
-		clients:    make(map[net.Conn]bool),
-		clientsCh:  make(chan net.Conn),
-		disconnect: make(chan net.Conn),
-		messages:   make(chan []byte),
+		transport:   transport,
+		config:      cfg,
+		logger:      logger.With("transport", transport),
+		grpcManager: grpcManager,
+		auth:        authenticator,
+		sessions:    gateway.NewRegistry(),
+		handshake:   gateway.NewHandshake(uint32(cfg.MinProtocolVersion), time.Duration(cfg.HandshakeTimeout)*time.Second, nil),
+		hub: NewHub(ConnOptions{
+			SendQueueSize: cfg.SendQueueSize,
+			WriteTimeout:  time.Duration(cfg.WriteTimeout) * time.Second,
+		}),
 	}
 }
 
-func (s *Server) Start() error {
-	var err error
+// SetBuild sets the server build reported to clients in WelcomeResp
+func (s *Server) SetBuild(build string) {
+	s.handshake.Build = build
+}
 
-	// Create listener
+// Sessions returns the registry of authenticated connections
+func (s *Server) Sessions() *gateway.Registry {
+	return s.sessions
+}
+
+func (s *Server) maxFrameSize() int {
+	if s.config.MaxFrameSize > 0 {
+		return s.config.MaxFrameSize
+	}
+	return DefaultMaxFrameSize
+}
+
+// Start listens on the configured TCP address and serves it
+func (s *Server) Start() error {
 	lc := net.ListenConfig{}
-	s.listener, err = lc.Listen(context.Background(), "tcp", fmt.Sprintf("%s:%d", s.config.TCP.Host, s.config.TCP.Port))
+	listener, err := lc.Listen(context.Background(), "tcp", s.addr)
 	if err != nil {
 		return fmt.Errorf("failed to listen on TCP port: %w", err)
 	}
 
-	s.logger.Infow("Starting TCP server", "address", s.listener.Addr().String())
+	return s.Serve(listener)
+}
+
+// Serve accepts connections on listener until Shutdown closes it
+func (s *Server) Serve(listener net.Listener) error {
+	if err := s.handshake.Validate(); err != nil {
+		listener.Close()
+		return err
+	}
+
+	// stream gateways run without Redis, so only connection limits apply
+	limiter, err := gateway.NewRateLimiter(s.config.RateLimit, nil, s.logger)
+	if err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to create rate limiter: %w", err)
+	}
 
-	// Start connection handler
-	go s.handleConnections()
+	s.router, err = gateway.NewGameRouter(s.logger, s.grpcManager, s.sessions, limiter)
+	if err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to create gateway router: %w", err)
+	}
 
-	// Start message broadcaster
-	go s.broadcastMessages()
+	capturer, err := gateway.NewCapturer(s.config.Capture, s.logger)
+	if err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to start session capture: %w", err)
+	}
+	s.sessions.SetCapturer(capturer)
+
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		listener.Close()
+		return nil
+	}
+	s.listener = listener
+	s.mu.Unlock()
+
+	s.logger.Infow("Starting stream gateway", "address", listener.Addr().String())
 
 	// Accept connections
 	for {
-		conn, err := s.listener.Accept()
+		conn, err := listener.Accept()
 		if err != nil {
-			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
-				s.logger.Warnw("Temporary TCP error, retrying...", "error", err)
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				s.logger.Warnw("Temporary accept error, retrying...", "error", err)
 				time.Sleep(connectionRetryDelay)
 				continue
 			}
-			return fmt.Errorf("TCP accept error: %w", err)
+			return fmt.Errorf("%s accept error: %w", s.transport, err)
+		}
+
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			_ = tcpConn.SetKeepAlive(s.config.KeepAlive)
 		}
 
-		s.clientsCh <- conn
+		c, err := s.hub.Register(conn)
+		if err != nil {
+			// shutting down
+			conn.Close()
+			continue
+		}
+
+		go s.handleClient(c)
 	}
 }
 
-func (s *Server) Shutdown(_ context.Context) error {
-	if s.listener == nil {
+// Shutdown stops accepting connections and drains the open ones until ctx
+// is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	listener := s.listener
+	s.mu.Unlock()
+
+	if listener == nil {
 		return nil
 	}
 
-	s.logger.Infow("Shutting down TCP server")
+	s.logger.Infow("Shutting down stream gateway", "connections", s.hub.Len())
 
 	// Close listener
-	if err := s.listener.Close(); err != nil {
-		s.logger.Errorw("Failed to close TCP listener", "error", err)
+	if err := listener.Close(); err != nil {
+		s.logger.Errorw("Failed to close listener", "error", err)
 	}
 
-	// Close all client connections
-	for client := range s.clients {
-		client.Close()
-		delete(s.clients, client)
+	if err := s.hub.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to drain %s connections: %w", s.transport, err)
 	}
-
 	return nil
 }
 
-func (s *Server) handleConnections() {
-	for {
-		select {
-		case conn := <-s.clientsCh:
-			s.clients[conn] = true
-			go s.handleClient(conn)
-		case conn := <-s.disconnect:
-			delete(s.clients, conn)
-			conn.Close()
-		}
-	}
-}
+// handleClient authenticates the connection with its HelloReq, then hands
+// its requests to the session's gateway pipeline
+func (s *Server) handleClient(c *Conn) {
+	clientAddr := c.RemoteAddr()
 
-func (s *Server) handleClient(conn net.Conn) {
 	defer func() {
-		s.disconnect <- conn
+		// a malformed Envelope must only cost its own connection
+		if r := recover(); r != nil {
+			s.logger.Errorw("Client handler panicked", "client", clientAddr, "panic", r)
+		}
+
+		// flush the responses still queued before letting go
+		c.Drain()
+		<-c.Done()
+		s.hub.Unregister(c)
 	}()
 
-	clientAddr := conn.RemoteAddr().String()
-	s.logger.Infow("TCP client connected", "client", clientAddr)
+	s.logger.Infow("Client connected", "client", clientAddr)
 
-	// Set read/write timeouts
-	if s.config.TCP.ReadTimeout > 0 {
-		if err := conn.SetReadDeadline(time.Now().Add(time.Duration(s.config.TCP.ReadTimeout) * time.Second)); err != nil {
-			s.logger.Errorw("Failed to set read deadline", "error", err)
-		}
-	}
-	if s.config.TCP.WriteTimeout > 0 {
-		if err := conn.SetWriteDeadline(time.Now().Add(time.Duration(s.config.TCP.WriteTimeout) * time.Second)); err != nil {
-			s.logger.Errorw("Failed to set write deadline", "error", err)
-		}
+	reader := bufio.NewReader(c.conn)
+
+	playerID, client, err := s.runHandshake(c, reader)
+	if err != nil {
+		s.logger.Infow("Handshake failed", "client", clientAddr, "error", err)
+		return
 	}
 
-	scanner := bufio.NewScanner(conn)
-	for scanner.Scan() {
-		message := scanner.Bytes()
+	session := s.sessions.Register(c, playerID, *client, s.transport)
+	defer s.sessions.Unregister(session)
 
-		s.logger.Debugw("Received TCP message", "client", clientAddr, "message", string(message))
+	s.logger.Infow("Client authenticated", "client", clientAddr, "session_id", session.ID, "player_id", playerID)
 
-		// Echo message back (placeholder)
-		if _, err := conn.Write(message); err != nil {
-			s.logger.Errorw("Failed to send TCP message", "error", err)
-			break
-		}
+	pipeline := s.router.NewPipeline(context.Background(), session, s.config.Workers)
+	defer pipeline.Close()
+
+	readTimeout := time.Duration(s.config.ReadTimeout) * time.Second
 
+	for {
 		// Reset read deadline
-		if s.config.TCP.ReadTimeout > 0 {
-			conn.SetReadDeadline(time.Now().Add(time.Duration(s.config.TCP.ReadTimeout) * time.Second))
+		if readTimeout > 0 {
+			_ = c.conn.SetReadDeadline(time.Now().Add(readTimeout))
+		} else {
+			_ = c.conn.SetReadDeadline(time.Time{})
 		}
-	}
 
-	if err := scanner.Err(); err != nil {
-		s.logger.Errorw("TCP client error", "error", err, "client", clientAddr)
-	}
+		frame, err := ReadFrame(reader, s.maxFrameSize())
+		if err != nil {
+			if errors.Is(err, ErrEmptyFrame) {
+				continue
+			}
+			if errors.Is(err, ErrFrameTooLarge) {
+				// the rest of the frame is still on the wire, so the stream
+				// cannot be resynchronized
+				_ = c.Send(gateway.ErrorResponse(errcode.New(errcode.InvalidArgument, err.Error()), 0))
+			}
+			s.logDisconnect(clientAddr, err)
+			return
+		}
 
-	s.logger.Infow("TCP client disconnected", "client", clientAddr)
-}
+		s.logger.Debugw("Received frame", "client", clientAddr, "size", len(frame))
 
-func (s *Server) broadcastMessages() {
-	for message := range s.messages {
-		for client := range s.clients {
-			if _, err := client.Write(message); err != nil {
-				s.logger.Errorw("Failed to broadcast TCP message", "error", err)
-				client.Close()
-				delete(s.clients, client)
-			}
+		if !pipeline.Submit(frame) {
+			s.logger.Infow("Client closed", "client", clientAddr, "player_id", playerID)
+			return
 		}
 	}
 }
 
-// Broadcast message to all connected clients
-func (s *Server) Broadcast(message []byte) {
-	select {
-	case s.messages <- message:
+func (s *Server) logDisconnect(clientAddr string, err error) {
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, net.ErrClosed):
+		s.logger.Infow("Client disconnected", "client", clientAddr)
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		s.logger.Infow("Client timed out", "client", clientAddr)
 	default:
-		s.logger.Warnw("TCP broadcast channel is full")
+		s.logger.Errorw("Client error", "client", clientAddr, "error", err)
+	}
+}
+
+// Broadcast sends an Envelope to all authenticated clients. Clients too slow
+// to take it are disconnected.
+func (s *Server) Broadcast(message []byte) {
+	sent := 0
+	for _, session := range s.sessions.All() {
+		if session.Send(message) == nil {
+			sent++
+		}
 	}
+	s.logger.Debugw("Broadcast", "recipients", sent)
 }
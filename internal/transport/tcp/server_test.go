@@ -0,0 +1,235 @@
+package tcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"go.uber.org/zap"
+
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/internal/gateway"
+	"github.com/Richard-inter/game/internal/transport/grpc"
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+const testMaxFrameSize = 1024
+
+// startServer serves a stream gateway on a loopback port. Requests that
+// reach the runtime service fail, since none runs; the handshake and the
+// requests the gateway answers itself do not need it.
+func startServer(t *testing.T) (string, *auth.Authenticator) {
+	t.Helper()
+
+	authenticator, err := auth.NewAuthenticator(config.JWTConfig{Secret: "test-secret", ExpirationTime: 60})
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+	grpcManager, err := grpc.NewClientManager(&grpc.ClientManagerConfig{RuntimeAddr: "127.0.0.1:1"})
+	if err != nil {
+		t.Fatalf("failed to create client manager: %v", err)
+	}
+
+	server := NewStreamServer("tcp", config.TCPConfig{
+		MaxFrameSize:     testMaxFrameSize,
+		HandshakeTimeout: 5,
+		SendQueueSize:    16,
+		WriteTimeout:     5,
+	}, zap.NewNop().Sugar(), grpcManager, authenticator)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			t.Errorf("Shutdown() error = %v", err)
+		}
+		if err := <-served; err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	})
+
+	return listener.Addr().String(), authenticator
+}
+
+type testClient struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dial(t *testing.T, addr string) *testClient {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	return &testClient{t: t, conn: conn, reader: bufio.NewReader(conn)}
+}
+
+func (c *testClient) write(frame []byte) {
+	c.t.Helper()
+	if err := WriteFrame(c.conn, frame); err != nil {
+		c.t.Fatalf("failed to write frame: %v", err)
+	}
+}
+
+func (c *testClient) read() *fbs.Envelope {
+	c.t.Helper()
+	frame, err := ReadFrame(c.reader, testMaxFrameSize)
+	if err != nil {
+		c.t.Fatalf("failed to read frame: %v", err)
+	}
+	if err := gateway.ValidateEnvelope(frame); err != nil {
+		c.t.Fatalf("server sent a malformed envelope: %v", err)
+	}
+	return fbs.GetRootAsEnvelope(frame, 0)
+}
+
+// closed waits for the server to close the connection
+func (c *testClient) closed() bool {
+	_, err := ReadFrame(c.reader, testMaxFrameSize)
+	return errors.Is(err, io.EOF)
+}
+
+func hello(version uint32, token string) []byte {
+	builder := flatbuffers.NewBuilder(128)
+	builder.Finish((&fbs.HelloReqT{ProtocolVersion: version, ClientBuild: "test", Token: token}).Pack(builder))
+	return gateway.BuildEnvelope(fbs.EnvelopeKindRequest, fbs.MessageTypeHelloReq, 1, builder.FinishedBytes())
+}
+
+func subscribeReq(requestID uint64, room string) []byte {
+	builder := flatbuffers.NewBuilder(64)
+	builder.Finish((&fbs.SubscribeReqT{Room: room}).Pack(builder))
+	return gateway.BuildEnvelope(fbs.EnvelopeKindRequest, fbs.MessageTypeSubscribeReq, requestID, builder.FinishedBytes())
+}
+
+func errorCode(t *testing.T, envelope *fbs.Envelope) errcode.Code {
+	t.Helper()
+	if envelope.Type() != fbs.MessageTypeErrorResp {
+		t.Fatalf("got %s, want an ErrorResp", envelope.Type())
+	}
+	return errcode.Code(fbs.GetRootAsErrorResp(envelope.PayloadBytes(), 0).ErrorCode())
+}
+
+func TestHandshake(t *testing.T) {
+	addr, authenticator := startServer(t)
+	token, err := authenticator.IssueToken(7)
+	if err != nil {
+		t.Fatalf("failed to issue token: %v", err)
+	}
+
+	malformed := hello(gateway.ProtocolVersion, token)
+	binary.LittleEndian.PutUint32(malformed, 0xfffffff0)
+
+	tests := []struct {
+		name     string
+		first    []byte
+		wantCode errcode.Code // "" when the client is welcomed
+	}{
+		{"welcome", hello(gateway.ProtocolVersion, token), ""},
+		{"bad token", hello(gateway.ProtocolVersion, "not-a-token"), errcode.Unauthenticated},
+		{"no token", hello(gateway.ProtocolVersion, ""), errcode.Unauthenticated},
+		{"unsupported version", hello(gateway.ProtocolVersion+1, token), errcode.UnsupportedVersion},
+		// clients from before the handshake open with a request
+		{"request before hello", subscribeReq(1, "lobby"), errcode.UnsupportedVersion},
+		{"malformed envelope", malformed, errcode.InvalidArgument},
+		{"malformed hello", gateway.BuildEnvelope(fbs.EnvelopeKindRequest, fbs.MessageTypeHelloReq, 1, []byte{0xf0, 0xff, 0xff, 0x7f}), errcode.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dial(t, addr)
+			client.write(tt.first)
+			resp := client.read()
+
+			if tt.wantCode == "" {
+				if resp.Type() != fbs.MessageTypeWelcomeResp || resp.RequestId() != 1 {
+					t.Fatalf("got %s #%d, want WelcomeResp #1", resp.Type(), resp.RequestId())
+				}
+				welcome := fbs.GetRootAsWelcomeResp(resp.PayloadBytes(), 0)
+				if welcome.ProtocolVersion() != gateway.ProtocolVersion {
+					t.Errorf("agreed version %d, want %d", welcome.ProtocolVersion(), gateway.ProtocolVersion)
+				}
+				return
+			}
+
+			if code := errorCode(t, resp); code != tt.wantCode {
+				t.Errorf("error code = %s, want %s", code, tt.wantCode)
+			}
+			if !client.closed() {
+				t.Error("connection left open after a rejected handshake")
+			}
+		})
+	}
+}
+
+func TestRequests(t *testing.T) {
+	addr, authenticator := startServer(t)
+	token, err := authenticator.IssueToken(7)
+	if err != nil {
+		t.Fatalf("failed to issue token: %v", err)
+	}
+
+	client := dial(t, addr)
+	client.write(hello(gateway.ProtocolVersion, token))
+	if resp := client.read(); resp.Type() != fbs.MessageTypeWelcomeResp {
+		t.Fatalf("got %s, want WelcomeResp", resp.Type())
+	}
+
+	// served by the gateway itself, in order, with the request ID echoed
+	for id := uint64(2); id <= 4; id++ {
+		client.write(subscribeReq(id, "lobby"))
+	}
+	for id := uint64(2); id <= 4; id++ {
+		resp := client.read()
+		if resp.Type() != fbs.MessageTypeSubscribeResp || resp.RequestId() != id || resp.Kind() != fbs.EnvelopeKindResponse {
+			t.Fatalf("got %s #%d (%s), want SubscribeResp #%d", resp.Type(), resp.RequestId(), resp.Kind(), id)
+		}
+	}
+
+	// a malformed envelope is answered without closing the connection
+	malformed := subscribeReq(5, "lobby")
+	binary.LittleEndian.PutUint32(malformed, 0xfffffff0)
+	client.write(malformed)
+	resp := client.read()
+	if code := errorCode(t, resp); code != errcode.InvalidArgument || resp.RequestId() != 0 {
+		t.Errorf("malformed envelope: got %s #%d, want INVALID_ARGUMENT #0", code, resp.RequestId())
+	}
+
+	client.write(subscribeReq(6, "lobby"))
+	if resp := client.read(); resp.Type() != fbs.MessageTypeSubscribeResp || resp.RequestId() != 6 {
+		t.Fatalf("after a malformed envelope: got %s #%d, want SubscribeResp #6", resp.Type(), resp.RequestId())
+	}
+
+	// an oversized frame cannot be skipped, so it ends the connection
+	var header [FrameHeaderSize]byte
+	binary.BigEndian.PutUint32(header[:], testMaxFrameSize+1)
+	if _, err := client.conn.Write(header[:]); err != nil {
+		t.Fatalf("failed to write header: %v", err)
+	}
+	if code := errorCode(t, client.read()); code != errcode.InvalidArgument {
+		t.Errorf("oversized frame: error code = %s, want %s", code, errcode.InvalidArgument)
+	}
+	if !client.closed() {
+		t.Error("connection left open after an oversized frame")
+	}
+}
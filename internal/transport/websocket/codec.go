@@ -0,0 +1,255 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/gorilla/websocket"
+
+	"github.com/Richard-inter/game/internal/gateway"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+const (
+	// CodecFlatBuffers exchanges binary frames holding FlatBuffers Envelopes
+	CodecFlatBuffers = "flatbuffers"
+	// CodecJSON exchanges text frames holding JSON envelopes whose payloads
+	// mirror the FlatBuffers tables field by field
+	CodecJSON = "json"
+
+	// CodecQueryParam selects the codec when no subprotocol was negotiated
+	CodecQueryParam = "codec"
+)
+
+// Subprotocols lists the codecs clients may request through
+// Sec-WebSocket-Protocol, in order of preference
+var Subprotocols = []string{CodecFlatBuffers, CodecJSON}
+
+// Codec converts between the frames of one connection and the FlatBuffers
+// Envelopes used everywhere behind the gateway
+type Codec interface {
+	Name() string
+	// Decode turns a client frame into an Envelope
+	Decode(frameType int, data []byte) ([]byte, error)
+	// Encode turns an Envelope into the frame type and data sent to the client
+	Encode(envelope []byte) (int, []byte, error)
+}
+
+// FrameError reports a client frame the codec could not decode. RequestID is
+// set when the frame was read far enough to carry one.
+type FrameError struct {
+	RequestID uint64
+	Err       error
+}
+
+func (e *FrameError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FrameError) Unwrap() error {
+	return e.Err
+}
+
+// CodecByName returns the codec registered under name
+func CodecByName(name string) (Codec, bool) {
+	switch name {
+	case CodecFlatBuffers:
+		return flatBuffersCodec{}, true
+	case CodecJSON:
+		return jsonCodec{}, true
+	default:
+		return nil, false
+	}
+}
+
+type flatBuffersCodec struct{}
+
+func (flatBuffersCodec) Name() string {
+	return CodecFlatBuffers
+}
+
+func (flatBuffersCodec) Decode(frameType int, data []byte) ([]byte, error) {
+	if frameType != websocket.BinaryMessage {
+		return nil, &FrameError{Err: errors.New("expected a binary frame")}
+	}
+	if len(data) < flatbuffers.SizeUOffsetT {
+		return nil, &FrameError{Err: errors.New("frame too short")}
+	}
+	return data, nil
+}
+
+func (flatBuffersCodec) Encode(envelope []byte) (int, []byte, error) {
+	return websocket.BinaryMessage, envelope, nil
+}
+
+// jsonEnvelope is the JSON form of an Envelope. Type and Kind use the names
+// from the schema.
+type jsonEnvelope struct {
+	Type      string          `json:"type"`
+	Kind      string          `json:"kind,omitempty"`
+	RequestID uint64          `json:"requestId"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string {
+	return CodecJSON
+}
+
+func (jsonCodec) Decode(frameType int, data []byte) ([]byte, error) {
+	if frameType != websocket.TextMessage {
+		return nil, &FrameError{Err: errors.New("expected a text frame")}
+	}
+
+	var env jsonEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, &FrameError{Err: fmt.Errorf("invalid envelope: %w", err)}
+	}
+
+	msgType, ok := fbs.EnumValuesMessageType[env.Type]
+	if !ok {
+		return nil, &FrameError{RequestID: env.RequestID, Err: fmt.Errorf("unknown message type %q", env.Type)}
+	}
+
+	var payload []byte
+	if len(env.Payload) > 0 && !bytes.Equal(env.Payload, []byte("null")) {
+		table, ok := jsonTables[msgType]
+		if !ok {
+			return nil, &FrameError{RequestID: env.RequestID, Err: fmt.Errorf("message type %s has no JSON form", env.Type)}
+		}
+
+		var err error
+		payload, err = table.decode(env.Payload)
+		if err != nil {
+			return nil, &FrameError{RequestID: env.RequestID, Err: fmt.Errorf("invalid %s payload: %w", env.Type, err)}
+		}
+	}
+
+	return gateway.BuildEnvelope(fbs.EnvelopeKindRequest, msgType, env.RequestID, payload), nil
+}
+
+func (jsonCodec) Encode(envelope []byte) (int, []byte, error) {
+	env := fbs.GetRootAsEnvelope(envelope, 0)
+
+	out := jsonEnvelope{
+		Type:      env.Type().String(),
+		Kind:      env.Kind().String(),
+		RequestID: env.RequestId(),
+	}
+
+	if raw := env.PayloadBytes(); len(raw) > 0 {
+		var payload any = raw
+		if table, ok := jsonTables[env.Type()]; ok {
+			payload = table.encode(raw)
+		}
+
+		var err error
+		out.Payload, err = json.Marshal(payload)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to encode %s payload: %w", env.Type(), err)
+		}
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to encode envelope: %w", err)
+	}
+	return websocket.TextMessage, data, nil
+}
+
+// jsonTable converts one FlatBuffers table to and from JSON through its
+// generated object API type, whose JSON tags use the schema field names
+type jsonTable struct {
+	decode func(raw json.RawMessage) ([]byte, error)
+	encode func(buf []byte) any
+}
+
+type packable[T any] interface {
+	*T
+	Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT
+}
+
+func jsonTableOf[T any, PT packable[T]](unpack func(buf []byte) PT) jsonTable {
+	return jsonTable{
+		decode: func(raw json.RawMessage) ([]byte, error) {
+			var t T
+			dec := json.NewDecoder(bytes.NewReader(raw))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&t); err != nil {
+				return nil, err
+			}
+
+			builder := flatbuffers.NewBuilder(256)
+			builder.Finish(PT(&t).Pack(builder))
+			return builder.FinishedBytes(), nil
+		},
+		encode: func(buf []byte) any {
+			return unpack(buf)
+		},
+	}
+}
+
+// jsonTables maps every message type to its payload table
+var jsonTables = map[fbs.MessageType]jsonTable{
+	fbs.MessageTypeHelloReq: jsonTableOf(func(b []byte) *fbs.HelloReqT {
+		return fbs.GetRootAsHelloReq(b, 0).UnPack()
+	}),
+	fbs.MessageTypeWelcomeResp: jsonTableOf(func(b []byte) *fbs.WelcomeRespT {
+		return fbs.GetRootAsWelcomeResp(b, 0).UnPack()
+	}),
+	fbs.MessageTypeStartClawGameReq: jsonTableOf(func(b []byte) *fbs.StartClawGameReqT {
+		return fbs.GetRootAsStartClawGameReq(b, 0).UnPack()
+	}),
+	fbs.MessageTypeStartClawGameResp: jsonTableOf(func(b []byte) *fbs.StartClawGameRespT {
+		return fbs.GetRootAsStartClawGameResp(b, 0).UnPack()
+	}),
+	fbs.MessageTypeClawInputReq: jsonTableOf(func(b []byte) *fbs.ClawInputReqT {
+		return fbs.GetRootAsClawInputReq(b, 0).UnPack()
+	}),
+	fbs.MessageTypeClawStateResp: jsonTableOf(func(b []byte) *fbs.ClawStateRespT {
+		return fbs.GetRootAsClawStateResp(b, 0).UnPack()
+	}),
+	fbs.MessageTypeGetPlayerInfoWsReq: jsonTableOf(func(b []byte) *fbs.GetPlayerInfoWsReqT {
+		return fbs.GetRootAsGetPlayerInfoWsReq(b, 0).UnPack()
+	}),
+	fbs.MessageTypeGetPlayerInfoWsResp: jsonTableOf(func(b []byte) *fbs.GetPlayerInfoWsRespT {
+		return fbs.GetRootAsGetPlayerInfoWsResp(b, 0).UnPack()
+	}),
+	fbs.MessageTypeRedeemVoucherReq: jsonTableOf(func(b []byte) *fbs.RedeemVoucherReqT {
+		return fbs.GetRootAsRedeemVoucherReq(b, 0).UnPack()
+	}),
+	fbs.MessageTypeRedeemVoucherResp: jsonTableOf(func(b []byte) *fbs.RedeemVoucherRespT {
+		return fbs.GetRootAsRedeemVoucherResp(b, 0).UnPack()
+	}),
+	fbs.MessageTypeSubscribeReq: jsonTableOf(func(b []byte) *fbs.SubscribeReqT {
+		return fbs.GetRootAsSubscribeReq(b, 0).UnPack()
+	}),
+	fbs.MessageTypeUnsubscribeReq: jsonTableOf(func(b []byte) *fbs.UnsubscribeReqT {
+		return fbs.GetRootAsUnsubscribeReq(b, 0).UnPack()
+	}),
+	fbs.MessageTypeSubscribeResp: jsonTableOf(func(b []byte) *fbs.SubscribeRespT {
+		return fbs.GetRootAsSubscribeResp(b, 0).UnPack()
+	}),
+	fbs.MessageTypeBalanceChangedPush: jsonTableOf(func(b []byte) *fbs.BalanceChangedPushT {
+		return fbs.GetRootAsBalanceChangedPush(b, 0).UnPack()
+	}),
+	fbs.MessageTypeNotificationPush: jsonTableOf(func(b []byte) *fbs.NotificationPushT {
+		return fbs.GetRootAsNotificationPush(b, 0).UnPack()
+	}),
+	fbs.MessageTypeSessionResumePush: jsonTableOf(func(b []byte) *fbs.SessionResumePushT {
+		return fbs.GetRootAsSessionResumePush(b, 0).UnPack()
+	}),
+	fbs.MessageTypeGetMachineInfoWsReq: jsonTableOf(func(b []byte) *fbs.GetMachineInfoWsReqT {
+		return fbs.GetRootAsGetMachineInfoWsReq(b, 0).UnPack()
+	}),
+	fbs.MessageTypeGetMachineInfoWsResp: jsonTableOf(func(b []byte) *fbs.GetMachineInfoWsRespT {
+		return fbs.GetRootAsGetMachineInfoWsResp(b, 0).UnPack()
+	}),
+	fbs.MessageTypeErrorResp: jsonTableOf(func(b []byte) *fbs.ErrorRespT {
+		return fbs.GetRootAsErrorResp(b, 0).UnPack()
+	}),
+}
@@ -0,0 +1,171 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/gorilla/websocket"
+
+	"github.com/Richard-inter/game/internal/gateway"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// jsonEqual compares two JSON documents ignoring layout and key order
+func jsonEqual(t *testing.T, got, want string) bool {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal([]byte(got), &g); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("invalid JSON %s: %v", want, err)
+	}
+	return reflect.DeepEqual(g, w)
+}
+
+func TestJSONTablesCoverMessageTypes(t *testing.T) {
+	for msgType, name := range fbs.EnumNamesMessageType {
+		switch msgType {
+		case fbs.MessageTypeAddTouchedItemRecordReq, fbs.MessageTypeAddTouchedItemRecordResp:
+			// protocol version 1 only, which predates the JSON codec
+			continue
+		}
+		if _, ok := jsonTables[msgType]; !ok {
+			t.Errorf("%s has no JSON form", name)
+		}
+	}
+}
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame string
+	}{
+		{"hello", `{"type":"HelloReq","requestId":1,"payload":{"protocol_version":2,"client_build":"web-1.0","features":["resume","push"],"token":"abc"}}`},
+		{"claw input", `{"type":"ClawInputReq","requestId":7,"payload":{"game_id":12,"action":1,"dx":-3,"dy":4}}`},
+		{"voucher", `{"type":"RedeemVoucherReq","requestId":8,"payload":{"player_id":0,"code":"WELCOME"}}`},
+		{"no payload", `{"type":"GetPlayerInfoWsReq","requestId":9}`},
+	}
+
+	codec := jsonCodec{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := codec.Decode(websocket.TextMessage, []byte(tt.frame))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if err := gateway.ValidateEnvelope(envelope); err != nil {
+				t.Fatalf("Decode() built a malformed envelope: %v", err)
+			}
+			if kind := fbs.GetRootAsEnvelope(envelope, 0).Kind(); kind != fbs.EnvelopeKindRequest {
+				t.Errorf("kind = %s, want Request", kind)
+			}
+
+			frameType, data, err := codec.Encode(envelope)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if frameType != websocket.TextMessage {
+				t.Errorf("frame type = %d, want text", frameType)
+			}
+
+			// the frame comes back as sent, plus the kind
+			var want map[string]any
+			_ = json.Unmarshal([]byte(tt.frame), &want)
+			want["kind"] = "Request"
+			wantJSON, _ := json.Marshal(want)
+			if !jsonEqual(t, string(data), string(wantJSON)) {
+				t.Errorf("Encode() = %s, want %s", data, wantJSON)
+			}
+		})
+	}
+}
+
+func TestJSONCodecEncodesNestedTables(t *testing.T) {
+	resp := &fbs.StartClawGameRespT{
+		GameId:    12,
+		FreePlay:  true,
+		PricePaid: 0,
+		Board: &fbs.ClawBoardT{
+			Width: 100, Height: 60, GrabRadius: 5, MaxStep: 10, MaxMoves: 20,
+			Items: []*fbs.BoardItemT{{ItemId: 3, X: 10, Y: 20}, {ItemId: 4, X: 30, Y: 40}},
+		},
+		Claw: &fbs.ClawStateRespT{GameId: 12, X: 50, Y: 30, MovesLeft: 20},
+	}
+	builder := flatbuffers.NewBuilder(256)
+	builder.Finish(resp.Pack(builder))
+	envelope := gateway.BuildEnvelope(fbs.EnvelopeKindResponse, fbs.MessageTypeStartClawGameResp, 5, builder.FinishedBytes())
+
+	_, data, err := jsonCodec{}.Encode(envelope)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	var frame jsonEnvelope
+	if err := json.Unmarshal(data, &frame); err != nil {
+		t.Fatalf("invalid frame %s: %v", data, err)
+	}
+	if frame.Type != "StartClawGameResp" || frame.Kind != "Response" || frame.RequestID != 5 {
+		t.Errorf("envelope = %s %s #%d", frame.Kind, frame.Type, frame.RequestID)
+	}
+
+	var got fbs.StartClawGameRespT
+	if err := json.Unmarshal(frame.Payload, &got); err != nil {
+		t.Fatalf("invalid payload %s: %v", frame.Payload, err)
+	}
+	if !reflect.DeepEqual(&got, resp) {
+		t.Errorf("payload = %s", frame.Payload)
+	}
+}
+
+func TestJSONCodecDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		frameType     int
+		frame         string
+		wantRequestID uint64
+	}{
+		{"binary frame", websocket.BinaryMessage, `{"type":"HelloReq","requestId":1}`, 0},
+		{"not json", websocket.TextMessage, `{"type":`, 0},
+		// errors after the request ID was read are answered to that request
+		{"unknown type", websocket.TextMessage, `{"type":"TeleportReq","requestId":4}`, 4},
+		{"unknown field", websocket.TextMessage, `{"type":"RedeemVoucherReq","requestId":5,"payload":{"code":"A","bonus":1}}`, 5},
+		{"wrong field type", websocket.TextMessage, `{"type":"ClawInputReq","requestId":6,"payload":{"game_id":"twelve"}}`, 6},
+		{"no JSON form", websocket.TextMessage, `{"type":"AddTouchedItemRecordReq","requestId":7,"payload":{}}`, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jsonCodec{}.Decode(tt.frameType, []byte(tt.frame))
+			var frameErr *FrameError
+			if !errors.As(err, &frameErr) {
+				t.Fatalf("Decode() error = %v, want a FrameError", err)
+			}
+			if frameErr.RequestID != tt.wantRequestID {
+				t.Errorf("request ID = %d, want %d", frameErr.RequestID, tt.wantRequestID)
+			}
+		})
+	}
+}
+
+func TestFlatBuffersCodec(t *testing.T) {
+	envelope := gateway.BuildEnvelope(fbs.EnvelopeKindRequest, fbs.MessageTypeGetPlayerInfoWsReq, 1, nil)
+
+	codec := flatBuffersCodec{}
+	decoded, err := codec.Decode(websocket.BinaryMessage, envelope)
+	if err != nil || !reflect.DeepEqual(decoded, envelope) {
+		t.Errorf("Decode() = %v, %v", decoded, err)
+	}
+	if frameType, data, _ := codec.Encode(envelope); frameType != websocket.BinaryMessage || !reflect.DeepEqual(data, envelope) {
+		t.Errorf("Encode() changed the envelope")
+	}
+	if _, err := codec.Decode(websocket.TextMessage, envelope); err == nil {
+		t.Error("Decode() accepted a text frame")
+	}
+	if _, err := codec.Decode(websocket.BinaryMessage, []byte{1}); err == nil {
+		t.Error("Decode() accepted a truncated frame")
+	}
+}
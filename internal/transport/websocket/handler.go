@@ -2,66 +2,57 @@ package websocket
 
 import (
 	"context"
+	"errors"
 
-	flatbuffers "github.com/google/flatbuffers/go"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 
-	"github.com/Richard-inter/game/internal/transport/grpc"
-	runtimepb "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket"
-	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+	"github.com/Richard-inter/game/internal/gateway"
+	"github.com/Richard-inter/game/pkg/errcode"
 )
 
-type messageHandler func(ctx context.Context, payload []byte) ([]byte, error)
+const defaultWorkersPerConnection = 4
 
+// WebSocketHandler reads the requests of WebSocket sessions and hands them
+// to the gateway router
 type WebSocketHandler struct {
-	logger            *zap.SugaredLogger
-	playerClient      *grpc.PlayerClient
-	clawmachineClient *grpc.ClawMachineClient
-	wsClient          *grpc.ClawMachineRuntimeClient
-
-	handlers map[fbs.MessageType]messageHandler
+	logger  *zap.SugaredLogger
+	router  *gateway.Router
+	workers int
 }
 
-func NewWebSocketHandler(logger *zap.SugaredLogger, grpcManager *grpc.ClientManager) (*WebSocketHandler, error) {
-	playerClient, err := grpcManager.GetPlayerClient()
-	if err != nil {
-		return nil, err
-	}
-
-	clawmachineClient, err := grpcManager.GetClawMachineClient()
-	if err != nil {
-		return nil, err
-	}
-
-	runtimeClient, err := grpcManager.GetClawMachineRuntimeClient()
-	if err != nil {
-		return nil, err
+func NewWebSocketHandler(logger *zap.SugaredLogger, router *gateway.Router, workers int) *WebSocketHandler {
+	if workers <= 0 {
+		workers = defaultWorkersPerConnection
 	}
 
-	h := &WebSocketHandler{
-		logger:            logger,
-		playerClient:      playerClient,
-		clawmachineClient: clawmachineClient,
-		wsClient:          runtimeClient,
-		handlers:          make(map[fbs.MessageType]messageHandler),
+	return &WebSocketHandler{
+		logger:  logger,
+		router:  router,
+		workers: workers,
 	}
+}
 
-	h.handlers[fbs.MessageTypeStartClawGameReq] = h.handleStartClawGame
-	h.handlers[fbs.MessageTypeGetPlayerInfoWsReq] = h.handleGetPlayerInfo
-	h.handlers[fbs.MessageTypeAddTouchedItemRecordReq] = h.handleAddTouchedItemRecord
+// HandleConnection serves one session until its connection goes away.
+// Requests run on the session's gateway pipeline; when every worker is busy
+// the read loop stops reading, pushing back on the client.
+func (h *WebSocketHandler) HandleConnection(session *Session) {
+	conn := session.conn
 
-	return h, nil
-}
+	h.logger.Infow("WebSocket client connected", "session_id", session.ID, "player_id", session.PlayerID)
 
-func (h *WebSocketHandler) HandleConnection(conn *websocket.Conn) {
-	defer conn.Close()
+	pipeline := h.router.NewPipeline(context.Background(), session.Session, h.workers)
 
-	h.logger.Infow("WebSocket client connected")
+	// Close first so responses of requests still in flight are kept for a
+	// resumed session instead of being written to a dead connection
+	defer func() {
+		session.Close()
+		pipeline.Close()
+	}()
 
 	for {
 		// Read message
-		messageType, message, err := conn.ReadMessage()
+		frameType, frame, err := conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				h.logger.Errorw("WebSocket error", "error", err)
@@ -71,119 +62,23 @@ func (h *WebSocketHandler) HandleConnection(conn *websocket.Conn) {
 			return
 		}
 
-		h.logger.Debugw("Received WebSocket message", "message_type", messageType, "message", string(message))
+		session.ExtendReadDeadline()
+		h.logger.Debugw("Received WebSocket message", "frame_type", frameType, "size", len(frame))
 
-		// Handle message
-		response, err := h.handleMessage(message)
+		message, err := session.Codec().Decode(frameType, frame)
 		if err != nil {
-			h.logger.Errorw("Error handling message", "error", err)
-			h.sendError(conn, "Failed to process message")
+			var requestID uint64
+			var frameErr *FrameError
+			if errors.As(err, &frameErr) {
+				requestID = frameErr.RequestID
+			}
+			h.logger.Debugw("Failed to decode frame", "session_id", session.ID, "codec", session.Codec().Name(), "error", err)
+			_ = session.Send(gateway.ErrorResponse(errcode.New(errcode.InvalidArgument, err.Error()), requestID))
 			continue
 		}
 
-		// Send response
-		if err := conn.WriteMessage(websocket.BinaryMessage, response); err != nil {
-			h.logger.Errorw("Error sending response", "error", err)
+		if !pipeline.Submit(message) {
 			return
 		}
 	}
 }
-
-func (h *WebSocketHandler) handleMessage(data []byte) ([]byte, error) {
-	envelope := fbs.GetRootAsEnvelope(data, 0)
-	msgType := envelope.Type()
-	payload := envelope.PayloadBytes()
-
-	handler, ok := h.handlers[msgType]
-	if !ok {
-		h.logger.Errorw("Unknown message type", "type", msgType)
-		return h.buildErrorResp(400, "Unknown message type"), nil
-	}
-
-	if len(payload) == 0 {
-		h.logger.Errorw("Empty payload", "type", msgType)
-		return h.buildErrorResp(400, "Empty payload"), nil
-	}
-
-	return handler(context.Background(), payload)
-}
-
-func (h *WebSocketHandler) handleStartClawGame(
-	ctx context.Context,
-	payload []byte,
-) ([]byte, error) {
-	resp, err := h.wsClient.StartClawGameWs(ctx, &runtimepb.RuntimeRequest{
-		Payload: payload,
-	})
-	if err != nil {
-		h.logger.Errorw("StartClawGameWs failed", "error", err)
-		return h.buildErrorResp(500, err.Error()), nil
-	}
-
-	return resp.Payload, nil
-}
-
-func (h *WebSocketHandler) handleGetPlayerInfo(
-	ctx context.Context,
-	payload []byte,
-) ([]byte, error) {
-	resp, err := h.wsClient.GetPlayerSnapshotWs(ctx, &runtimepb.RuntimeRequest{
-		Payload: payload,
-	})
-	if err != nil {
-		h.logger.Errorw("GetPlayerSnapshotWs failed", "error", err)
-		return h.buildErrorResp(500, err.Error()), nil
-	}
-
-	return resp.Payload, nil
-}
-
-func (h *WebSocketHandler) handleAddTouchedItemRecord(
-	ctx context.Context,
-	payload []byte,
-) ([]byte, error) {
-	resp, err := h.wsClient.AddTouchedItemRecordWs(ctx, &runtimepb.RuntimeRequest{
-		Payload: payload,
-	})
-	if err != nil {
-		h.logger.Errorw("AddTouchedItemRecordWs failed", "error", err)
-		return h.buildErrorResp(500, err.Error()), nil
-	}
-
-	return resp.Payload, nil
-}
-
-func (h *WebSocketHandler) buildErrorResp(code int32, message string) []byte {
-	builder := flatbuffers.NewBuilder(128)
-
-	msgOffset := builder.CreateString(message)
-
-	fbs.ErrorRespStart(builder)
-	fbs.ErrorRespAddCode(builder, code)
-	fbs.ErrorRespAddMessage(builder, msgOffset)
-	errorResp := fbs.ErrorRespEnd(builder)
-
-	builder.Finish(errorResp)
-	errorBytes := builder.FinishedBytes()
-
-	// Wrap error response in Envelope
-	envBuilder := flatbuffers.NewBuilder(256)
-	payloadOffset := envBuilder.CreateByteVector(errorBytes)
-
-	fbs.EnvelopeStart(envBuilder)
-	fbs.EnvelopeAddType(envBuilder, fbs.MessageTypeErrorResp)
-	fbs.EnvelopeAddPayload(envBuilder, payloadOffset)
-	envOffset := fbs.EnvelopeEnd(envBuilder)
-	envBuilder.Finish(envOffset)
-
-	return envBuilder.FinishedBytes()
-}
-
-func (h *WebSocketHandler) sendError(conn *websocket.Conn, message string) {
-	response := h.buildErrorResp(500, message)
-
-	err := conn.WriteMessage(websocket.BinaryMessage, response)
-	if err != nil {
-		h.logger.Errorw("Failed to send error message", "error", err)
-	}
-}
@@ -0,0 +1,72 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/Richard-inter/game/internal/gateway"
+	"github.com/Richard-inter/game/pkg/errcode"
+)
+
+const maxHelloSize = 4096
+
+var errHandshakeRejected = errors.New("handshake rejected")
+
+// runHandshake reads the client's HelloReq and answers with WelcomeResp.
+// Clients that send anything else, or speak a protocol version outside the
+// supported range, get an ErrorResp and a close frame. It runs before the
+// session's writer starts, so it owns the connection.
+func runHandshake(conn *websocket.Conn, codec Codec, hs *gateway.Handshake, router *gateway.Router) (*gateway.ClientInfo, error) {
+	conn.SetReadLimit(maxHelloSize)
+	_ = conn.SetReadDeadline(time.Now().Add(hs.Timeout))
+
+	frameType, frame, err := conn.ReadMessage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hello: %w", err)
+	}
+
+	message, err := codec.Decode(frameType, frame)
+	if err != nil {
+		var requestID uint64
+		var frameErr *FrameError
+		if errors.As(err, &frameErr) {
+			requestID = frameErr.RequestID
+		}
+		rejectErr := errcode.New(errcode.InvalidArgument, err.Error())
+		return nil, reject(conn, codec, hs.Timeout, gateway.ErrorResponse(rejectErr, requestID), rejectErr)
+	}
+
+	hello, rejection, err := hs.Accept(message)
+	if err != nil {
+		return nil, reject(conn, codec, hs.Timeout, rejection, err)
+	}
+
+	if err := writeFrame(conn, codec, hs.Welcome(hello, router), hs.Timeout); err != nil {
+		return nil, fmt.Errorf("failed to send welcome: %w", err)
+	}
+
+	return &hello.Client, nil
+}
+
+// reject sends the ErrorResp and closes the connection
+func reject(conn *websocket.Conn, codec Codec, timeout time.Duration, response []byte, err error) error {
+	message := errcode.From(err).PublicMessage()
+	_ = writeFrame(conn, codec, response, timeout)
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseProtocolError, message), time.Now().Add(timeout))
+	_ = conn.Close()
+
+	return fmt.Errorf("%w: %s", errHandshakeRejected, message)
+}
+
+func writeFrame(conn *websocket.Conn, codec Codec, envelope []byte, timeout time.Duration) error {
+	frameType, frame, err := codec.Encode(envelope)
+	if err != nil {
+		return err
+	}
+
+	_ = conn.SetWriteDeadline(time.Now().Add(timeout))
+	return conn.WriteMessage(frameType, frame)
+}
@@ -0,0 +1,105 @@
+package websocket
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+
+	"github.com/Richard-inter/game/internal/cache"
+	"github.com/Richard-inter/game/internal/gateway"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// ResumeQueryParam carries the resume token of a previous connection
+const ResumeQueryParam = "resume"
+
+// resumeState is what survives a disconnect for the grace window. The state
+// lives in Redis so the client may reconnect to any gateway instance.
+type resumeState struct {
+	PlayerID int64    `json:"playerID"`
+	Rooms    []string `json:"rooms"`
+}
+
+// ResumeStore keeps detached sessions around so a client that reconnects
+// within the grace window gets its subscriptions and undelivered responses
+// back
+type ResumeStore struct {
+	redis     *cache.RedisClient
+	grace     time.Duration
+	maxFrames int
+}
+
+func NewResumeStore(redis *cache.RedisClient, grace time.Duration, maxFrames int) *ResumeStore {
+	return &ResumeStore{
+		redis:     redis,
+		grace:     grace,
+		maxFrames: maxFrames,
+	}
+}
+
+// Enabled reports whether sessions can be resumed at all
+func (r *ResumeStore) Enabled() bool {
+	return r != nil && r.grace > 0
+}
+
+// Detach stores the session's state under its resume token
+func (r *ResumeStore) Detach(ctx context.Context, session *Session, rooms []string) error {
+	if !r.Enabled() || session.ResumeToken == "" {
+		return nil
+	}
+
+	pending := session.Undelivered()
+	if len(pending) > r.maxFrames {
+		pending = pending[:r.maxFrames]
+	}
+
+	return r.redis.StoreResumeState(ctx, session.ResumeToken, resumeState{
+		PlayerID: session.PlayerID,
+		Rooms:    rooms,
+	}, pending, r.grace)
+}
+
+// Resume claims the state stored under token. A token only resumes sessions
+// of the player it was issued to.
+func (r *ResumeStore) Resume(ctx context.Context, token string, playerID int64) ([]string, [][]byte, bool, error) {
+	if !r.Enabled() || token == "" {
+		return nil, nil, false, nil
+	}
+
+	var state resumeState
+	pending, ok, err := r.redis.TakeResumeState(ctx, token, &state)
+	if err != nil || !ok {
+		return nil, nil, false, err
+	}
+	if state.PlayerID != playerID {
+		return nil, nil, false, nil
+	}
+
+	return state.Rooms, pending, true, nil
+}
+
+func newResumeToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate resume token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func buildSessionResumePush(token string, resumed bool, window time.Duration) []byte {
+	builder := flatbuffers.NewBuilder(128)
+	tokenOffset := builder.CreateString(token)
+
+	fbs.SessionResumePushStart(builder)
+	fbs.SessionResumePushAddResumeToken(builder, tokenOffset)
+	fbs.SessionResumePushAddResumed(builder, resumed)
+	fbs.SessionResumePushAddResumeWindowMs(builder, window.Milliseconds())
+	pushOffset := fbs.SessionResumePushEnd(builder)
+	builder.Finish(pushOffset)
+
+	return gateway.BuildEnvelope(fbs.EnvelopeKindPush, fbs.MessageTypeSessionResumePush, 0, builder.FinishedBytes())
+}
@@ -0,0 +1,108 @@
+package websocket
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/Richard-inter/game/internal/cache"
+	"github.com/Richard-inter/game/internal/gateway"
+)
+
+// detachedSession returns a closed session of playerID with frames left in
+// its send queue
+func detachedSession(playerID int64, token string, frames ...string) *Session {
+	session := &Session{
+		Session:     &gateway.Session{PlayerID: playerID},
+		ResumeToken: token,
+		opts:        SessionOptions{SendQueueSize: 8},
+		send:        make(chan []byte, 8),
+		done:        make(chan struct{}),
+	}
+	for _, frame := range frames {
+		session.send <- []byte(frame)
+	}
+	close(session.done)
+	return session
+}
+
+func TestResumeStore(t *testing.T) {
+	tests := []struct {
+		name        string
+		resumeAs    int64
+		token       string
+		wantResumed bool
+	}{
+		{"own session", 42, "token-a", true},
+		// a leaked token is no use to anyone else
+		{"another player", 43, "token-a", false},
+		{"unknown token", 42, "token-b", false},
+		{"no token", 42, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := miniredis.RunT(t)
+			store := NewResumeStore(cache.NewRedisClient(server.Addr(), ""), time.Minute, 2)
+			ctx := context.Background()
+
+			if err := store.Detach(ctx, detachedSession(42, "token-a", "1", "2", "3"), []string{"lobby"}); err != nil {
+				t.Fatalf("Detach() error = %v", err)
+			}
+
+			rooms, pending, resumed, err := store.Resume(ctx, tt.token, tt.resumeAs)
+			if err != nil {
+				t.Fatalf("Resume() error = %v", err)
+			}
+			if resumed != tt.wantResumed {
+				t.Fatalf("resumed = %v, want %v", resumed, tt.wantResumed)
+			}
+			if !resumed {
+				if rooms != nil || pending != nil {
+					t.Errorf("state handed out without resuming: %v %v", rooms, pending)
+				}
+				return
+			}
+
+			if strings.Join(rooms, ",") != "lobby" {
+				t.Errorf("rooms = %v, want [lobby]", rooms)
+			}
+			// only maxFrames undelivered frames are kept, oldest first
+			var frames []string
+			for _, frame := range pending {
+				frames = append(frames, string(frame))
+			}
+			if strings.Join(frames, ",") != "1,2" {
+				t.Errorf("pending = %v, want [1 2]", frames)
+			}
+
+			// a session resumes once
+			if _, _, again, _ := store.Resume(ctx, tt.token, tt.resumeAs); again {
+				t.Error("resumed the same token twice")
+			}
+		})
+	}
+}
+
+func TestResumeStoreExpires(t *testing.T) {
+	server := miniredis.RunT(t)
+	store := NewResumeStore(cache.NewRedisClient(server.Addr(), ""), time.Minute, 8)
+	ctx := context.Background()
+
+	if err := store.Detach(ctx, detachedSession(42, "token-a"), nil); err != nil {
+		t.Fatalf("Detach() error = %v", err)
+	}
+	server.FastForward(time.Minute + time.Second)
+
+	if _, _, resumed, err := store.Resume(ctx, "token-a", 42); err != nil || resumed {
+		t.Errorf("Resume() after the grace window = %v, %v, want false", resumed, err)
+	}
+
+	var disabled *ResumeStore
+	if disabled.Enabled() {
+		t.Error("nil store is enabled")
+	}
+}
@@ -4,49 +4,153 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/Richard-inter/game/internal/auth"
+	"github.com/Richard-inter/game/internal/cache"
 	"github.com/Richard-inter/game/internal/config"
+	"github.com/Richard-inter/game/internal/gateway"
 	"github.com/Richard-inter/game/internal/transport/grpc"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 )
 
 type Server struct {
-	config      *config.Config
+	config      *config.ServiceConfig
 	logger      *zap.SugaredLogger
 	server      *http.Server
 	grpcManager *grpc.ClientManager
+	auth        *auth.Authenticator
+	redis       *cache.RedisClient
 	upgrader    websocket.Upgrader
-	clients     map[*websocket.Conn]bool
+	sessionOpts SessionOptions
+	sessions    *gateway.Registry
+	resume      *ResumeStore
+	handshake   *gateway.Handshake
 }
 
-func NewServer(cfg *config.Config, logger *zap.SugaredLogger, grpcManager *grpc.ClientManager) *Server {
+func NewServer(
+	cfg *config.ServiceConfig,
+	logger *zap.SugaredLogger,
+	grpcManager *grpc.ClientManager,
+	authenticator *auth.Authenticator,
+	redis *cache.RedisClient,
+) *Server {
+	ws := cfg.WebSocket
+	sessionOpts := SessionOptions{
+		SendQueueSize:  ws.SendQueueSize,
+		OverflowPolicy: ws.OverflowPolicy,
+		PingInterval:   time.Duration(ws.PingInterval) * time.Second,
+		PongWait:       time.Duration(ws.PongWait) * time.Second,
+		WriteWait:      time.Duration(ws.WriteWait) * time.Second,
+		MaxMessageSize: ws.MaxMessageSize,
+	}.withDefaults()
+
+	resume := NewResumeStore(redis, time.Duration(ws.ResumeGrace)*time.Second, sessionOpts.SendQueueSize-1)
+
+	features := []string{gateway.FeaturePush}
+	if resume.Enabled() {
+		features = append(features, gateway.FeatureResume)
+	}
+
 	return &Server{
 		config:      cfg,
 		logger:      logger,
 		grpcManager: grpcManager,
+		auth:        authenticator,
+		redis:       redis,
 		upgrader: websocket.Upgrader{
 			ReadBufferSize:  cfg.WebSocket.ReadBufferSize,
 			WriteBufferSize: cfg.WebSocket.WriteBufferSize,
-			CheckOrigin: func(_ *http.Request) bool {
-				return true // Allow all origins for now
-			},
+			CheckOrigin:     checkOrigin(cfg.WebSocket),
+			Subprotocols:    Subprotocols,
 		},
-		clients: make(map[*websocket.Conn]bool),
+		sessionOpts: sessionOpts,
+		sessions:    gateway.NewRegistry(),
+		resume:      resume,
+		handshake:   gateway.NewHandshake(uint32(ws.MinProtocolVersion), time.Duration(ws.HandshakeTimeout)*time.Second, features),
+	}
+}
+
+// SetBuild sets the server build reported to clients in WelcomeResp
+func (s *Server) SetBuild(build string) {
+	s.handshake.Build = build
+}
+
+// checkOrigin allows same-origin requests and any origin listed in the config.
+// When origin checks are disabled every origin is accepted.
+func checkOrigin(cfg config.WebSocketConfig) func(r *http.Request) bool {
+	if !cfg.CheckOrigin {
+		return func(_ *http.Request) bool {
+			return true
+		}
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			// Non-browser clients do not send an Origin header
+			return true
+		}
+		if slices.Contains(cfg.AllowedOrigins, "*") || slices.Contains(cfg.AllowedOrigins, origin) {
+			return true
+		}
+
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		return strings.EqualFold(u.Host, r.Host)
 	}
 }
 
 func (s *Server) Start() error {
-	// Create WebSocket handler
-	wsHandler, err := NewWebSocketHandler(s.logger, s.grpcManager)
+	if err := s.handshake.Validate(); err != nil {
+		return err
+	}
+
+	limiter, err := gateway.NewRateLimiter(s.config.WebSocket.RateLimit, s.redis, s.logger)
+	if err != nil {
+		return fmt.Errorf("failed to create rate limiter: %w", err)
+	}
+
+	router, err := gateway.NewGameRouter(s.logger.With("transport", "websocket"), s.grpcManager, s.sessions, limiter)
+	if err != nil {
+		return fmt.Errorf("failed to create gateway router: %w", err)
+	}
+
+	capturer, err := gateway.NewCapturer(s.config.WebSocket.Capture, s.logger)
 	if err != nil {
-		return fmt.Errorf("failed to create WebSocket handler: %w", err)
+		return fmt.Errorf("failed to start session capture: %w", err)
 	}
+	s.sessions.SetCapturer(capturer)
+
+	// Create WebSocket handler
+	wsHandler := NewWebSocketHandler(s.logger, router, s.config.WebSocket.Workers)
 
 	// Create HTTP server for WebSocket
 	mux := http.NewServeMux()
 	mux.HandleFunc(s.config.WebSocket.Path, func(w http.ResponseWriter, r *http.Request) {
+		// Authenticate before upgrading so rejected clients get a plain HTTP error
+		playerID, err := s.auth.Authenticate(auth.TokenFromRequest(r))
+		if err != nil {
+			s.logger.Infow("Rejected unauthenticated WebSocket connection", "remote_addr", r.RemoteAddr, "error", err)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		codecName := r.URL.Query().Get(CodecQueryParam)
+		if codecName == "" {
+			codecName = CodecFlatBuffers
+		}
+		if _, ok := CodecByName(codecName); !ok {
+			http.Error(w, "unsupported codec", http.StatusBadRequest)
+			return
+		}
+
 		// Upgrade HTTP connection to WebSocket
 		conn, err := s.upgrader.Upgrade(w, r, nil)
 		if err != nil {
@@ -54,12 +158,24 @@ func (s *Server) Start() error {
 			return
 		}
 
-		// Handle connection using the handler
-		wsHandler.HandleConnection(conn)
+		// A negotiated subprotocol takes precedence over the query parameter
+		if conn.Subprotocol() != "" {
+			codecName = conn.Subprotocol()
+		}
+		codec, _ := CodecByName(codecName)
+
+		s.serveSession(wsHandler, conn, playerID, codec, r.URL.Query().Get(ResumeQueryParam))
+	})
+
+	// Add health check
+	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":"healthy","service":"websocket-service"}`)
 	})
 
 	s.server = &http.Server{
-		Addr:         fmt.Sprintf("%s:%d", s.config.WebSocket.Host, s.config.WebSocket.Port),
+		Addr:         s.config.GetWebSocketAddr(),
 		Handler:      mux,
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
@@ -82,21 +198,77 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Infow("Shutting down WebSocket server")
 
 	// Close all client connections
-	for client := range s.clients {
-		client.Close()
-		delete(s.clients, client)
+	for _, session := range s.sessions.All() {
+		session.Close()
 	}
 
 	return s.server.Shutdown(ctx)
 }
 
+// serveSession runs one connection from the handshake until it is detached.
+// A valid resume token from a previous connection restores its subscriptions
+// and replays the frames it never received.
+func (s *Server) serveSession(wsHandler *WebSocketHandler, conn *websocket.Conn, playerID int64, codec Codec, previousToken string) {
+	ctx := context.Background()
+
+	client, err := runHandshake(conn, codec, s.handshake, wsHandler.router)
+	if err != nil {
+		s.logger.Infow("WebSocket handshake failed", "player_id", playerID, "error", err)
+		_ = conn.Close()
+		return
+	}
+
+	var token string
+	if client.HasFeature(gateway.FeatureResume) {
+		var err error
+		token, err = newResumeToken()
+		if err != nil {
+			s.logger.Errorw("Failed to issue resume token", "error", err)
+		}
+	}
+
+	session := newSession(token, conn, codec, s.sessionOpts)
+	session.Session = s.sessions.Register(session, playerID, *client, "websocket")
+
+	if client.HasFeature(gateway.FeatureResume) {
+		rooms, pending, resumed, err := s.resume.Resume(ctx, previousToken, playerID)
+		if err != nil {
+			s.logger.Errorw("Failed to resume session", "player_id", playerID, "error", err)
+		}
+		for _, room := range rooms {
+			s.sessions.Join(session.Session, room)
+		}
+
+		_ = session.Send(buildSessionResumePush(token, resumed, s.resume.grace))
+		for _, frame := range pending {
+			_ = session.Send(frame)
+		}
+		if resumed {
+			s.logger.Infow("Session resumed", "session_id", session.ID, "player_id", playerID, "rooms", len(rooms), "pending", len(pending))
+		}
+	}
+
+	// Handle connection using the handler
+	wsHandler.HandleConnection(session)
+
+	rooms := s.sessions.Rooms(session.Session)
+	s.sessions.Unregister(session.Session)
+
+	if err := s.resume.Detach(ctx, session, rooms); err != nil {
+		s.logger.Errorw("Failed to store session for resume", "session_id", session.ID, "error", err)
+	}
+}
+
+// Sessions returns the registry of live connections
+func (s *Server) Sessions() *gateway.Registry {
+	return s.sessions
+}
+
 // Broadcast message to all connected clients
 func (s *Server) Broadcast(message []byte) {
-	for client := range s.clients {
-		if err := client.WriteMessage(websocket.TextMessage, message); err != nil {
-			s.logger.Errorw("Failed to broadcast message", "error", err)
-			client.Close()
-			delete(s.clients, client)
+	for _, session := range s.sessions.All() {
+		if err := session.Send(message); err != nil {
+			s.logger.Errorw("Failed to broadcast message", "session_id", session.ID, "error", err)
 		}
 	}
 }
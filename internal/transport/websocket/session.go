@@ -0,0 +1,227 @@
+package websocket
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/Richard-inter/game/internal/gateway"
+)
+
+const (
+	// OverflowDrop discards messages that do not fit in the send queue
+	OverflowDrop = "drop"
+	// OverflowDisconnect closes connections whose send queue is full
+	OverflowDisconnect = "disconnect"
+
+	defaultSendQueueSize  = 64
+	defaultWriteWait      = 10 * time.Second
+	defaultPongWait       = 60 * time.Second
+	defaultMaxMessageSize = 64 * 1024
+)
+
+var (
+	ErrSendQueueFull = errors.New("send queue full")
+	ErrSessionClosed = gateway.ErrSessionClosed
+)
+
+// SessionOptions controls the outbound queue and keepalive of every session
+type SessionOptions struct {
+	SendQueueSize  int
+	OverflowPolicy string
+	// PingInterval must be shorter than PongWait, the time a connection may
+	// stay silent before it is considered dead
+	PingInterval   time.Duration
+	PongWait       time.Duration
+	WriteWait      time.Duration
+	MaxMessageSize int64
+}
+
+func (o SessionOptions) withDefaults() SessionOptions {
+	if o.SendQueueSize <= 0 {
+		o.SendQueueSize = defaultSendQueueSize
+	}
+	if o.WriteWait <= 0 {
+		o.WriteWait = defaultWriteWait
+	}
+	if o.PongWait <= 0 {
+		o.PongWait = defaultPongWait
+	}
+	if o.PingInterval <= 0 || o.PingInterval >= o.PongWait {
+		o.PingInterval = o.PongWait * 9 / 10
+	}
+	if o.MaxMessageSize <= 0 {
+		o.MaxMessageSize = defaultMaxMessageSize
+	}
+	return o
+}
+
+// Session is one authenticated WebSocket connection and the transport side
+// of its gateway session. Gorilla connections allow a single concurrent
+// writer, so outgoing frames are queued by Send and written, together with
+// pings, by one writer goroutine per session. Frames are queued as
+// FlatBuffers Envelopes and encoded with the session's codec only when
+// written.
+type Session struct {
+	*gateway.Session
+	ResumeToken string
+
+	conn      *websocket.Conn
+	codec     Codec
+	opts      SessionOptions
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// frames that could not be delivered before the connection went away
+	pendingMu sync.Mutex
+	pending   [][]byte
+}
+
+// newSession starts the writer of a connection that completed its
+// handshake; the caller registers it to get its gateway session
+func newSession(resumeToken string, conn *websocket.Conn, codec Codec, opts SessionOptions) *Session {
+	session := &Session{
+		ResumeToken: resumeToken,
+		conn:        conn,
+		codec:       codec,
+		opts:        opts,
+		send:        make(chan []byte, opts.SendQueueSize),
+		done:        make(chan struct{}),
+	}
+
+	conn.SetReadLimit(opts.MaxMessageSize)
+	session.ExtendReadDeadline()
+	conn.SetPongHandler(func(string) error {
+		session.ExtendReadDeadline()
+		return nil
+	})
+
+	go session.writeLoop()
+
+	return session
+}
+
+// ExtendReadDeadline gives the client another PongWait to send a frame or pong
+func (s *Session) ExtendReadDeadline() {
+	_ = s.conn.SetReadDeadline(time.Now().Add(s.opts.PongWait))
+}
+
+// Codec returns the codec the client chose for this connection
+func (s *Session) Codec() Codec {
+	return s.codec
+}
+
+// Send queues one Envelope for the client without blocking. When the
+// queue is full the frame is dropped, and under the disconnect policy the
+// session is closed as well. Frames sent after the session closed are kept
+// so they can be handed over on resume.
+func (s *Session) Send(data []byte) error {
+	select {
+	case <-s.done:
+		s.keepPending(data)
+		return ErrSessionClosed
+	default:
+	}
+
+	select {
+	case s.send <- data:
+		return nil
+	case <-s.done:
+		s.keepPending(data)
+		return ErrSessionClosed
+	default:
+	}
+
+	if s.opts.OverflowPolicy != OverflowDrop {
+		s.Close()
+	}
+	return ErrSendQueueFull
+}
+
+// Done is closed once the session is closed
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close stops the writer and closes the underlying connection
+func (s *Session) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+		err = s.conn.Close()
+	})
+	return err
+}
+
+// CloseWithReason tells the client why the connection is going away before
+// closing the session
+func (s *Session) CloseWithReason(code int, reason string) error {
+	msg := websocket.FormatCloseMessage(code, reason)
+	_ = s.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(s.opts.WriteWait))
+	return s.Close()
+}
+
+// Disconnect closes the session as a policy violation
+func (s *Session) Disconnect(reason string) error {
+	return s.CloseWithReason(websocket.ClosePolicyViolation, reason)
+}
+
+// Undelivered returns the frames that never reached the client, both those
+// still queued at close time and those sent afterwards. Only meaningful once
+// the session is closed and nothing sends to it anymore.
+func (s *Session) Undelivered() [][]byte {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+
+	frames := make([][]byte, 0, len(s.send)+len(s.pending))
+	for {
+		select {
+		case data := <-s.send:
+			frames = append(frames, data)
+		default:
+			return append(frames, s.pending...)
+		}
+	}
+}
+
+func (s *Session) keepPending(data []byte) {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+
+	if len(s.pending) < s.opts.SendQueueSize {
+		s.pending = append(s.pending, data)
+	}
+}
+
+func (s *Session) writeLoop() {
+	ticker := time.NewTicker(s.opts.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case data := <-s.send:
+			frameType, frame, err := s.codec.Encode(data)
+			if err != nil {
+				// a frame the codec cannot represent is dropped
+				continue
+			}
+
+			_ = s.conn.SetWriteDeadline(time.Now().Add(s.opts.WriteWait))
+			if err := s.conn.WriteMessage(frameType, frame); err != nil {
+				s.keepPending(data)
+				s.Close()
+				return
+			}
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(s.opts.WriteWait)); err != nil {
+				s.Close()
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package websocket
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// queuedSession returns a session over a real connection whose writer is not
+// running, so frames stay in its send queue
+func queuedSession(t *testing.T, opts SessionOptions) *Session {
+	t.Helper()
+
+	conns := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("failed to upgrade: %v", err)
+			return
+		}
+		conns <- conn
+	}))
+	t.Cleanup(server.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	opts = opts.withDefaults()
+	session := &Session{
+		conn: <-conns,
+		opts: opts,
+		send: make(chan []byte, opts.SendQueueSize),
+		done: make(chan struct{}),
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func isClosed(s *Session) bool {
+	select {
+	case <-s.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestSessionOverflowPolicy(t *testing.T) {
+	tests := []struct {
+		policy     string
+		wantClosed bool
+	}{
+		{OverflowDisconnect, true},
+		// an unset policy disconnects, like the config default
+		{"", true},
+		{OverflowDrop, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			session := queuedSession(t, SessionOptions{SendQueueSize: 2, OverflowPolicy: tt.policy})
+
+			for _, frame := range []string{"1", "2"} {
+				if err := session.Send([]byte(frame)); err != nil {
+					t.Fatalf("Send(%s) error = %v, the queue has room", frame, err)
+				}
+			}
+			if err := session.Send([]byte("3")); !errors.Is(err, ErrSendQueueFull) {
+				t.Fatalf("Send() error = %v, want %v", err, ErrSendQueueFull)
+			}
+			if isClosed(session) != tt.wantClosed {
+				t.Fatalf("closed = %v, want %v", isClosed(session), tt.wantClosed)
+			}
+
+			if !tt.wantClosed {
+				// the dropped frame is gone, the queued ones are still there
+				if got := len(session.send); got != 2 {
+					t.Errorf("queued = %d, want 2", got)
+				}
+				return
+			}
+
+			// frames queued or sent after the close are kept for resume,
+			// up to the queue size
+			for _, frame := range []string{"4", "5", "6"} {
+				if err := session.Send([]byte(frame)); !errors.Is(err, ErrSessionClosed) {
+					t.Fatalf("Send() after close error = %v, want %v", err, ErrSessionClosed)
+				}
+			}
+			var undelivered []string
+			for _, frame := range session.Undelivered() {
+				undelivered = append(undelivered, string(frame))
+			}
+			if got := strings.Join(undelivered, ","); got != "1,2,4,5" {
+				t.Errorf("Undelivered() = %s, want 1,2,4,5", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package voucher
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Richard-inter/game/internal/domain"
+)
+
+const (
+	// MaxBulkCount caps how many codes a single bulk request may generate
+	MaxBulkCount = 10000
+
+	codeLength = 10
+	// codeAlphabet leaves out characters that are easy to confuse when typed
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// Validate checks that a voucher grants a usable reward and can be redeemed
+func Validate(v *domain.Voucher, now time.Time) error {
+	switch v.RewardType {
+	case domain.VoucherRewardCoin, domain.VoucherRewardDiamond, domain.VoucherRewardFreePlay:
+	case domain.VoucherRewardItem:
+		if v.RewardItemID <= 0 {
+			return fmt.Errorf("item reward requires an item ID")
+		}
+	default:
+		return fmt.Errorf("invalid reward type: %s", v.RewardType)
+	}
+
+	if v.RewardAmount <= 0 {
+		return fmt.Errorf("reward amount must be positive")
+	}
+	if v.MaxRedemptions <= 0 {
+		return fmt.Errorf("max redemptions must be positive")
+	}
+	if v.PerPlayerLimit <= 0 {
+		return fmt.Errorf("per player limit must be positive")
+	}
+	if !v.ExpiresAt.After(now) {
+		return fmt.Errorf("voucher must expire in the future")
+	}
+
+	return nil
+}
+
+// NormalizeCode makes redemption case and whitespace insensitive
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// GenerateCode returns a random code with the given prefix
+func GenerateCode(prefix string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(NormalizeCode(prefix))
+
+	max := big.NewInt(int64(len(codeAlphabet)))
+	for i := 0; i < codeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate voucher code: %w", err)
+		}
+		sb.WriteByte(codeAlphabet[n.Int64()])
+	}
+
+	return sb.String(), nil
+}
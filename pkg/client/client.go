@@ -0,0 +1,614 @@
+// Package client is a Go SDK for the game's real-time protocol. A Client
+// holds one authenticated WebSocket session with the gateway, correlates
+// responses with their requests by request ID, hands pushes to callbacks and
+// reconnects when the connection drops, resuming the session where the
+// gateway allows it.
+//
+// Claw games are played on the server since protocol version 2: a game is
+// started with StartClawGame, steered with ClawInput and settled by a Drop,
+// which replaces reporting the touched item.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+const (
+	// ProtocolVersion is the protocol version the client speaks
+	ProtocolVersion uint32 = 2
+
+	// features announced in HelloReq
+	featurePush   = "push"
+	featureResume = "resume"
+
+	// subprotocol selecting binary FlatBuffers frames
+	subprotocol      = "flatbuffers"
+	resumeQueryParam = "resume"
+
+	defaultDialTimeout       = 10 * time.Second
+	defaultRequestTimeout    = 10 * time.Second
+	defaultMinReconnectDelay = 250 * time.Millisecond
+	defaultMaxReconnectDelay = 30 * time.Second
+)
+
+// Options configures a Client
+type Options struct {
+	// URL of the gateway's WebSocket endpoint, such as ws://localhost:8081/ws
+	URL string
+	// Token is the player's JWT. TokenSource, when set, is asked for a token
+	// on every connect instead, so reconnects can use a fresh one.
+	Token       string
+	TokenSource func(ctx context.Context) (string, error)
+	// Build identifies the client build to the gateway
+	Build string
+
+	DialTimeout time.Duration
+	// RequestTimeout bounds requests whose context has no deadline
+	RequestTimeout time.Duration
+
+	// Reconnect re-dials after the connection drops, waiting between
+	// MinReconnectDelay and MaxReconnectDelay between attempts. Requests made
+	// while reconnecting wait for the new connection.
+	Reconnect         bool
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+	// DisableResume starts a fresh session on every reconnect
+	DisableResume bool
+
+	Logger *zap.SugaredLogger
+}
+
+func (o Options) withDefaults() Options {
+	if o.DialTimeout <= 0 {
+		o.DialTimeout = defaultDialTimeout
+	}
+	if o.RequestTimeout <= 0 {
+		o.RequestTimeout = defaultRequestTimeout
+	}
+	if o.MinReconnectDelay <= 0 {
+		o.MinReconnectDelay = defaultMinReconnectDelay
+	}
+	if o.MaxReconnectDelay < o.MinReconnectDelay {
+		o.MaxReconnectDelay = max(defaultMaxReconnectDelay, o.MinReconnectDelay)
+	}
+	if o.Logger == nil {
+		o.Logger = zap.NewNop().Sugar()
+	}
+	return o
+}
+
+// Client is a session with the real-time gateway. It is safe for concurrent
+// use; requests may be in flight concurrently.
+type Client struct {
+	opts   Options
+	logger *zap.SugaredLogger
+	nextID atomic.Uint64
+
+	writeMu sync.Mutex
+
+	mu     sync.Mutex
+	conn   *websocket.Conn // nil while disconnected
+	ready  chan struct{}   // closed while connected
+	done   chan struct{}   // closed by Close
+	closed bool
+
+	pending map[uint64]chan response
+	// orphaned are pending requests of a dropped connection, answered only
+	// if the next connection resumes the session
+	orphaned     map[uint64]struct{}
+	reconnecting bool
+
+	welcome      *fbs.WelcomeRespT
+	resumeToken  string
+	resumeWindow time.Duration
+	resumeUntil  time.Time
+	rooms        map[string]struct{}
+
+	handlersMu       sync.RWMutex
+	onBalanceChanged func(*fbs.BalanceChangedPushT)
+	onNotification   func(*fbs.NotificationPushT)
+	onReconnect      func(resumed bool)
+}
+
+type response struct {
+	envelope []byte
+	err      error
+}
+
+// Dial connects to the gateway and completes the handshake
+func Dial(ctx context.Context, opts Options) (*Client, error) {
+	if opts.URL == "" {
+		return nil, errors.New("client URL is required")
+	}
+	if opts.Token == "" && opts.TokenSource == nil {
+		return nil, errors.New("client token is required")
+	}
+
+	opts = opts.withDefaults()
+	c := &Client{
+		opts:     opts,
+		logger:   opts.Logger,
+		ready:    make(chan struct{}),
+		done:     make(chan struct{}),
+		pending:  make(map[uint64]chan response),
+		orphaned: make(map[uint64]struct{}),
+		rooms:    make(map[string]struct{}),
+	}
+
+	conn, welcome, err := c.dial(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.attach(conn, welcome)
+	c.mu.Unlock()
+
+	return c, nil
+}
+
+// Welcome returns the gateway's answer to the latest handshake
+func (c *Client) Welcome() *fbs.WelcomeRespT {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.welcome
+}
+
+// Close ends the session. Requests in flight fail with ErrClosed.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+	conn := c.conn
+	c.failPendingLocked(ErrClosed)
+	c.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+
+	c.writeMu.Lock()
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	c.writeMu.Unlock()
+	return conn.Close()
+}
+
+// dial opens a connection and runs the Hello/Welcome handshake on it
+func (c *Client) dial(ctx context.Context, resumeToken string) (*websocket.Conn, *fbs.WelcomeRespT, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.DialTimeout)
+	defer cancel()
+
+	token := c.opts.Token
+	if c.opts.TokenSource != nil {
+		var err error
+		token, err = c.opts.TokenSource(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get token: %w", err)
+		}
+	}
+
+	target, err := url.Parse(c.opts.URL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid client URL: %w", err)
+	}
+	if resumeToken != "" {
+		query := target.Query()
+		query.Set(resumeQueryParam, resumeToken)
+		target.RawQuery = query.Encode()
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	dialer := websocket.Dialer{
+		Proxy:        http.ProxyFromEnvironment,
+		Subprotocols: []string{subprotocol},
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, target.String(), header)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil {
+			return nil, nil, fmt.Errorf("failed to connect: %w (HTTP %d)", err, resp.StatusCode)
+		}
+		return nil, nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	welcome, err := c.handshake(ctx, conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	return conn, welcome, nil
+}
+
+func (c *Client) handshake(ctx context.Context, conn *websocket.Conn) (*fbs.WelcomeRespT, error) {
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetWriteDeadline(deadline)
+	_ = conn.SetReadDeadline(deadline)
+	defer func() {
+		_ = conn.SetWriteDeadline(time.Time{})
+		_ = conn.SetReadDeadline(time.Time{})
+	}()
+
+	features := []string{featurePush}
+	if !c.opts.DisableResume {
+		features = append(features, featureResume)
+	}
+	hello := &fbs.HelloReqT{
+		ProtocolVersion: ProtocolVersion,
+		ClientBuild:     c.opts.Build,
+		Features:        features,
+	}
+
+	message := buildEnvelope(fbs.MessageTypeHelloReq, c.nextID.Add(1), pack(hello))
+	if err := conn.WriteMessage(websocket.BinaryMessage, message); err != nil {
+		return nil, fmt.Errorf("failed to send hello: %w", err)
+	}
+
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read welcome: %w", err)
+	}
+	if len(data) < flatbuffers.SizeUOffsetT {
+		return nil, errors.New("invalid welcome frame")
+	}
+
+	env := fbs.GetRootAsEnvelope(data, 0)
+	switch env.Type() {
+	case fbs.MessageTypeWelcomeResp:
+		return fbs.GetRootAsWelcomeResp(env.PayloadBytes(), 0).UnPack(), nil
+	case fbs.MessageTypeErrorResp:
+		return nil, fmt.Errorf("handshake rejected: %w", newRequestError(env.PayloadBytes()))
+	default:
+		return nil, fmt.Errorf("handshake failed: %w", unexpectedResponse(fbs.MessageTypeWelcomeResp, env.Type()))
+	}
+}
+
+// attach makes conn the client's connection. It must be called with mu held.
+func (c *Client) attach(conn *websocket.Conn, welcome *fbs.WelcomeRespT) {
+	c.conn = conn
+	c.welcome = welcome
+	close(c.ready)
+
+	// without resume no SessionResumePush follows, so requests of the
+	// dropped connection will never be answered
+	if !c.resumeOffered() {
+		c.failOrphanedLocked()
+		if c.reconnecting {
+			c.reconnecting = false
+			go c.afterReconnect(false)
+		}
+	}
+
+	go c.readLoop(conn)
+}
+
+func (c *Client) resumeOffered() bool {
+	return !c.opts.DisableResume && c.welcome != nil && slices.Contains(c.welcome.Features, featureResume)
+}
+
+func (c *Client) readLoop(conn *websocket.Conn) {
+	for {
+		frameType, data, err := conn.ReadMessage()
+		if err != nil {
+			c.connectionLost(conn, err)
+			return
+		}
+		if frameType != websocket.BinaryMessage || len(data) < flatbuffers.SizeUOffsetT {
+			c.logger.Warnw("Ignoring invalid frame", "frame_type", frameType, "size", len(data))
+			continue
+		}
+
+		env := fbs.GetRootAsEnvelope(data, 0)
+		if env.Kind() == fbs.EnvelopeKindPush {
+			c.handlePush(env)
+			continue
+		}
+		if env.RequestId() == 0 {
+			// errors the gateway could not tie to a request, such as an
+			// undecodable frame
+			c.logger.Warnw("Unsolicited response", "type", env.Type())
+			continue
+		}
+
+		c.mu.Lock()
+		ch, ok := c.pending[env.RequestId()]
+		delete(c.pending, env.RequestId())
+		delete(c.orphaned, env.RequestId())
+		c.mu.Unlock()
+
+		if !ok {
+			c.logger.Debugw("Response to an abandoned request", "request_id", env.RequestId(), "type", env.Type())
+			continue
+		}
+		ch <- response{envelope: data}
+	}
+}
+
+// connectionLost detaches a dropped connection and starts reconnecting.
+// Requests in flight wait for a resumed session when one is possible.
+func (c *Client) connectionLost(conn *websocket.Conn, err error) {
+	c.mu.Lock()
+	if c.conn != conn {
+		c.mu.Unlock()
+		return
+	}
+	c.conn = nil
+	c.ready = make(chan struct{})
+
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+
+	c.logger.Warnw("Connection lost", "error", err)
+
+	if !c.opts.Reconnect {
+		c.failPendingLocked(ErrDisconnected)
+		c.mu.Unlock()
+		return
+	}
+
+	if c.resumeOffered() && c.resumeToken != "" {
+		c.resumeUntil = time.Now().Add(c.resumeWindow)
+		for id := range c.pending {
+			c.orphaned[id] = struct{}{}
+		}
+	} else {
+		c.failPendingLocked(ErrDisconnected)
+	}
+	c.reconnecting = true
+	c.mu.Unlock()
+
+	go c.reconnect()
+}
+
+// reconnect dials until it succeeds or the client is closed, backing off
+// exponentially with jitter between attempts
+func (c *Client) reconnect() {
+	delay := c.opts.MinReconnectDelay
+	for attempt := 1; ; attempt++ {
+		timer := time.NewTimer(delay/2 + rand.N(delay/2+1))
+		select {
+		case <-c.done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		c.mu.Lock()
+		resumeToken := ""
+		if c.resumeToken != "" && time.Now().Before(c.resumeUntil) {
+			resumeToken = c.resumeToken
+		} else {
+			c.failOrphanedLocked()
+		}
+		c.mu.Unlock()
+
+		conn, welcome, err := c.dial(context.Background(), resumeToken)
+		if err != nil {
+			c.logger.Warnw("Reconnect failed", "attempt", attempt, "error", err)
+			delay = min(delay*2, c.opts.MaxReconnectDelay)
+			continue
+		}
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			conn.Close()
+			return
+		}
+		c.attach(conn, welcome)
+		c.mu.Unlock()
+
+		c.logger.Infow("Reconnected", "attempt", attempt, "resuming", resumeToken != "")
+		return
+	}
+}
+
+// handleResume keeps the resume token of the new session and settles the
+// requests of the previous connection
+func (c *Client) handleResume(push *fbs.SessionResumePushT) {
+	c.mu.Lock()
+	c.resumeToken = push.ResumeToken
+	c.resumeWindow = time.Duration(push.ResumeWindowMs) * time.Millisecond
+	if push.Resumed {
+		// the gateway replays the responses it had not delivered
+		clear(c.orphaned)
+	} else {
+		c.failOrphanedLocked()
+	}
+	reconnected := c.reconnecting
+	c.reconnecting = false
+	c.mu.Unlock()
+
+	if reconnected {
+		go c.afterReconnect(push.Resumed)
+	}
+}
+
+// afterReconnect restores the subscriptions of a session that could not be
+// resumed, then runs the OnReconnect callback
+func (c *Client) afterReconnect(resumed bool) {
+	if !resumed {
+		c.mu.Lock()
+		rooms := make([]string, 0, len(c.rooms))
+		for room := range c.rooms {
+			rooms = append(rooms, room)
+		}
+		c.mu.Unlock()
+
+		for _, room := range rooms {
+			if err := c.Subscribe(context.Background(), room); err != nil {
+				c.logger.Warnw("Failed to restore subscription", "room", room, "error", err)
+			}
+		}
+	}
+
+	c.handlersMu.RLock()
+	onReconnect := c.onReconnect
+	c.handlersMu.RUnlock()
+	if onReconnect != nil {
+		onReconnect(resumed)
+	}
+}
+
+// failPendingLocked fails every request in flight. It must be called with
+// mu held.
+func (c *Client) failPendingLocked(err error) {
+	for id, ch := range c.pending {
+		ch <- response{err: err}
+		delete(c.pending, id)
+	}
+	clear(c.orphaned)
+}
+
+// failOrphanedLocked fails the requests of a dropped connection. It must be
+// called with mu held.
+func (c *Client) failOrphanedLocked() {
+	for id := range c.orphaned {
+		if ch, ok := c.pending[id]; ok {
+			ch <- response{err: ErrDisconnected}
+			delete(c.pending, id)
+		}
+	}
+	clear(c.orphaned)
+}
+
+// call sends a request and returns the payload of its response, which must
+// be of type want. ErrorResps are returned as *RequestError.
+func (c *Client) call(ctx context.Context, msgType, want fbs.MessageType, payload []byte) ([]byte, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.RequestTimeout)
+		defer cancel()
+	}
+
+	conn, err := c.connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id := c.nextID.Add(1)
+	ch := make(chan response, 1)
+
+	c.mu.Lock()
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	message := buildEnvelope(msgType, id, payload)
+
+	c.writeMu.Lock()
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetWriteDeadline(deadline)
+	err = conn.WriteMessage(websocket.BinaryMessage, message)
+	c.writeMu.Unlock()
+
+	if err != nil {
+		c.abandon(id)
+		// a failed write leaves the connection unusable; closing it lets
+		// the read loop reconnect
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to send %s: %w", msgType, err)
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.err != nil {
+			return nil, resp.err
+		}
+
+		env := fbs.GetRootAsEnvelope(resp.envelope, 0)
+		switch env.Type() {
+		case want:
+			return env.PayloadBytes(), nil
+		case fbs.MessageTypeErrorResp:
+			return nil, newRequestError(env.PayloadBytes())
+		default:
+			return nil, unexpectedResponse(want, env.Type())
+		}
+	case <-ctx.Done():
+		c.abandon(id)
+		return nil, ctx.Err()
+	}
+}
+
+// connection returns the current connection, waiting for a reconnect when
+// the client has one in progress
+func (c *Client) connection(ctx context.Context) (*websocket.Conn, error) {
+	for {
+		c.mu.Lock()
+		conn, ready, closed := c.conn, c.ready, c.closed
+		c.mu.Unlock()
+
+		switch {
+		case closed:
+			return nil, ErrClosed
+		case conn != nil:
+			return conn, nil
+		case !c.opts.Reconnect:
+			return nil, ErrDisconnected
+		}
+
+		select {
+		case <-ready:
+		case <-c.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (c *Client) abandon(id uint64) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	delete(c.orphaned, id)
+	c.mu.Unlock()
+}
+
+// packer is implemented by the generated object API types
+type packer interface {
+	Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT
+}
+
+func pack(table packer) []byte {
+	builder := flatbuffers.NewBuilder(128)
+	builder.Finish(table.Pack(builder))
+	return builder.FinishedBytes()
+}
+
+func buildEnvelope(msgType fbs.MessageType, requestID uint64, payload []byte) []byte {
+	builder := flatbuffers.NewBuilder(len(payload) + 64)
+	payloadOffset := builder.CreateByteVector(payload)
+
+	fbs.EnvelopeStart(builder)
+	fbs.EnvelopeAddType(builder, msgType)
+	fbs.EnvelopeAddPayload(builder, payloadOffset)
+	fbs.EnvelopeAddRequestId(builder, requestID)
+	fbs.EnvelopeAddKind(builder, fbs.EnvelopeKindRequest)
+	builder.Finish(fbs.EnvelopeEnd(builder))
+
+	return builder.FinishedBytes()
+}
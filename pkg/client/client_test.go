@@ -0,0 +1,356 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/gorilla/websocket"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// fakeGateway answers the handshake of every connection with welcome, then
+// hands the connection to serve. connects counts the connections accepted.
+type fakeGateway struct {
+	t       *testing.T
+	server  *httptest.Server
+	welcome *fbs.WelcomeRespT
+	serve   func(conn *websocket.Conn, connection int, r *http.Request)
+
+	mu       sync.Mutex
+	connects int
+	headers  []http.Header
+}
+
+func newFakeGateway(t *testing.T, serve func(conn *websocket.Conn, connection int, r *http.Request)) *fakeGateway {
+	t.Helper()
+
+	g := &fakeGateway{
+		t:       t,
+		welcome: &fbs.WelcomeRespT{ProtocolVersion: ProtocolVersion, Features: []string{featurePush, featureResume}},
+		serve:   serve,
+	}
+	upgrader := websocket.Upgrader{Subprotocols: []string{subprotocol}}
+	g.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		g.mu.Lock()
+		g.connects++
+		connection := g.connects
+		g.headers = append(g.headers, r.Header.Clone())
+		g.mu.Unlock()
+
+		hello, ok := readFrame(conn)
+		if !ok || hello.Type() != fbs.MessageTypeHelloReq {
+			return
+		}
+		if g.welcome == nil {
+			writeFrame(conn, fbs.EnvelopeKindResponse, fbs.MessageTypeErrorResp, hello.RequestId(), &fbs.ErrorRespT{
+				Code:      http.StatusUpgradeRequired,
+				Message:   "protocol version not supported",
+				ErrorCode: string(errcode.UnsupportedVersion),
+			})
+			return
+		}
+		writeFrame(conn, fbs.EnvelopeKindResponse, fbs.MessageTypeWelcomeResp, hello.RequestId(), g.welcome)
+
+		if g.serve != nil {
+			g.serve(conn, connection, r)
+		}
+	}))
+	t.Cleanup(g.server.Close)
+	return g
+}
+
+func (g *fakeGateway) url() string {
+	return "ws" + strings.TrimPrefix(g.server.URL, "http")
+}
+
+func (g *fakeGateway) dial(opts Options) *Client {
+	g.t.Helper()
+
+	opts.URL = g.url()
+	if opts.Token == "" {
+		opts.Token = "token"
+	}
+	c, err := Dial(context.Background(), opts)
+	if err != nil {
+		g.t.Fatalf("Dial: %v", err)
+	}
+	g.t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func readFrame(conn *websocket.Conn) (*fbs.Envelope, bool) {
+	_, data, err := conn.ReadMessage()
+	if err != nil || len(data) < flatbuffers.SizeUOffsetT {
+		return nil, false
+	}
+	return fbs.GetRootAsEnvelope(data, 0), true
+}
+
+func writeFrame(conn *websocket.Conn, kind fbs.EnvelopeKind, msgType fbs.MessageType, requestID uint64, table packer) {
+	payload := pack(table)
+
+	builder := flatbuffers.NewBuilder(len(payload) + 64)
+	payloadOffset := builder.CreateByteVector(payload)
+	fbs.EnvelopeStart(builder)
+	fbs.EnvelopeAddType(builder, msgType)
+	fbs.EnvelopeAddPayload(builder, payloadOffset)
+	fbs.EnvelopeAddRequestId(builder, requestID)
+	fbs.EnvelopeAddKind(builder, kind)
+	builder.Finish(fbs.EnvelopeEnd(builder))
+
+	_ = conn.WriteMessage(websocket.BinaryMessage, builder.FinishedBytes())
+}
+
+func TestDial(t *testing.T) {
+	g := newFakeGateway(t, nil)
+	g.welcome.ServerBuild = "test"
+
+	c := g.dial(Options{Token: "secret"})
+	g.mu.Lock()
+	header := g.headers[0]
+	g.mu.Unlock()
+
+	if welcome := c.Welcome(); welcome == nil || welcome.ServerBuild != "test" {
+		t.Errorf("Welcome() = %+v, want the gateway's welcome", welcome)
+	}
+	if got := header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+	}
+	if got := header.Get("Sec-WebSocket-Protocol"); got != subprotocol {
+		t.Errorf("Sec-WebSocket-Protocol = %q, want %q", got, subprotocol)
+	}
+}
+
+func TestDialRequiresURLAndToken(t *testing.T) {
+	if _, err := Dial(context.Background(), Options{Token: "token"}); err == nil {
+		t.Error("Dial without a URL succeeded")
+	}
+	if _, err := Dial(context.Background(), Options{URL: "ws://localhost"}); err == nil {
+		t.Error("Dial without a token succeeded")
+	}
+}
+
+func TestDialRejected(t *testing.T) {
+	g := newFakeGateway(t, nil)
+	g.welcome = nil
+
+	_, err := Dial(context.Background(), Options{URL: g.url(), Token: "token"})
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("Dial() error = %v, want a *RequestError", err)
+	}
+	if code := errcode.CodeOf(err); code != errcode.UnsupportedVersion {
+		t.Errorf("CodeOf() = %s, want %s", code, errcode.UnsupportedVersion)
+	}
+}
+
+func TestCallCorrelatesResponses(t *testing.T) {
+	// answers two requests in reverse order
+	g := newFakeGateway(t, func(conn *websocket.Conn, _ int, _ *http.Request) {
+		var requests []*fbs.Envelope
+		for len(requests) < 2 {
+			env, ok := readFrame(conn)
+			if !ok {
+				return
+			}
+			requests = append(requests, env)
+		}
+		for i := len(requests) - 1; i >= 0; i-- {
+			req := fbs.GetRootAsStartClawGameReq(requests[i].PayloadBytes(), 0)
+			writeFrame(conn, fbs.EnvelopeKindResponse, fbs.MessageTypeStartClawGameResp, requests[i].RequestId(),
+				&fbs.StartClawGameRespT{GameId: req.MachineId() * 10})
+		}
+		_, _, _ = conn.ReadMessage()
+	})
+	c := g.dial(Options{})
+
+	var wg sync.WaitGroup
+	for _, machineID := range []uint64{1, 2} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.StartClawGame(context.Background(), machineID)
+			if err != nil {
+				t.Errorf("StartClawGame(%d): %v", machineID, err)
+				return
+			}
+			if resp.GameId != machineID*10 {
+				t.Errorf("StartClawGame(%d) game = %d, want %d", machineID, resp.GameId, machineID*10)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCallErrorResp(t *testing.T) {
+	tests := []struct {
+		name           string
+		resp           *fbs.ErrorRespT
+		wantCode       errcode.Code
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:     "catalogue code",
+			resp:     &fbs.ErrorRespT{Code: http.StatusPaymentRequired, Message: "not enough coins", ErrorCode: string(errcode.InsufficientFunds)},
+			wantCode: errcode.InsufficientFunds,
+		},
+		{
+			name:           "HTTP status only",
+			resp:           &fbs.ErrorRespT{Code: http.StatusTooManyRequests, Message: "slow down", RetryAfterMs: 1500},
+			wantCode:       errcode.RateLimited,
+			wantRetryAfter: 1500 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newFakeGateway(t, func(conn *websocket.Conn, _ int, _ *http.Request) {
+				env, ok := readFrame(conn)
+				if !ok {
+					return
+				}
+				writeFrame(conn, fbs.EnvelopeKindResponse, fbs.MessageTypeErrorResp, env.RequestId(), tt.resp)
+				_, _, _ = conn.ReadMessage()
+			})
+			c := g.dial(Options{})
+
+			_, err := c.StartClawGame(context.Background(), 1)
+			var reqErr *RequestError
+			if !errors.As(err, &reqErr) {
+				t.Fatalf("StartClawGame() error = %v, want a *RequestError", err)
+			}
+			if code := errcode.CodeOf(err); code != tt.wantCode {
+				t.Errorf("CodeOf() = %s, want %s", code, tt.wantCode)
+			}
+			if reqErr.Status != int(tt.resp.Code) {
+				t.Errorf("Status = %d, want %d", reqErr.Status, tt.resp.Code)
+			}
+			if reqErr.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("RetryAfter = %v, want %v", reqErr.RetryAfter, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestPushCallbacks(t *testing.T) {
+	pushed := make(chan struct{})
+	g := newFakeGateway(t, func(conn *websocket.Conn, _ int, _ *http.Request) {
+		<-pushed
+		writeFrame(conn, fbs.EnvelopeKindPush, fbs.MessageTypeBalanceChangedPush, 0,
+			&fbs.BalanceChangedPushT{PlayerId: 7, Currency: "coin", Delta: -10, Balance: 90})
+		_, _, _ = conn.ReadMessage()
+	})
+	c := g.dial(Options{})
+
+	got := make(chan *fbs.BalanceChangedPushT, 1)
+	c.OnBalanceChanged(func(push *fbs.BalanceChangedPushT) { got <- push })
+	close(pushed)
+
+	select {
+	case push := <-got:
+		if push.PlayerId != 7 || push.Delta != -10 || push.Balance != 90 {
+			t.Errorf("push = %+v, want player 7 charged 10 down to 90", push)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("BalanceChangedPush not delivered")
+	}
+}
+
+func TestDisconnectFailsPending(t *testing.T) {
+	// drops the connection without answering
+	g := newFakeGateway(t, func(conn *websocket.Conn, _ int, _ *http.Request) {
+		readFrame(conn)
+	})
+	c := g.dial(Options{})
+
+	if _, err := c.StartClawGame(context.Background(), 1); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("StartClawGame() error = %v, want ErrDisconnected", err)
+	}
+	if _, err := c.StartClawGame(context.Background(), 1); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("StartClawGame() after the drop error = %v, want ErrDisconnected", err)
+	}
+
+	c.Close()
+	if _, err := c.StartClawGame(context.Background(), 1); !errors.Is(err, ErrClosed) {
+		t.Errorf("StartClawGame() after Close error = %v, want ErrClosed", err)
+	}
+}
+
+func TestReconnectResumes(t *testing.T) {
+	requestID := make(chan uint64, 1)
+	resumeToken := make(chan string, 1)
+
+	// the first connection offers resume and drops with a request pending;
+	// the second resumes the session and delivers the response
+	g := newFakeGateway(t, func(conn *websocket.Conn, connection int, r *http.Request) {
+		switch connection {
+		case 1:
+			writeFrame(conn, fbs.EnvelopeKindPush, fbs.MessageTypeSessionResumePush, 0,
+				&fbs.SessionResumePushT{ResumeToken: "resume-1", ResumeWindowMs: 60_000})
+			env, ok := readFrame(conn)
+			if !ok {
+				return
+			}
+			requestID <- env.RequestId()
+		case 2:
+			resumeToken <- r.URL.Query().Get(resumeQueryParam)
+			writeFrame(conn, fbs.EnvelopeKindPush, fbs.MessageTypeSessionResumePush, 0,
+				&fbs.SessionResumePushT{ResumeToken: "resume-2", Resumed: true, ResumeWindowMs: 60_000})
+			writeFrame(conn, fbs.EnvelopeKindResponse, fbs.MessageTypeStartClawGameResp, <-requestID,
+				&fbs.StartClawGameRespT{GameId: 42})
+			_, _, _ = conn.ReadMessage()
+		}
+	})
+
+	// the resume token must arrive before the connection drops
+	c := g.dial(Options{Reconnect: true, MinReconnectDelay: 10 * time.Millisecond, MaxReconnectDelay: 10 * time.Millisecond})
+	reconnected := make(chan bool, 1)
+	c.OnReconnect(func(resumed bool) { reconnected <- resumed })
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.Lock()
+		token := c.resumeToken
+		c.mu.Unlock()
+		if token != "" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("SessionResumePush not handled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	resp, err := c.StartClawGame(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("StartClawGame: %v", err)
+	}
+	if resp.GameId != 42 {
+		t.Errorf("game = %d, want 42", resp.GameId)
+	}
+	if token := <-resumeToken; token != "resume-1" {
+		t.Errorf("reconnect resume token = %q, want %q", token, "resume-1")
+	}
+
+	select {
+	case resumed := <-reconnected:
+		if !resumed {
+			t.Error("OnReconnect(false), want the session resumed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnReconnect not called")
+	}
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Richard-inter/game/pkg/errcode"
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+var (
+	// ErrClosed is returned by calls on a client that was closed
+	ErrClosed = errors.New("client closed")
+	// ErrDisconnected is returned for requests whose connection dropped
+	// before they were answered, when the session could not be resumed.
+	// Whether the server handled them is unknown.
+	ErrDisconnected = errors.New("connection lost before the response")
+)
+
+// RequestError is an ErrorResp from the gateway. It unwraps to the
+// catalogue error, so errcode.CodeOf reports its code.
+type RequestError struct {
+	Err *errcode.Error
+	// Status is the HTTP status the gateway reported with the error
+	Status int
+	// RetryAfter is set with RATE_LIMITED errors, when the request may be
+	// retried
+	RetryAfter time.Duration
+}
+
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+func newRequestError(payload []byte) *RequestError {
+	resp := fbs.GetRootAsErrorResp(payload, 0)
+
+	code := errcode.Code(resp.ErrorCode())
+	if code == "" {
+		code = errcode.FromHTTPStatus(int(resp.Code()))
+	}
+
+	return &RequestError{
+		Err:        errcode.New(code, string(resp.Message())),
+		Status:     int(resp.Code()),
+		RetryAfter: time.Duration(resp.RetryAfterMs()) * time.Millisecond,
+	}
+}
+
+// unexpectedResponse reports a response of the wrong type for its request
+func unexpectedResponse(want, got fbs.MessageType) error {
+	return fmt.Errorf("expected %s, got %s", want, got)
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// StartClawGame pays for a game on the machine and returns its board and
+// the claw's starting position. The game is played with ClawInput.
+func (c *Client) StartClawGame(ctx context.Context, machineID uint64) (*fbs.StartClawGameRespT, error) {
+	payload, err := c.call(ctx, fbs.MessageTypeStartClawGameReq, fbs.MessageTypeStartClawGameResp,
+		pack(&fbs.StartClawGameReqT{MachineId: machineID}))
+	if err != nil {
+		return nil, err
+	}
+	return fbs.GetRootAsStartClawGameResp(payload, 0).UnPack(), nil
+}
+
+// ClawInput applies one input to a running game and returns the claw's
+// state after it. Inputs of one client are applied in the order they are
+// sent.
+func (c *Client) ClawInput(ctx context.Context, gameID uint64, action fbs.ClawAction, dx, dy int32) (*fbs.ClawStateRespT, error) {
+	payload, err := c.call(ctx, fbs.MessageTypeClawInputReq, fbs.MessageTypeClawStateResp,
+		pack(&fbs.ClawInputReqT{GameId: gameID, Action: action, Dx: dx, Dy: dy}))
+	if err != nil {
+		return nil, err
+	}
+	return fbs.GetRootAsClawStateResp(payload, 0).UnPack(), nil
+}
+
+// MoveClaw shifts the claw by dx, dy board units
+func (c *Client) MoveClaw(ctx context.Context, gameID uint64, dx, dy int32) (*fbs.ClawStateRespT, error) {
+	return c.ClawInput(ctx, gameID, fbs.ClawActionMove, dx, dy)
+}
+
+// DropClaw lowers the claw where it stands and settles the game; the
+// returned state reports the item caught, if any
+func (c *Client) DropClaw(ctx context.Context, gameID uint64) (*fbs.ClawStateRespT, error) {
+	return c.ClawInput(ctx, gameID, fbs.ClawActionDrop, 0, 0)
+}
+
+// GetPlayerInfo returns the authenticated player's profile and balances
+func (c *Client) GetPlayerInfo(ctx context.Context) (*fbs.GetPlayerInfoWsRespT, error) {
+	payload, err := c.call(ctx, fbs.MessageTypeGetPlayerInfoWsReq, fbs.MessageTypeGetPlayerInfoWsResp,
+		pack(&fbs.GetPlayerInfoWsReqT{}))
+	if err != nil {
+		return nil, err
+	}
+	return fbs.GetRootAsGetPlayerInfoWsResp(payload, 0).UnPack(), nil
+}
+
+// RedeemVoucher redeems a voucher code for the authenticated player
+func (c *Client) RedeemVoucher(ctx context.Context, code string) (*fbs.RedeemVoucherRespT, error) {
+	payload, err := c.call(ctx, fbs.MessageTypeRedeemVoucherReq, fbs.MessageTypeRedeemVoucherResp,
+		pack(&fbs.RedeemVoucherReqT{Code: code}))
+	if err != nil {
+		return nil, err
+	}
+	return fbs.GetRootAsRedeemVoucherResp(payload, 0).UnPack(), nil
+}
+
+// GetMachineInfo returns one machine with its items, or every machine when
+// machineID is 0
+func (c *Client) GetMachineInfo(ctx context.Context, machineID uint64) (*fbs.GetMachineInfoWsRespT, error) {
+	payload, err := c.call(ctx, fbs.MessageTypeGetMachineInfoWsReq, fbs.MessageTypeGetMachineInfoWsResp,
+		pack(&fbs.GetMachineInfoWsReqT{MachineId: machineID}))
+	if err != nil {
+		return nil, err
+	}
+	return fbs.GetRootAsGetMachineInfoWsResp(payload, 0).UnPack(), nil
+}
+
+// Subscribe joins a room to receive its pushes. Subscriptions are restored
+// after a reconnect.
+func (c *Client) Subscribe(ctx context.Context, room string) error {
+	if _, err := c.call(ctx, fbs.MessageTypeSubscribeReq, fbs.MessageTypeSubscribeResp,
+		pack(&fbs.SubscribeReqT{Room: room})); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.rooms[room] = struct{}{}
+	c.mu.Unlock()
+	return nil
+}
+
+// Unsubscribe leaves a room
+func (c *Client) Unsubscribe(ctx context.Context, room string) error {
+	if _, err := c.call(ctx, fbs.MessageTypeUnsubscribeReq, fbs.MessageTypeSubscribeResp,
+		pack(&fbs.UnsubscribeReqT{Room: room})); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	delete(c.rooms, room)
+	c.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	fbs "github.com/Richard-inter/game/pkg/protocol/clawMachine_Websocket/clawMachine"
+)
+
+// Push callbacks run on the connection's read goroutine, in the order the
+// pushes arrive. They must not block, and must not wait for responses.
+
+// OnBalanceChanged registers fn for BalanceChangedPush, replacing any
+// earlier callback
+func (c *Client) OnBalanceChanged(fn func(*fbs.BalanceChangedPushT)) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.onBalanceChanged = fn
+}
+
+// OnNotification registers fn for NotificationPush, replacing any earlier
+// callback
+func (c *Client) OnNotification(fn func(*fbs.NotificationPushT)) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.onNotification = fn
+}
+
+// OnReconnect registers fn to run after every reconnect. resumed reports
+// whether the server restored the previous session, with its subscriptions
+// and the responses it had not delivered.
+func (c *Client) OnReconnect(fn func(resumed bool)) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.onReconnect = fn
+}
+
+// handlePush delivers a push Envelope to its callback
+func (c *Client) handlePush(env *fbs.Envelope) {
+	c.handlersMu.RLock()
+	onBalanceChanged, onNotification := c.onBalanceChanged, c.onNotification
+	c.handlersMu.RUnlock()
+
+	switch env.Type() {
+	case fbs.MessageTypeSessionResumePush:
+		c.handleResume(fbs.GetRootAsSessionResumePush(env.PayloadBytes(), 0).UnPack())
+	case fbs.MessageTypeBalanceChangedPush:
+		if onBalanceChanged != nil {
+			onBalanceChanged(fbs.GetRootAsBalanceChangedPush(env.PayloadBytes(), 0).UnPack())
+		}
+	case fbs.MessageTypeNotificationPush:
+		if onNotification != nil {
+			onNotification(fbs.GetRootAsNotificationPush(env.PayloadBytes(), 0).UnPack())
+		}
+	default:
+		c.logger.Debugw("Ignoring unknown push", "type", env.Type())
+	}
+}
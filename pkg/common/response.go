@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/Richard-inter/game/pkg/errcode"
 )
 
 // Response represents a standard API response
@@ -11,15 +13,43 @@ type Response struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"` // errcode catalogue code of Error
 	Data    interface{} `json:"data,omitempty"`
 }
 
-// SendSuccess sends a successful response
-func SendSuccess(c *gin.Context, data interface{}) {
-	c.JSON(http.StatusOK, Response{
+// Success is the response carrying data
+func Success(data interface{}) Response {
+	return Response{
 		Success: true,
 		Data:    data,
-	})
+	}
+}
+
+// Created is the response carrying a newly created resource
+func Created(data interface{}) Response {
+	return Response{
+		Success: true,
+		Data:    data,
+		Message: "Created successfully",
+	}
+}
+
+// ErrorFrom is the status and response for err, typically returned by a
+// gRPC call. Catalogue errors keep their code, status and message; anything
+// else is reported as an internal error without its details.
+func ErrorFrom(err error) (int, Response) {
+	e := errcode.FromGRPC(err)
+	return e.Code.HTTPStatus(), Response{
+		Success: false,
+		Error:   e.PublicMessage(),
+		Code:    string(e.Code),
+		Data:    nil,
+	}
+}
+
+// SendSuccess sends a successful response
+func SendSuccess(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusOK, Success(data))
 }
 
 // SendSuccessWithMessage sends a successful response with a message
@@ -33,18 +63,20 @@ func SendSuccessWithMessage(c *gin.Context, data interface{}, message string) {
 
 // SendCreated sends a created response
 func SendCreated(c *gin.Context, data interface{}) {
-	c.JSON(http.StatusCreated, Response{
-		Success: true,
-		Data:    data,
-		Message: "Created successfully",
-	})
+	c.JSON(http.StatusCreated, Created(data))
 }
 
-// SendError sends an error response
+// SendError sends an error response with the generic code for statusCode
 func SendError(c *gin.Context, statusCode int, message string) {
 	c.JSON(statusCode, Response{
 		Success: false,
 		Error:   message,
+		Code:    string(errcode.FromHTTPStatus(statusCode)),
 		Data:    nil,
 	})
 }
+
+// SendErrorFrom sends the error response for err, see ErrorFrom
+func SendErrorFrom(c *gin.Context, err error) {
+	c.JSON(ErrorFrom(err))
+}
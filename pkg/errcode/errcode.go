@@ -0,0 +1,170 @@
+// Package errcode is the catalogue of errors the game services report to
+// clients. Every error carries a stable Code clients can branch on and a
+// message safe to show to players; the underlying cause stays in the logs.
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Code identifies an error independently of the transport it travels over
+type Code string
+
+const (
+	Internal           Code = "INTERNAL"
+	InvalidArgument    Code = "INVALID_ARGUMENT"
+	Unauthenticated    Code = "UNAUTHENTICATED"
+	PermissionDenied   Code = "PERMISSION_DENIED"
+	NotFound           Code = "NOT_FOUND"
+	AlreadyExists      Code = "ALREADY_EXISTS"
+	InsufficientFunds  Code = "INSUFFICIENT_FUNDS"
+	GameExpired        Code = "GAME_EXPIRED"
+	VoucherExpired     Code = "VOUCHER_EXPIRED"
+	VoucherExhausted   Code = "VOUCHER_EXHAUSTED"
+	VoucherRedeemed    Code = "VOUCHER_ALREADY_REDEEMED"
+	RateLimited        Code = "RATE_LIMITED"
+	UnsupportedVersion Code = "UNSUPPORTED_VERSION"
+	Unavailable        Code = "UNAVAILABLE"
+	Deprecated         Code = "DEPRECATED"
+)
+
+type codeInfo struct {
+	grpc   codes.Code
+	http   int
+	public string // message used when an error carries none
+}
+
+var catalogue = map[Code]codeInfo{
+	Internal:           {codes.Internal, http.StatusInternalServerError, "internal error"},
+	InvalidArgument:    {codes.InvalidArgument, http.StatusBadRequest, "invalid argument"},
+	Unauthenticated:    {codes.Unauthenticated, http.StatusUnauthorized, "unauthenticated"},
+	PermissionDenied:   {codes.PermissionDenied, http.StatusForbidden, "permission denied"},
+	NotFound:           {codes.NotFound, http.StatusNotFound, "not found"},
+	AlreadyExists:      {codes.AlreadyExists, http.StatusConflict, "already exists"},
+	InsufficientFunds:  {codes.FailedPrecondition, http.StatusPaymentRequired, "insufficient funds"},
+	GameExpired:        {codes.FailedPrecondition, http.StatusGone, "game expired"},
+	VoucherExpired:     {codes.FailedPrecondition, http.StatusGone, "voucher expired"},
+	VoucherExhausted:   {codes.ResourceExhausted, http.StatusConflict, "voucher fully redeemed"},
+	VoucherRedeemed:    {codes.AlreadyExists, http.StatusConflict, "voucher already redeemed"},
+	RateLimited:        {codes.ResourceExhausted, http.StatusTooManyRequests, "rate limit exceeded"},
+	UnsupportedVersion: {codes.FailedPrecondition, http.StatusUpgradeRequired, "unsupported protocol version"},
+	Unavailable:        {codes.Unavailable, http.StatusServiceUnavailable, "service unavailable"},
+	Deprecated:         {codes.Unimplemented, http.StatusGone, "no longer supported"},
+}
+
+// Codes returns every code in the catalogue, sorted
+func Codes() []Code {
+	codes := make([]Code, 0, len(catalogue))
+	for code := range catalogue {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+func (c Code) info() codeInfo {
+	if info, ok := catalogue[c]; ok {
+		return info
+	}
+	return catalogue[Internal]
+}
+
+// HTTPStatus is the HTTP status, also used as ErrorResp.code, for the code
+func (c Code) HTTPStatus() int {
+	return c.info().http
+}
+
+// GRPCCode is the gRPC status code for the code
+func (c Code) GRPCCode() codes.Code {
+	return c.info().grpc
+}
+
+// Error is an error from the catalogue
+type Error struct {
+	Code    Code
+	Message string
+	cause   error
+}
+
+// New returns an error with a message for clients
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Newf is New with a formatted message
+func Newf(code Code, format string, args ...any) *Error {
+	return New(code, fmt.Sprintf(format, args...))
+}
+
+// Wrap returns an error with a message for clients that keeps err as its
+// cause for logs and errors.Is
+func Wrap(code Code, err error, message string) *Error {
+	return &Error{Code: code, Message: message, cause: err}
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.PublicMessage(), e.cause)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.PublicMessage())
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// PublicMessage is the message shown to clients
+func (e *Error) PublicMessage() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.Code.info().public
+}
+
+// From finds the catalogue error in err's chain. Errors outside the
+// catalogue become Internal so their text never reaches clients.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return Wrap(Internal, err, "")
+}
+
+// CodeOf returns the code of err, Internal for errors outside the catalogue
+func CodeOf(err error) Code {
+	return From(err).Code
+}
+
+// FromHTTPStatus picks the generic code for an HTTP status
+func FromHTTPStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return InvalidArgument
+	case http.StatusUnauthorized:
+		return Unauthenticated
+	case http.StatusForbidden:
+		return PermissionDenied
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return AlreadyExists
+	case http.StatusTooManyRequests:
+		return RateLimited
+	case http.StatusUpgradeRequired:
+		return UnsupportedVersion
+	case http.StatusServiceUnavailable:
+		return Unavailable
+	default:
+		return Internal
+	}
+}
@@ -0,0 +1,172 @@
+package errcode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mapping is what clients see for each code. Changing a row changes the
+// public API of every transport.
+var mapping = []struct {
+	code Code
+	grpc codes.Code
+	http int
+}{
+	{Internal, codes.Internal, http.StatusInternalServerError},
+	{InvalidArgument, codes.InvalidArgument, http.StatusBadRequest},
+	{Unauthenticated, codes.Unauthenticated, http.StatusUnauthorized},
+	{PermissionDenied, codes.PermissionDenied, http.StatusForbidden},
+	{NotFound, codes.NotFound, http.StatusNotFound},
+	{AlreadyExists, codes.AlreadyExists, http.StatusConflict},
+	{InsufficientFunds, codes.FailedPrecondition, http.StatusPaymentRequired},
+	{GameExpired, codes.FailedPrecondition, http.StatusGone},
+	{VoucherExpired, codes.FailedPrecondition, http.StatusGone},
+	{VoucherExhausted, codes.ResourceExhausted, http.StatusConflict},
+	{VoucherRedeemed, codes.AlreadyExists, http.StatusConflict},
+	{RateLimited, codes.ResourceExhausted, http.StatusTooManyRequests},
+	{UnsupportedVersion, codes.FailedPrecondition, http.StatusUpgradeRequired},
+	{Unavailable, codes.Unavailable, http.StatusServiceUnavailable},
+	{Deprecated, codes.Unimplemented, http.StatusGone},
+}
+
+func TestMapping(t *testing.T) {
+	if len(mapping) != len(Codes()) {
+		t.Fatalf("mapping covers %d codes, the catalogue has %d", len(mapping), len(Codes()))
+	}
+
+	for _, tt := range mapping {
+		t.Run(string(tt.code), func(t *testing.T) {
+			if got := tt.code.GRPCCode(); got != tt.grpc {
+				t.Errorf("GRPCCode() = %s, want %s", got, tt.grpc)
+			}
+			if got := tt.code.HTTPStatus(); got != tt.http {
+				t.Errorf("HTTPStatus() = %d, want %d", got, tt.http)
+			}
+			if New(tt.code, "").PublicMessage() == "" {
+				t.Error("no public message")
+			}
+
+			// the code and message survive a trip over gRPC
+			err := New(tt.code, "details for players").GRPCStatus().Err()
+			if got := status.Code(err); got != tt.grpc {
+				t.Errorf("status code = %s, want %s", got, tt.grpc)
+			}
+			back := FromGRPC(fmt.Errorf("call failed: %w", err))
+			if back.Code != tt.code || back.PublicMessage() != "details for players" {
+				t.Errorf("FromGRPC() = %s %q, want %s", back.Code, back.PublicMessage(), tt.code)
+			}
+		})
+	}
+
+	if got := Code("NOT_IN_CATALOGUE").HTTPStatus(); got != http.StatusInternalServerError {
+		t.Errorf("unknown code HTTPStatus() = %d, want 500", got)
+	}
+}
+
+func TestFromGRPCWithoutDetails(t *testing.T) {
+	tests := []struct {
+		grpc        codes.Code
+		want        Code
+		keepMessage bool
+	}{
+		{codes.InvalidArgument, InvalidArgument, true},
+		{codes.OutOfRange, InvalidArgument, true},
+		{codes.Unauthenticated, Unauthenticated, true},
+		{codes.PermissionDenied, PermissionDenied, true},
+		{codes.NotFound, NotFound, true},
+		{codes.AlreadyExists, AlreadyExists, true},
+		{codes.ResourceExhausted, RateLimited, true},
+		// transport failures do not tell players where the call went
+		{codes.Unavailable, Unavailable, false},
+		{codes.DeadlineExceeded, Unavailable, false},
+		{codes.Internal, Internal, false},
+		{codes.Unknown, Internal, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.grpc.String(), func(t *testing.T) {
+			e := FromGRPC(status.Error(tt.grpc, "dial tcp 10.0.0.7:9091"))
+			if e.Code != tt.want {
+				t.Errorf("code = %s, want %s", e.Code, tt.want)
+			}
+			if leaked := strings.Contains(e.PublicMessage(), "10.0.0.7"); leaked != tt.keepMessage {
+				t.Errorf("public message = %q", e.PublicMessage())
+			}
+		})
+	}
+}
+
+func TestFromHidesUncataloguedErrors(t *testing.T) {
+	cause := errors.New("Error 1062: Duplicate entry")
+
+	e := From(fmt.Errorf("failed to save: %w", cause))
+	if e.Code != Internal || e.PublicMessage() != "internal error" {
+		t.Errorf("From() = %s %q", e.Code, e.PublicMessage())
+	}
+	if !errors.Is(e, cause) {
+		t.Error("cause lost")
+	}
+	if CodeOf(fmt.Errorf("wrapped: %w", New(NotFound, "player not found"))) != NotFound {
+		t.Error("CodeOf() lost a wrapped catalogue code")
+	}
+}
+
+func TestFromHTTPStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   Code
+	}{
+		{http.StatusBadRequest, InvalidArgument},
+		{http.StatusUnauthorized, Unauthenticated},
+		{http.StatusForbidden, PermissionDenied},
+		{http.StatusNotFound, NotFound},
+		{http.StatusConflict, AlreadyExists},
+		{http.StatusTooManyRequests, RateLimited},
+		{http.StatusUpgradeRequired, UnsupportedVersion},
+		{http.StatusServiceUnavailable, Unavailable},
+		{http.StatusTeapot, Internal},
+	}
+
+	for _, tt := range tests {
+		if got := FromHTTPStatus(tt.status); got != tt.want {
+			t.Errorf("FromHTTPStatus(%d) = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{"catalogue error", New(NotFound, "player not found"), codes.NotFound, "player not found"},
+		{"wrapped catalogue error", fmt.Errorf("lookup: %w", New(InsufficientFunds, "")), codes.FailedPrecondition, "insufficient funds"},
+		{"plain error", errors.New("Error 1146: Table 'game.player' doesn't exist"), codes.Internal, "internal error"},
+		// statuses from other interceptors pass through untouched
+		{"status", status.Error(codes.Unauthenticated, "missing player identity"), codes.Unauthenticated, "missing player identity"},
+	}
+
+	interceptor := UnaryServerInterceptor(zap.NewNop().Sugar())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, func(context.Context, any) (any, error) {
+				return nil, tt.err
+			})
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMessage {
+				t.Errorf("status = %s %q, want %s %q", st.Code(), st.Message(), tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package errcode
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the ErrorInfo domain of catalogue errors sent over gRPC
+const ErrorDomain = "game"
+
+// GRPCStatus converts the error into a gRPC status carrying the code as
+// ErrorInfo details
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Code.GRPCCode(), e.PublicMessage())
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: string(e.Code),
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// FromGRPC recovers the catalogue error from an error returned by a gRPC
+// call. Statuses without details are mapped from their gRPC code.
+func FromGRPC(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	// read the status itself, since status.FromError puts the text of any
+	// wrapping errors into the message
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return Wrap(Internal, err, "")
+	}
+	st := grpcErr.GRPCStatus()
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == ErrorDomain {
+			return Wrap(Code(info.Reason), err, st.Message())
+		}
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return Wrap(InvalidArgument, err, st.Message())
+	case codes.Unauthenticated:
+		return Wrap(Unauthenticated, err, st.Message())
+	case codes.PermissionDenied:
+		return Wrap(PermissionDenied, err, st.Message())
+	case codes.NotFound:
+		return Wrap(NotFound, err, st.Message())
+	case codes.AlreadyExists:
+		return Wrap(AlreadyExists, err, st.Message())
+	case codes.ResourceExhausted:
+		return Wrap(RateLimited, err, st.Message())
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return Wrap(Unavailable, err, "")
+	default:
+		return Wrap(Internal, err, "")
+	}
+}
+
+// UnaryServerInterceptor turns errors returned by handlers into gRPC statuses.
+// Catalogue errors keep their code and message; anything else is logged and
+// reported as Internal so database and cache errors do not leak to clients.
+func UnaryServerInterceptor(logger *zap.SugaredLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		var e *Error
+		if !errors.As(err, &e) {
+			if _, ok := status.FromError(err); ok {
+				// already a status, such as those from the auth interceptor
+				return resp, err
+			}
+			e = Wrap(Internal, err, "")
+		}
+
+		if e.Code == Internal {
+			logger.Errorw("Request failed", "method", info.FullMethod, "error", err)
+		} else {
+			logger.Debugw("Request rejected", "method", info.FullMethod, "code", e.Code, "error", err)
+		}
+		return resp, e.GRPCStatus().Err()
+	}
+}
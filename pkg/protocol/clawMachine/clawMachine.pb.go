@@ -1,13 +1,14 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.10
-// 	protoc        v6.33.0
+// 	protoc        (unknown)
 // source: clawMachine/clawMachine.proto
 
 package clawMachine
 
 import (
 	player "github.com/Richard-inter/game/pkg/protocol/player"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -113,6 +114,12 @@ type ClawMachine struct {
 	Items         []*Item                `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
 	Price         int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
 	MaxItem       int32                  `protobuf:"varint,5,opt,name=maxItem,proto3" json:"maxItem,omitempty"`
+	CurrentPrice  int64                  `protobuf:"varint,6,opt,name=currentPrice,proto3" json:"currentPrice,omitempty"` // price after the best active promotion
+	PromotionID   int64                  `protobuf:"varint,7,opt,name=promotionID,proto3" json:"promotionID,omitempty"`   // 0 when no promotion applies
+	Currency      string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`          // coin or diamond, what a game is paid in
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`              // active, maintenance or retired
+	Tags          []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	PlayCount     int64                  `protobuf:"varint,11,opt,name=playCount,proto3" json:"playCount,omitempty"` // games started on the machine
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -182,6 +189,48 @@ func (x *ClawMachine) GetMaxItem() int32 {
 	return 0
 }
 
+func (x *ClawMachine) GetCurrentPrice() int64 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *ClawMachine) GetPromotionID() int64 {
+	if x != nil {
+		return x.PromotionID
+	}
+	return 0
+}
+
+func (x *ClawMachine) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ClawMachine) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ClawMachine) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ClawMachine) GetPlayCount() int64 {
+	if x != nil {
+		return x.PlayCount
+	}
+	return 0
+}
+
 type ClawPlayer struct {
 	state      protoimpl.MessageState `protogen:"open.v1"`
 	BasePlayer *player.Player         `protobuf:"bytes,1,opt,name=basePlayer,proto3" json:"basePlayer,omitempty"`
@@ -293,6 +342,9 @@ type CreateClawMachineReq struct {
 	Items         []*Items               `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
 	Price         int64                  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
 	MaxItem       int32                  `protobuf:"varint,4,opt,name=maxItem,proto3" json:"maxItem,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"` // defaults to coin
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`     // defaults to active
+	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -355,6 +407,27 @@ func (x *CreateClawMachineReq) GetMaxItem() int32 {
 	return 0
 }
 
+func (x *CreateClawMachineReq) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateClawMachineReq) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateClawMachineReq) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type CreateClawMachineResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Machine       *ClawMachine           `protobuf:"bytes,1,opt,name=machine,proto3" json:"machine,omitempty"`
@@ -507,6 +580,9 @@ type StartClawGameResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GameID        int64                  `protobuf:"varint,1,opt,name=gameID,proto3" json:"gameID,omitempty"`
 	Results       []*ClawResult          `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	FreePlay      bool                   `protobuf:"varint,3,opt,name=freePlay,proto3" json:"freePlay,omitempty"`
+	PricePaid     int64                  `protobuf:"varint,4,opt,name=pricePaid,proto3" json:"pricePaid,omitempty"`
+	PromotionID   int64                  `protobuf:"varint,5,opt,name=promotionID,proto3" json:"promotionID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -555,6 +631,27 @@ func (x *StartClawGameResp) GetResults() []*ClawResult {
 	return nil
 }
 
+func (x *StartClawGameResp) GetFreePlay() bool {
+	if x != nil {
+		return x.FreePlay
+	}
+	return false
+}
+
+func (x *StartClawGameResp) GetPricePaid() int64 {
+	if x != nil {
+		return x.PricePaid
+	}
+	return 0
+}
+
+func (x *StartClawGameResp) GetPromotionID() int64 {
+	if x != nil {
+		return x.PromotionID
+	}
+	return 0
+}
+
 type GetClawPlayerInfoReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerID      int64                  `protobuf:"varint,1,opt,name=playerID,proto3" json:"playerID,omitempty"`
@@ -731,6 +828,177 @@ func (x *GetClawMachineInfoResp) GetMachine() []*ClawMachine {
 	return nil
 }
 
+// ListClawMachinesReq selects one page of the machine lobby. Zero filters
+// match every machine; pass the same filters and sort with pageToken to get
+// the following pages.
+type ListClawMachinesReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`  // 1 to 100, defaults to 20
+	PageToken     string                 `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // nextPageToken of the previous page
+	MinPrice      int64                  `protobuf:"varint,3,opt,name=minPrice,proto3" json:"minPrice,omitempty"`  // base price, inclusive
+	MaxPrice      int64                  `protobuf:"varint,4,opt,name=maxPrice,proto3" json:"maxPrice,omitempty"`  // base price, inclusive
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Rarity        string                 `protobuf:"bytes,6,opt,name=rarity,proto3" json:"rarity,omitempty"` // machines holding at least one item of this rarity
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Tag           string                 `protobuf:"bytes,8,opt,name=tag,proto3" json:"tag,omitempty"`
+	SortBy        string                 `protobuf:"bytes,9,opt,name=sortBy,proto3" json:"sortBy,omitempty"`         // newest (default), price_asc, price_desc or popularity
+	OmitItems     bool                   `protobuf:"varint,10,opt,name=omitItems,proto3" json:"omitItems,omitempty"` // leave out the items of each machine
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClawMachinesReq) Reset() {
+	*x = ListClawMachinesReq{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClawMachinesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClawMachinesReq) ProtoMessage() {}
+
+func (x *ListClawMachinesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClawMachinesReq.ProtoReflect.Descriptor instead.
+func (*ListClawMachinesReq) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListClawMachinesReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListClawMachinesReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListClawMachinesReq) GetMinPrice() int64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListClawMachinesReq) GetMaxPrice() int64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListClawMachinesReq) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListClawMachinesReq) GetRarity() string {
+	if x != nil {
+		return x.Rarity
+	}
+	return ""
+}
+
+func (x *ListClawMachinesReq) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListClawMachinesReq) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListClawMachinesReq) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListClawMachinesReq) GetOmitItems() bool {
+	if x != nil {
+		return x.OmitItems
+	}
+	return false
+}
+
+type ListClawMachinesResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Machines      []*ClawMachine         `protobuf:"bytes,1,rep,name=machines,proto3" json:"machines,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClawMachinesResp) Reset() {
+	*x = ListClawMachinesResp{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClawMachinesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClawMachinesResp) ProtoMessage() {}
+
+func (x *ListClawMachinesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClawMachinesResp.ProtoReflect.Descriptor instead.
+func (*ListClawMachinesResp) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListClawMachinesResp) GetMachines() []*ClawMachine {
+	if x != nil {
+		return x.Machines
+	}
+	return nil
+}
+
+func (x *ListClawMachinesResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type CreateItemReq struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -744,7 +1012,7 @@ type CreateItemReq struct {
 
 func (x *CreateItemReq) Reset() {
 	*x = CreateItemReq{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[13]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -756,7 +1024,7 @@ func (x *CreateItemReq) String() string {
 func (*CreateItemReq) ProtoMessage() {}
 
 func (x *CreateItemReq) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[13]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -769,7 +1037,7 @@ func (x *CreateItemReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateItemReq.ProtoReflect.Descriptor instead.
 func (*CreateItemReq) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{13}
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CreateItemReq) GetName() string {
@@ -816,7 +1084,7 @@ type CreateClawItemsReq struct {
 
 func (x *CreateClawItemsReq) Reset() {
 	*x = CreateClawItemsReq{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[14]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -828,7 +1096,7 @@ func (x *CreateClawItemsReq) String() string {
 func (*CreateClawItemsReq) ProtoMessage() {}
 
 func (x *CreateClawItemsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[14]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -841,7 +1109,7 @@ func (x *CreateClawItemsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateClawItemsReq.ProtoReflect.Descriptor instead.
 func (*CreateClawItemsReq) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{14}
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CreateClawItemsReq) GetClawItems() []*CreateItemReq {
@@ -860,7 +1128,7 @@ type CreateClawItemsResp struct {
 
 func (x *CreateClawItemsResp) Reset() {
 	*x = CreateClawItemsResp{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[15]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -872,7 +1140,7 @@ func (x *CreateClawItemsResp) String() string {
 func (*CreateClawItemsResp) ProtoMessage() {}
 
 func (x *CreateClawItemsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[15]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -885,7 +1153,7 @@ func (x *CreateClawItemsResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateClawItemsResp.ProtoReflect.Descriptor instead.
 func (*CreateClawItemsResp) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{15}
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *CreateClawItemsResp) GetClawItems() []*Item {
@@ -904,7 +1172,7 @@ type CreateClawPlayerReq struct {
 
 func (x *CreateClawPlayerReq) Reset() {
 	*x = CreateClawPlayerReq{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[16]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -916,7 +1184,7 @@ func (x *CreateClawPlayerReq) String() string {
 func (*CreateClawPlayerReq) ProtoMessage() {}
 
 func (x *CreateClawPlayerReq) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[16]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -929,7 +1197,7 @@ func (x *CreateClawPlayerReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateClawPlayerReq.ProtoReflect.Descriptor instead.
 func (*CreateClawPlayerReq) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{16}
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *CreateClawPlayerReq) GetPlayer() *ClawPlayer {
@@ -948,7 +1216,7 @@ type CreateClawPlayerResp struct {
 
 func (x *CreateClawPlayerResp) Reset() {
 	*x = CreateClawPlayerResp{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[17]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -960,7 +1228,7 @@ func (x *CreateClawPlayerResp) String() string {
 func (*CreateClawPlayerResp) ProtoMessage() {}
 
 func (x *CreateClawPlayerResp) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[17]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -973,7 +1241,7 @@ func (x *CreateClawPlayerResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateClawPlayerResp.ProtoReflect.Descriptor instead.
 func (*CreateClawPlayerResp) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{17}
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *CreateClawPlayerResp) GetPlayer() *ClawPlayer {
@@ -994,7 +1262,7 @@ type AdjustPlayerCoinReq struct {
 
 func (x *AdjustPlayerCoinReq) Reset() {
 	*x = AdjustPlayerCoinReq{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[18]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1006,7 +1274,7 @@ func (x *AdjustPlayerCoinReq) String() string {
 func (*AdjustPlayerCoinReq) ProtoMessage() {}
 
 func (x *AdjustPlayerCoinReq) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[18]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1019,7 +1287,7 @@ func (x *AdjustPlayerCoinReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdjustPlayerCoinReq.ProtoReflect.Descriptor instead.
 func (*AdjustPlayerCoinReq) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{18}
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *AdjustPlayerCoinReq) GetPlayerID() int64 {
@@ -1053,7 +1321,7 @@ type AdjustPlayerCoinResp struct {
 
 func (x *AdjustPlayerCoinResp) Reset() {
 	*x = AdjustPlayerCoinResp{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[19]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1065,7 +1333,7 @@ func (x *AdjustPlayerCoinResp) String() string {
 func (*AdjustPlayerCoinResp) ProtoMessage() {}
 
 func (x *AdjustPlayerCoinResp) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[19]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1078,7 +1346,7 @@ func (x *AdjustPlayerCoinResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdjustPlayerCoinResp.ProtoReflect.Descriptor instead.
 func (*AdjustPlayerCoinResp) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{19}
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *AdjustPlayerCoinResp) GetPlayerID() int64 {
@@ -1106,7 +1374,7 @@ type AdjustPlayerDiamondReq struct {
 
 func (x *AdjustPlayerDiamondReq) Reset() {
 	*x = AdjustPlayerDiamondReq{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[20]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1118,7 +1386,7 @@ func (x *AdjustPlayerDiamondReq) String() string {
 func (*AdjustPlayerDiamondReq) ProtoMessage() {}
 
 func (x *AdjustPlayerDiamondReq) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[20]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1131,7 +1399,7 @@ func (x *AdjustPlayerDiamondReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdjustPlayerDiamondReq.ProtoReflect.Descriptor instead.
 func (*AdjustPlayerDiamondReq) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{20}
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *AdjustPlayerDiamondReq) GetPlayerID() int64 {
@@ -1165,7 +1433,7 @@ type AdjustPlayerDiamondResp struct {
 
 func (x *AdjustPlayerDiamondResp) Reset() {
 	*x = AdjustPlayerDiamondResp{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[21]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1177,7 +1445,7 @@ func (x *AdjustPlayerDiamondResp) String() string {
 func (*AdjustPlayerDiamondResp) ProtoMessage() {}
 
 func (x *AdjustPlayerDiamondResp) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[21]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1190,7 +1458,7 @@ func (x *AdjustPlayerDiamondResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdjustPlayerDiamondResp.ProtoReflect.Descriptor instead.
 func (*AdjustPlayerDiamondResp) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{21}
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *AdjustPlayerDiamondResp) GetPlayerID() int64 {
@@ -1207,30 +1475,28 @@ func (x *AdjustPlayerDiamondResp) GetAdjustedAmount() int64 {
 	return 0
 }
 
-type AddTouchedItemRecordReq struct {
+type GetEntitlementsReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GameID        int64                  `protobuf:"varint,1,opt,name=gameID,proto3" json:"gameID,omitempty"`
-	ItemID        int64                  `protobuf:"varint,2,opt,name=itemID,proto3" json:"itemID,omitempty"`
-	Catched       *bool                  `protobuf:"varint,3,opt,name=catched,proto3,oneof" json:"catched,omitempty"`
+	PlayerID      int64                  `protobuf:"varint,1,opt,name=playerID,proto3" json:"playerID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddTouchedItemRecordReq) Reset() {
-	*x = AddTouchedItemRecordReq{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[22]
+func (x *GetEntitlementsReq) Reset() {
+	*x = GetEntitlementsReq{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddTouchedItemRecordReq) String() string {
+func (x *GetEntitlementsReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddTouchedItemRecordReq) ProtoMessage() {}
+func (*GetEntitlementsReq) ProtoMessage() {}
 
-func (x *AddTouchedItemRecordReq) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[22]
+func (x *GetEntitlementsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1241,27 +1507,813 @@ func (x *AddTouchedItemRecordReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddTouchedItemRecordReq.ProtoReflect.Descriptor instead.
-func (*AddTouchedItemRecordReq) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use GetEntitlementsReq.ProtoReflect.Descriptor instead.
+func (*GetEntitlementsReq) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *AddTouchedItemRecordReq) GetGameID() int64 {
+func (x *GetEntitlementsReq) GetPlayerID() int64 {
 	if x != nil {
-		return x.GameID
+		return x.PlayerID
 	}
 	return 0
 }
 
-func (x *AddTouchedItemRecordReq) GetItemID() int64 {
-	if x != nil {
-		return x.ItemID
-	}
-	return 0
+type GetEntitlementsResp struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID           int64                  `protobuf:"varint,1,opt,name=playerID,proto3" json:"playerID,omitempty"`
+	DailyFreePlays     int32                  `protobuf:"varint,2,opt,name=dailyFreePlays,proto3" json:"dailyFreePlays,omitempty"`
+	FreePlaysUsed      int32                  `protobuf:"varint,3,opt,name=freePlaysUsed,proto3" json:"freePlaysUsed,omitempty"`
+	FreePlaysRemaining int32                  `protobuf:"varint,4,opt,name=freePlaysRemaining,proto3" json:"freePlaysRemaining,omitempty"`
+	NextResetAt        int64                  `protobuf:"varint,5,opt,name=nextResetAt,proto3" json:"nextResetAt,omitempty"`       // unix seconds
+	BonusFreePlays     int64                  `protobuf:"varint,6,opt,name=bonusFreePlays,proto3" json:"bonusFreePlays,omitempty"` // granted by vouchers, never reset
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *AddTouchedItemRecordReq) GetCatched() bool {
-	if x != nil && x.Catched != nil {
+func (x *GetEntitlementsResp) Reset() {
+	*x = GetEntitlementsResp{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntitlementsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntitlementsResp) ProtoMessage() {}
+
+func (x *GetEntitlementsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntitlementsResp.ProtoReflect.Descriptor instead.
+func (*GetEntitlementsResp) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetEntitlementsResp) GetPlayerID() int64 {
+	if x != nil {
+		return x.PlayerID
+	}
+	return 0
+}
+
+func (x *GetEntitlementsResp) GetDailyFreePlays() int32 {
+	if x != nil {
+		return x.DailyFreePlays
+	}
+	return 0
+}
+
+func (x *GetEntitlementsResp) GetFreePlaysUsed() int32 {
+	if x != nil {
+		return x.FreePlaysUsed
+	}
+	return 0
+}
+
+func (x *GetEntitlementsResp) GetFreePlaysRemaining() int32 {
+	if x != nil {
+		return x.FreePlaysRemaining
+	}
+	return 0
+}
+
+func (x *GetEntitlementsResp) GetNextResetAt() int64 {
+	if x != nil {
+		return x.NextResetAt
+	}
+	return 0
+}
+
+func (x *GetEntitlementsResp) GetBonusFreePlays() int64 {
+	if x != nil {
+		return x.BonusFreePlays
+	}
+	return 0
+}
+
+type Promotion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromotionID   int64                  `protobuf:"varint,1,opt,name=promotionID,proto3" json:"promotionID,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MachineIDs    []int64                `protobuf:"varint,3,rep,packed,name=machineIDs,proto3" json:"machineIDs,omitempty"` // empty targets every machine
+	DiscountType  string                 `protobuf:"bytes,4,opt,name=discountType,proto3" json:"discountType,omitempty"`     // percentage or fixed
+	DiscountValue int64                  `protobuf:"varint,5,opt,name=discountValue,proto3" json:"discountValue,omitempty"`
+	StartsAt      int64                  `protobuf:"varint,6,opt,name=startsAt,proto3" json:"startsAt,omitempty"` // unix seconds
+	EndsAt        int64                  `protobuf:"varint,7,opt,name=endsAt,proto3" json:"endsAt,omitempty"`     // unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Promotion) GetPromotionID() int64 {
+	if x != nil {
+		return x.PromotionID
+	}
+	return 0
+}
+
+func (x *Promotion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Promotion) GetMachineIDs() []int64 {
+	if x != nil {
+		return x.MachineIDs
+	}
+	return nil
+}
+
+func (x *Promotion) GetDiscountType() string {
+	if x != nil {
+		return x.DiscountType
+	}
+	return ""
+}
+
+func (x *Promotion) GetDiscountValue() int64 {
+	if x != nil {
+		return x.DiscountValue
+	}
+	return 0
+}
+
+func (x *Promotion) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *Promotion) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+type CreatePromotionReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionReq) Reset() {
+	*x = CreatePromotionReq{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionReq) ProtoMessage() {}
+
+func (x *CreatePromotionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionReq.ProtoReflect.Descriptor instead.
+func (*CreatePromotionReq) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreatePromotionReq) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type CreatePromotionResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionResp) Reset() {
+	*x = CreatePromotionResp{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionResp) ProtoMessage() {}
+
+func (x *CreatePromotionResp) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionResp.ProtoReflect.Descriptor instead.
+func (*CreatePromotionResp) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreatePromotionResp) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type GetActivePromotionsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MachineID     int64                  `protobuf:"varint,1,opt,name=machineID,proto3" json:"machineID,omitempty"` // 0 returns promotions for every machine
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivePromotionsReq) Reset() {
+	*x = GetActivePromotionsReq{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivePromotionsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivePromotionsReq) ProtoMessage() {}
+
+func (x *GetActivePromotionsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivePromotionsReq.ProtoReflect.Descriptor instead.
+func (*GetActivePromotionsReq) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetActivePromotionsReq) GetMachineID() int64 {
+	if x != nil {
+		return x.MachineID
+	}
+	return 0
+}
+
+type GetActivePromotionsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivePromotionsResp) Reset() {
+	*x = GetActivePromotionsResp{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivePromotionsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivePromotionsResp) ProtoMessage() {}
+
+func (x *GetActivePromotionsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivePromotionsResp.ProtoReflect.Descriptor instead.
+func (*GetActivePromotionsResp) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetActivePromotionsResp) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+type Voucher struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VoucherID      int64                  `protobuf:"varint,1,opt,name=voucherID,proto3" json:"voucherID,omitempty"`
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	RewardType     string                 `protobuf:"bytes,3,opt,name=rewardType,proto3" json:"rewardType,omitempty"` // coin, diamond, free_play or item
+	RewardAmount   int64                  `protobuf:"varint,4,opt,name=rewardAmount,proto3" json:"rewardAmount,omitempty"`
+	RewardItemID   int64                  `protobuf:"varint,5,opt,name=rewardItemID,proto3" json:"rewardItemID,omitempty"`     // only for item rewards
+	MaxRedemptions int64                  `protobuf:"varint,6,opt,name=maxRedemptions,proto3" json:"maxRedemptions,omitempty"` // 1 for single-use codes
+	RedeemedCount  int64                  `protobuf:"varint,7,opt,name=redeemedCount,proto3" json:"redeemedCount,omitempty"`
+	PerPlayerLimit int64                  `protobuf:"varint,8,opt,name=perPlayerLimit,proto3" json:"perPlayerLimit,omitempty"`
+	ExpiresAt      int64                  `protobuf:"varint,9,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"` // unix seconds
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Voucher) Reset() {
+	*x = Voucher{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Voucher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Voucher) ProtoMessage() {}
+
+func (x *Voucher) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Voucher.ProtoReflect.Descriptor instead.
+func (*Voucher) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Voucher) GetVoucherID() int64 {
+	if x != nil {
+		return x.VoucherID
+	}
+	return 0
+}
+
+func (x *Voucher) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Voucher) GetRewardType() string {
+	if x != nil {
+		return x.RewardType
+	}
+	return ""
+}
+
+func (x *Voucher) GetRewardAmount() int64 {
+	if x != nil {
+		return x.RewardAmount
+	}
+	return 0
+}
+
+func (x *Voucher) GetRewardItemID() int64 {
+	if x != nil {
+		return x.RewardItemID
+	}
+	return 0
+}
+
+func (x *Voucher) GetMaxRedemptions() int64 {
+	if x != nil {
+		return x.MaxRedemptions
+	}
+	return 0
+}
+
+func (x *Voucher) GetRedeemedCount() int64 {
+	if x != nil {
+		return x.RedeemedCount
+	}
+	return 0
+}
+
+func (x *Voucher) GetPerPlayerLimit() int64 {
+	if x != nil {
+		return x.PerPlayerLimit
+	}
+	return 0
+}
+
+func (x *Voucher) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type CreateVouchersReq struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Count          int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"` // number of codes to generate
+	CodePrefix     string                 `protobuf:"bytes,2,opt,name=codePrefix,proto3" json:"codePrefix,omitempty"`
+	Code           string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"` // fixed code for a single voucher, ignores count and codePrefix
+	RewardType     string                 `protobuf:"bytes,4,opt,name=rewardType,proto3" json:"rewardType,omitempty"`
+	RewardAmount   int64                  `protobuf:"varint,5,opt,name=rewardAmount,proto3" json:"rewardAmount,omitempty"`
+	RewardItemID   int64                  `protobuf:"varint,6,opt,name=rewardItemID,proto3" json:"rewardItemID,omitempty"`
+	MaxRedemptions int64                  `protobuf:"varint,7,opt,name=maxRedemptions,proto3" json:"maxRedemptions,omitempty"`
+	PerPlayerLimit int64                  `protobuf:"varint,8,opt,name=perPlayerLimit,proto3" json:"perPlayerLimit,omitempty"`
+	ExpiresAt      int64                  `protobuf:"varint,9,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"` // unix seconds
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateVouchersReq) Reset() {
+	*x = CreateVouchersReq{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVouchersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVouchersReq) ProtoMessage() {}
+
+func (x *CreateVouchersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVouchersReq.ProtoReflect.Descriptor instead.
+func (*CreateVouchersReq) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateVouchersReq) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CreateVouchersReq) GetCodePrefix() string {
+	if x != nil {
+		return x.CodePrefix
+	}
+	return ""
+}
+
+func (x *CreateVouchersReq) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateVouchersReq) GetRewardType() string {
+	if x != nil {
+		return x.RewardType
+	}
+	return ""
+}
+
+func (x *CreateVouchersReq) GetRewardAmount() int64 {
+	if x != nil {
+		return x.RewardAmount
+	}
+	return 0
+}
+
+func (x *CreateVouchersReq) GetRewardItemID() int64 {
+	if x != nil {
+		return x.RewardItemID
+	}
+	return 0
+}
+
+func (x *CreateVouchersReq) GetMaxRedemptions() int64 {
+	if x != nil {
+		return x.MaxRedemptions
+	}
+	return 0
+}
+
+func (x *CreateVouchersReq) GetPerPlayerLimit() int64 {
+	if x != nil {
+		return x.PerPlayerLimit
+	}
+	return 0
+}
+
+func (x *CreateVouchersReq) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type CreateVouchersResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vouchers      []*Voucher             `protobuf:"bytes,1,rep,name=vouchers,proto3" json:"vouchers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVouchersResp) Reset() {
+	*x = CreateVouchersResp{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVouchersResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVouchersResp) ProtoMessage() {}
+
+func (x *CreateVouchersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVouchersResp.ProtoReflect.Descriptor instead.
+func (*CreateVouchersResp) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateVouchersResp) GetVouchers() []*Voucher {
+	if x != nil {
+		return x.Vouchers
+	}
+	return nil
+}
+
+type RedeemVoucherReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID      int64                  `protobuf:"varint,1,opt,name=playerID,proto3" json:"playerID,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemVoucherReq) Reset() {
+	*x = RedeemVoucherReq{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemVoucherReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemVoucherReq) ProtoMessage() {}
+
+func (x *RedeemVoucherReq) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemVoucherReq.ProtoReflect.Descriptor instead.
+func (*RedeemVoucherReq) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RedeemVoucherReq) GetPlayerID() int64 {
+	if x != nil {
+		return x.PlayerID
+	}
+	return 0
+}
+
+func (x *RedeemVoucherReq) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type RedeemVoucherResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID      int64                  `protobuf:"varint,1,opt,name=playerID,proto3" json:"playerID,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	RewardType    string                 `protobuf:"bytes,3,opt,name=rewardType,proto3" json:"rewardType,omitempty"`
+	RewardAmount  int64                  `protobuf:"varint,4,opt,name=rewardAmount,proto3" json:"rewardAmount,omitempty"`
+	RewardItemID  int64                  `protobuf:"varint,5,opt,name=rewardItemID,proto3" json:"rewardItemID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemVoucherResp) Reset() {
+	*x = RedeemVoucherResp{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemVoucherResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemVoucherResp) ProtoMessage() {}
+
+func (x *RedeemVoucherResp) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemVoucherResp.ProtoReflect.Descriptor instead.
+func (*RedeemVoucherResp) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RedeemVoucherResp) GetPlayerID() int64 {
+	if x != nil {
+		return x.PlayerID
+	}
+	return 0
+}
+
+func (x *RedeemVoucherResp) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RedeemVoucherResp) GetRewardType() string {
+	if x != nil {
+		return x.RewardType
+	}
+	return ""
+}
+
+func (x *RedeemVoucherResp) GetRewardAmount() int64 {
+	if x != nil {
+		return x.RewardAmount
+	}
+	return 0
+}
+
+func (x *RedeemVoucherResp) GetRewardItemID() int64 {
+	if x != nil {
+		return x.RewardItemID
+	}
+	return 0
+}
+
+type AddTouchedItemRecordReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameID        int64                  `protobuf:"varint,1,opt,name=gameID,proto3" json:"gameID,omitempty"`
+	ItemID        int64                  `protobuf:"varint,2,opt,name=itemID,proto3" json:"itemID,omitempty"`
+	Catched       *bool                  `protobuf:"varint,3,opt,name=catched,proto3,oneof" json:"catched,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTouchedItemRecordReq) Reset() {
+	*x = AddTouchedItemRecordReq{}
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTouchedItemRecordReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTouchedItemRecordReq) ProtoMessage() {}
+
+func (x *AddTouchedItemRecordReq) ProtoReflect() protoreflect.Message {
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTouchedItemRecordReq.ProtoReflect.Descriptor instead.
+func (*AddTouchedItemRecordReq) Descriptor() ([]byte, []int) {
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AddTouchedItemRecordReq) GetGameID() int64 {
+	if x != nil {
+		return x.GameID
+	}
+	return 0
+}
+
+func (x *AddTouchedItemRecordReq) GetItemID() int64 {
+	if x != nil {
+		return x.ItemID
+	}
+	return 0
+}
+
+func (x *AddTouchedItemRecordReq) GetCatched() bool {
+	if x != nil && x.Catched != nil {
 		return *x.Catched
 	}
 	return false
@@ -1278,7 +2330,7 @@ type AddTouchedItemRecordResp struct {
 
 func (x *AddTouchedItemRecordResp) Reset() {
 	*x = AddTouchedItemRecordResp{}
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[23]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1290,7 +2342,7 @@ func (x *AddTouchedItemRecordResp) String() string {
 func (*AddTouchedItemRecordResp) ProtoMessage() {}
 
 func (x *AddTouchedItemRecordResp) ProtoReflect() protoreflect.Message {
-	mi := &file_clawMachine_clawMachine_proto_msgTypes[23]
+	mi := &file_clawMachine_clawMachine_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1303,7 +2355,7 @@ func (x *AddTouchedItemRecordResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddTouchedItemRecordResp.ProtoReflect.Descriptor instead.
 func (*AddTouchedItemRecordResp) Descriptor() ([]byte, []int) {
-	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{23}
+	return file_clawMachine_clawMachine_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *AddTouchedItemRecordResp) GetGameID() int64 {
@@ -1331,20 +2383,27 @@ var File_clawMachine_clawMachine_proto protoreflect.FileDescriptor
 
 const file_clawMachine_clawMachine_proto_rawDesc = "" +
 	"\n" +
-	"\x1dclawMachine/clawMachine.proto\x12\vclawMachine\x1a\x13player/player.proto\"\xc6\x01\n" +
+	"\x1dclawMachine/clawMachine.proto\x12\vclawMachine\x1a\x1cgoogle/api/annotations.proto\x1a\x13player/player.proto\"\xc6\x01\n" +
 	"\x04Item\x12\x16\n" +
 	"\x06itemID\x18\x01 \x01(\x03R\x06itemID\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
 	"\x06rarity\x18\x03 \x01(\tR\x06rarity\x12(\n" +
 	"\x0fspawnPercentage\x18\x04 \x01(\x03R\x0fspawnPercentage\x12(\n" +
 	"\x0fcatchPercentage\x18\x05 \x01(\x03R\x0fcatchPercentage\x12&\n" +
-	"\x0emaxItemSpawned\x18\x06 \x01(\x03R\x0emaxItemSpawned\"\x98\x01\n" +
+	"\x0emaxItemSpawned\x18\x06 \x01(\x03R\x0emaxItemSpawned\"\xc4\x02\n" +
 	"\vClawMachine\x12\x1c\n" +
 	"\tmachineID\x18\x01 \x01(\x03R\tmachineID\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12'\n" +
 	"\x05items\x18\x03 \x03(\v2\x11.clawMachine.ItemR\x05items\x12\x14\n" +
 	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x18\n" +
-	"\amaxItem\x18\x05 \x01(\x05R\amaxItem\"j\n" +
+	"\amaxItem\x18\x05 \x01(\x05R\amaxItem\x12\"\n" +
+	"\fcurrentPrice\x18\x06 \x01(\x03R\fcurrentPrice\x12 \n" +
+	"\vpromotionID\x18\a \x01(\x03R\vpromotionID\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12\x1c\n" +
+	"\tplayCount\x18\v \x01(\x03R\tplayCount\"j\n" +
 	"\n" +
 	"ClawPlayer\x12.\n" +
 	"\n" +
@@ -1353,12 +2412,15 @@ const file_clawMachine_clawMachine_proto_rawDesc = "" +
 	"\x04coin\x18\x02 \x01(\x03R\x04coin\x12\x18\n" +
 	"\adiamond\x18\x03 \x01(\x03R\adiamond\"\x1f\n" +
 	"\x05Items\x12\x16\n" +
-	"\x06itemID\x18\x01 \x01(\x03R\x06itemID\"\x84\x01\n" +
+	"\x06itemID\x18\x01 \x01(\x03R\x06itemID\"\xcc\x01\n" +
 	"\x14CreateClawMachineReq\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12(\n" +
 	"\x05items\x18\x02 \x03(\v2\x12.clawMachine.ItemsR\x05items\x12\x14\n" +
 	"\x05price\x18\x03 \x01(\x03R\x05price\x12\x18\n" +
-	"\amaxItem\x18\x04 \x01(\x05R\amaxItem\"K\n" +
+	"\amaxItem\x18\x04 \x01(\x05R\amaxItem\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\"K\n" +
 	"\x15CreateClawMachineResp\x122\n" +
 	"\amachine\x18\x01 \x01(\v2\x18.clawMachine.ClawMachineR\amachine\"L\n" +
 	"\x10StartClawGameReq\x12\x1a\n" +
@@ -1369,10 +2431,13 @@ const file_clawMachine_clawMachine_proto_rawDesc = "" +
 	"\x06itemID\x18\x01 \x01(\x03R\x06itemID\x12\x1d\n" +
 	"\acatched\x18\x02 \x01(\bH\x00R\acatched\x88\x01\x01B\n" +
 	"\n" +
-	"\b_catched\"^\n" +
+	"\b_catched\"\xba\x01\n" +
 	"\x11StartClawGameResp\x12\x16\n" +
 	"\x06gameID\x18\x01 \x01(\x03R\x06gameID\x121\n" +
-	"\aresults\x18\x02 \x03(\v2\x17.clawMachine.ClawResultR\aresults\"2\n" +
+	"\aresults\x18\x02 \x03(\v2\x17.clawMachine.ClawResultR\aresults\x12\x1a\n" +
+	"\bfreePlay\x18\x03 \x01(\bR\bfreePlay\x12\x1c\n" +
+	"\tpricePaid\x18\x04 \x01(\x03R\tpricePaid\x12 \n" +
+	"\vpromotionID\x18\x05 \x01(\x03R\vpromotionID\"2\n" +
 	"\x14GetClawPlayerInfoReq\x12\x1a\n" +
 	"\bplayerID\x18\x01 \x01(\x03R\bplayerID\"H\n" +
 	"\x15GetClawPlayerInfoResp\x12/\n" +
@@ -1380,7 +2445,22 @@ const file_clawMachine_clawMachine_proto_rawDesc = "" +
 	"\x15GetClawMachineInfoReq\x12\x1c\n" +
 	"\tmachineID\x18\x01 \x01(\x03R\tmachineID\"L\n" +
 	"\x16GetClawMachineInfoResp\x122\n" +
-	"\amachine\x18\x01 \x03(\v2\x18.clawMachine.ClawMachineR\amachine\"\xb7\x01\n" +
+	"\amachine\x18\x01 \x03(\v2\x18.clawMachine.ClawMachineR\amachine\"\x9b\x02\n" +
+	"\x13ListClawMachinesReq\x12\x1a\n" +
+	"\bpageSize\x18\x01 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x02 \x01(\tR\tpageToken\x12\x1a\n" +
+	"\bminPrice\x18\x03 \x01(\x03R\bminPrice\x12\x1a\n" +
+	"\bmaxPrice\x18\x04 \x01(\x03R\bmaxPrice\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06rarity\x18\x06 \x01(\tR\x06rarity\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x10\n" +
+	"\x03tag\x18\b \x01(\tR\x03tag\x12\x16\n" +
+	"\x06sortBy\x18\t \x01(\tR\x06sortBy\x12\x1c\n" +
+	"\tomitItems\x18\n" +
+	" \x01(\bR\tomitItems\"r\n" +
+	"\x14ListClawMachinesResp\x124\n" +
+	"\bmachines\x18\x01 \x03(\v2\x18.clawMachine.ClawMachineR\bmachines\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"\xb7\x01\n" +
 	"\rCreateItemReq\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
 	"\x06rarity\x18\x02 \x01(\tR\x06rarity\x12(\n" +
@@ -1408,7 +2488,75 @@ const file_clawMachine_clawMachine_proto_rawDesc = "" +
 	"\x04type\x18\x03 \x01(\tR\x04type\"]\n" +
 	"\x17AdjustPlayerDiamondResp\x12\x1a\n" +
 	"\bplayerID\x18\x01 \x01(\x03R\bplayerID\x12&\n" +
-	"\x0eadjustedAmount\x18\x02 \x01(\x03R\x0eadjustedAmount\"t\n" +
+	"\x0eadjustedAmount\x18\x02 \x01(\x03R\x0eadjustedAmount\"0\n" +
+	"\x12GetEntitlementsReq\x12\x1a\n" +
+	"\bplayerID\x18\x01 \x01(\x03R\bplayerID\"\xf9\x01\n" +
+	"\x13GetEntitlementsResp\x12\x1a\n" +
+	"\bplayerID\x18\x01 \x01(\x03R\bplayerID\x12&\n" +
+	"\x0edailyFreePlays\x18\x02 \x01(\x05R\x0edailyFreePlays\x12$\n" +
+	"\rfreePlaysUsed\x18\x03 \x01(\x05R\rfreePlaysUsed\x12.\n" +
+	"\x12freePlaysRemaining\x18\x04 \x01(\x05R\x12freePlaysRemaining\x12 \n" +
+	"\vnextResetAt\x18\x05 \x01(\x03R\vnextResetAt\x12&\n" +
+	"\x0ebonusFreePlays\x18\x06 \x01(\x03R\x0ebonusFreePlays\"\xdf\x01\n" +
+	"\tPromotion\x12 \n" +
+	"\vpromotionID\x18\x01 \x01(\x03R\vpromotionID\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"machineIDs\x18\x03 \x03(\x03R\n" +
+	"machineIDs\x12\"\n" +
+	"\fdiscountType\x18\x04 \x01(\tR\fdiscountType\x12$\n" +
+	"\rdiscountValue\x18\x05 \x01(\x03R\rdiscountValue\x12\x1a\n" +
+	"\bstartsAt\x18\x06 \x01(\x03R\bstartsAt\x12\x16\n" +
+	"\x06endsAt\x18\a \x01(\x03R\x06endsAt\"J\n" +
+	"\x12CreatePromotionReq\x124\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x16.clawMachine.PromotionR\tpromotion\"K\n" +
+	"\x13CreatePromotionResp\x124\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x16.clawMachine.PromotionR\tpromotion\"6\n" +
+	"\x16GetActivePromotionsReq\x12\x1c\n" +
+	"\tmachineID\x18\x01 \x01(\x03R\tmachineID\"Q\n" +
+	"\x17GetActivePromotionsResp\x126\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x16.clawMachine.PromotionR\n" +
+	"promotions\"\xb7\x02\n" +
+	"\aVoucher\x12\x1c\n" +
+	"\tvoucherID\x18\x01 \x01(\x03R\tvoucherID\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1e\n" +
+	"\n" +
+	"rewardType\x18\x03 \x01(\tR\n" +
+	"rewardType\x12\"\n" +
+	"\frewardAmount\x18\x04 \x01(\x03R\frewardAmount\x12\"\n" +
+	"\frewardItemID\x18\x05 \x01(\x03R\frewardItemID\x12&\n" +
+	"\x0emaxRedemptions\x18\x06 \x01(\x03R\x0emaxRedemptions\x12$\n" +
+	"\rredeemedCount\x18\a \x01(\x03R\rredeemedCount\x12&\n" +
+	"\x0eperPlayerLimit\x18\b \x01(\x03R\x0eperPlayerLimit\x12\x1c\n" +
+	"\texpiresAt\x18\t \x01(\x03R\texpiresAt\"\xb3\x02\n" +
+	"\x11CreateVouchersReq\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x1e\n" +
+	"\n" +
+	"codePrefix\x18\x02 \x01(\tR\n" +
+	"codePrefix\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x1e\n" +
+	"\n" +
+	"rewardType\x18\x04 \x01(\tR\n" +
+	"rewardType\x12\"\n" +
+	"\frewardAmount\x18\x05 \x01(\x03R\frewardAmount\x12\"\n" +
+	"\frewardItemID\x18\x06 \x01(\x03R\frewardItemID\x12&\n" +
+	"\x0emaxRedemptions\x18\a \x01(\x03R\x0emaxRedemptions\x12&\n" +
+	"\x0eperPlayerLimit\x18\b \x01(\x03R\x0eperPlayerLimit\x12\x1c\n" +
+	"\texpiresAt\x18\t \x01(\x03R\texpiresAt\"F\n" +
+	"\x12CreateVouchersResp\x120\n" +
+	"\bvouchers\x18\x01 \x03(\v2\x14.clawMachine.VoucherR\bvouchers\"B\n" +
+	"\x10RedeemVoucherReq\x12\x1a\n" +
+	"\bplayerID\x18\x01 \x01(\x03R\bplayerID\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\xab\x01\n" +
+	"\x11RedeemVoucherResp\x12\x1a\n" +
+	"\bplayerID\x18\x01 \x01(\x03R\bplayerID\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1e\n" +
+	"\n" +
+	"rewardType\x18\x03 \x01(\tR\n" +
+	"rewardType\x12\"\n" +
+	"\frewardAmount\x18\x04 \x01(\x03R\frewardAmount\x12\"\n" +
+	"\frewardItemID\x18\x05 \x01(\x03R\frewardItemID\"t\n" +
 	"\x17AddTouchedItemRecordReq\x12\x16\n" +
 	"\x06gameID\x18\x01 \x01(\x03R\x06gameID\x12\x16\n" +
 	"\x06itemID\x18\x02 \x01(\x03R\x06itemID\x12\x1d\n" +
@@ -1420,17 +2568,23 @@ const file_clawMachine_clawMachine_proto_rawDesc = "" +
 	"\x06itemID\x18\x02 \x01(\x03R\x06itemID\x12\x1d\n" +
 	"\acatched\x18\x03 \x01(\bH\x00R\acatched\x88\x01\x01B\n" +
 	"\n" +
-	"\b_catched2\xca\x06\n" +
+	"\b_catched2\xd5\x0f\n" +
 	"\x12ClawMachineService\x12W\n" +
-	"\x10CreateClawPlayer\x12 .clawMachine.CreateClawPlayerReq\x1a!.clawMachine.CreateClawPlayerResp\x12Z\n" +
-	"\x11GetClawPlayerInfo\x12!.clawMachine.GetClawPlayerInfoReq\x1a\".clawMachine.GetClawPlayerInfoResp\x12W\n" +
-	"\x10AdjustPlayerCoin\x12 .clawMachine.AdjustPlayerCoinReq\x1a!.clawMachine.AdjustPlayerCoinResp\x12`\n" +
-	"\x13AdjustPlayerDiamond\x12#.clawMachine.AdjustPlayerDiamondReq\x1a$.clawMachine.AdjustPlayerDiamondResp\x12Z\n" +
-	"\x11CreateClawMachine\x12!.clawMachine.CreateClawMachineReq\x1a\".clawMachine.CreateClawMachineResp\x12]\n" +
-	"\x12GetClawMachineInfo\x12\".clawMachine.GetClawMachineInfoReq\x1a#.clawMachine.GetClawMachineInfoResp\x12N\n" +
-	"\rStartClawGame\x12\x1d.clawMachine.StartClawGameReq\x1a\x1e.clawMachine.StartClawGameResp\x12c\n" +
-	"\x14AddTouchedItemRecord\x12$.clawMachine.AddTouchedItemRecordReq\x1a%.clawMachine.AddTouchedItemRecordResp\x12T\n" +
-	"\x0fCreateClawItems\x12\x1f.clawMachine.CreateClawItemsReq\x1a .clawMachine.CreateClawItemsRespB8Z6github.com/Richard-inter/game/pkg/protocol/clawMachineb\x06proto3"
+	"\x10CreateClawPlayer\x12 .clawMachine.CreateClawPlayerReq\x1a!.clawMachine.CreateClawPlayerResp\x12\x94\x01\n" +
+	"\x11GetClawPlayerInfo\x12!.clawMachine.GetClawPlayerInfoReq\x1a\".clawMachine.GetClawPlayerInfoResp\"8\x82\xd3\xe4\x93\x022\x120/api/v1/clawMachine/getClawPlayerInfo/{playerID}\x12\x88\x01\n" +
+	"\x10AdjustPlayerCoin\x12 .clawMachine.AdjustPlayerCoinReq\x1a!.clawMachine.AdjustPlayerCoinResp\"/\x82\xd3\xe4\x93\x02):\x01*\"$/api/v1/clawMachine/adjustPlayerCoin\x12\x94\x01\n" +
+	"\x13AdjustPlayerDiamond\x12#.clawMachine.AdjustPlayerDiamondReq\x1a$.clawMachine.AdjustPlayerDiamondResp\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/clawMachine/adjustPlayerDiamond\x12\x8c\x01\n" +
+	"\x0fGetEntitlements\x12\x1f.clawMachine.GetEntitlementsReq\x1a .clawMachine.GetEntitlementsResp\"6\x82\xd3\xe4\x93\x020\x12./api/v1/clawMachine/getEntitlements/{playerID}\x12\x8c\x01\n" +
+	"\x11CreateClawMachine\x12!.clawMachine.CreateClawMachineReq\x1a\".clawMachine.CreateClawMachineResp\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/api/v1/clawMachine/createClawMachine\x12\x99\x01\n" +
+	"\x12GetClawMachineInfo\x12\".clawMachine.GetClawMachineInfoReq\x1a#.clawMachine.GetClawMachineInfoResp\":\x82\xd3\xe4\x93\x024\x122/api/v1/clawMachine/getClawMachineInfo/{machineID}\x12\x85\x01\n" +
+	"\x10ListClawMachines\x12 .clawMachine.ListClawMachinesReq\x1a!.clawMachine.ListClawMachinesResp\",\x82\xd3\xe4\x93\x02&\x12$/api/v1/clawMachine/listClawMachines\x12\x7f\n" +
+	"\rStartClawGame\x12\x1d.clawMachine.StartClawGameReq\x1a\x1e.clawMachine.StartClawGameResp\"/\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/clawMachine/startClawGame\x88\x02\x01\x12\x9b\x01\n" +
+	"\x14AddTouchedItemRecord\x12$.clawMachine.AddTouchedItemRecordReq\x1a%.clawMachine.AddTouchedItemRecordResp\"6\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1/clawMachine/addTouchedItemRecord\x88\x02\x01\x12\x84\x01\n" +
+	"\x0fCreateClawItems\x12\x1f.clawMachine.CreateClawItemsReq\x1a .clawMachine.CreateClawItemsResp\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/clawMachine/createClawItems\x12T\n" +
+	"\x0fCreatePromotion\x12\x1f.clawMachine.CreatePromotionReq\x1a .clawMachine.CreatePromotionResp\x12\x9d\x01\n" +
+	"\x13GetActivePromotions\x12#.clawMachine.GetActivePromotionsReq\x1a$.clawMachine.GetActivePromotionsResp\";\x82\xd3\xe4\x93\x025\x123/api/v1/clawMachine/getActivePromotions/{machineID}\x12Q\n" +
+	"\x0eCreateVouchers\x12\x1e.clawMachine.CreateVouchersReq\x1a\x1f.clawMachine.CreateVouchersResp\x12|\n" +
+	"\rRedeemVoucher\x12\x1d.clawMachine.RedeemVoucherReq\x1a\x1e.clawMachine.RedeemVoucherResp\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/clawMachine/redeemVoucherB8Z6github.com/Richard-inter/game/pkg/protocol/clawMachineb\x06proto3"
 
 var (
 	file_clawMachine_clawMachine_proto_rawDescOnce sync.Once
@@ -1444,7 +2598,7 @@ func file_clawMachine_clawMachine_proto_rawDescGZIP() []byte {
 	return file_clawMachine_clawMachine_proto_rawDescData
 }
 
-var file_clawMachine_clawMachine_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_clawMachine_clawMachine_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_clawMachine_clawMachine_proto_goTypes = []any{
 	(*Item)(nil),                     // 0: clawMachine.Item
 	(*ClawMachine)(nil),              // 1: clawMachine.ClawMachine
@@ -1459,54 +2613,85 @@ var file_clawMachine_clawMachine_proto_goTypes = []any{
 	(*GetClawPlayerInfoResp)(nil),    // 10: clawMachine.GetClawPlayerInfoResp
 	(*GetClawMachineInfoReq)(nil),    // 11: clawMachine.GetClawMachineInfoReq
 	(*GetClawMachineInfoResp)(nil),   // 12: clawMachine.GetClawMachineInfoResp
-	(*CreateItemReq)(nil),            // 13: clawMachine.CreateItemReq
-	(*CreateClawItemsReq)(nil),       // 14: clawMachine.CreateClawItemsReq
-	(*CreateClawItemsResp)(nil),      // 15: clawMachine.CreateClawItemsResp
-	(*CreateClawPlayerReq)(nil),      // 16: clawMachine.CreateClawPlayerReq
-	(*CreateClawPlayerResp)(nil),     // 17: clawMachine.CreateClawPlayerResp
-	(*AdjustPlayerCoinReq)(nil),      // 18: clawMachine.AdjustPlayerCoinReq
-	(*AdjustPlayerCoinResp)(nil),     // 19: clawMachine.AdjustPlayerCoinResp
-	(*AdjustPlayerDiamondReq)(nil),   // 20: clawMachine.AdjustPlayerDiamondReq
-	(*AdjustPlayerDiamondResp)(nil),  // 21: clawMachine.AdjustPlayerDiamondResp
-	(*AddTouchedItemRecordReq)(nil),  // 22: clawMachine.AddTouchedItemRecordReq
-	(*AddTouchedItemRecordResp)(nil), // 23: clawMachine.AddTouchedItemRecordResp
-	(*player.Player)(nil),            // 24: player.Player
+	(*ListClawMachinesReq)(nil),      // 13: clawMachine.ListClawMachinesReq
+	(*ListClawMachinesResp)(nil),     // 14: clawMachine.ListClawMachinesResp
+	(*CreateItemReq)(nil),            // 15: clawMachine.CreateItemReq
+	(*CreateClawItemsReq)(nil),       // 16: clawMachine.CreateClawItemsReq
+	(*CreateClawItemsResp)(nil),      // 17: clawMachine.CreateClawItemsResp
+	(*CreateClawPlayerReq)(nil),      // 18: clawMachine.CreateClawPlayerReq
+	(*CreateClawPlayerResp)(nil),     // 19: clawMachine.CreateClawPlayerResp
+	(*AdjustPlayerCoinReq)(nil),      // 20: clawMachine.AdjustPlayerCoinReq
+	(*AdjustPlayerCoinResp)(nil),     // 21: clawMachine.AdjustPlayerCoinResp
+	(*AdjustPlayerDiamondReq)(nil),   // 22: clawMachine.AdjustPlayerDiamondReq
+	(*AdjustPlayerDiamondResp)(nil),  // 23: clawMachine.AdjustPlayerDiamondResp
+	(*GetEntitlementsReq)(nil),       // 24: clawMachine.GetEntitlementsReq
+	(*GetEntitlementsResp)(nil),      // 25: clawMachine.GetEntitlementsResp
+	(*Promotion)(nil),                // 26: clawMachine.Promotion
+	(*CreatePromotionReq)(nil),       // 27: clawMachine.CreatePromotionReq
+	(*CreatePromotionResp)(nil),      // 28: clawMachine.CreatePromotionResp
+	(*GetActivePromotionsReq)(nil),   // 29: clawMachine.GetActivePromotionsReq
+	(*GetActivePromotionsResp)(nil),  // 30: clawMachine.GetActivePromotionsResp
+	(*Voucher)(nil),                  // 31: clawMachine.Voucher
+	(*CreateVouchersReq)(nil),        // 32: clawMachine.CreateVouchersReq
+	(*CreateVouchersResp)(nil),       // 33: clawMachine.CreateVouchersResp
+	(*RedeemVoucherReq)(nil),         // 34: clawMachine.RedeemVoucherReq
+	(*RedeemVoucherResp)(nil),        // 35: clawMachine.RedeemVoucherResp
+	(*AddTouchedItemRecordReq)(nil),  // 36: clawMachine.AddTouchedItemRecordReq
+	(*AddTouchedItemRecordResp)(nil), // 37: clawMachine.AddTouchedItemRecordResp
+	(*player.Player)(nil),            // 38: player.Player
 }
 var file_clawMachine_clawMachine_proto_depIdxs = []int32{
 	0,  // 0: clawMachine.ClawMachine.items:type_name -> clawMachine.Item
-	24, // 1: clawMachine.ClawPlayer.basePlayer:type_name -> player.Player
+	38, // 1: clawMachine.ClawPlayer.basePlayer:type_name -> player.Player
 	3,  // 2: clawMachine.CreateClawMachineReq.items:type_name -> clawMachine.Items
 	1,  // 3: clawMachine.CreateClawMachineResp.machine:type_name -> clawMachine.ClawMachine
 	7,  // 4: clawMachine.StartClawGameResp.results:type_name -> clawMachine.ClawResult
 	2,  // 5: clawMachine.GetClawPlayerInfoResp.player:type_name -> clawMachine.ClawPlayer
 	1,  // 6: clawMachine.GetClawMachineInfoResp.machine:type_name -> clawMachine.ClawMachine
-	13, // 7: clawMachine.CreateClawItemsReq.clawItems:type_name -> clawMachine.CreateItemReq
-	0,  // 8: clawMachine.CreateClawItemsResp.clawItems:type_name -> clawMachine.Item
-	2,  // 9: clawMachine.CreateClawPlayerReq.player:type_name -> clawMachine.ClawPlayer
-	2,  // 10: clawMachine.CreateClawPlayerResp.player:type_name -> clawMachine.ClawPlayer
-	16, // 11: clawMachine.ClawMachineService.CreateClawPlayer:input_type -> clawMachine.CreateClawPlayerReq
-	9,  // 12: clawMachine.ClawMachineService.GetClawPlayerInfo:input_type -> clawMachine.GetClawPlayerInfoReq
-	18, // 13: clawMachine.ClawMachineService.AdjustPlayerCoin:input_type -> clawMachine.AdjustPlayerCoinReq
-	20, // 14: clawMachine.ClawMachineService.AdjustPlayerDiamond:input_type -> clawMachine.AdjustPlayerDiamondReq
-	4,  // 15: clawMachine.ClawMachineService.CreateClawMachine:input_type -> clawMachine.CreateClawMachineReq
-	11, // 16: clawMachine.ClawMachineService.GetClawMachineInfo:input_type -> clawMachine.GetClawMachineInfoReq
-	6,  // 17: clawMachine.ClawMachineService.StartClawGame:input_type -> clawMachine.StartClawGameReq
-	22, // 18: clawMachine.ClawMachineService.AddTouchedItemRecord:input_type -> clawMachine.AddTouchedItemRecordReq
-	14, // 19: clawMachine.ClawMachineService.CreateClawItems:input_type -> clawMachine.CreateClawItemsReq
-	17, // 20: clawMachine.ClawMachineService.CreateClawPlayer:output_type -> clawMachine.CreateClawPlayerResp
-	10, // 21: clawMachine.ClawMachineService.GetClawPlayerInfo:output_type -> clawMachine.GetClawPlayerInfoResp
-	19, // 22: clawMachine.ClawMachineService.AdjustPlayerCoin:output_type -> clawMachine.AdjustPlayerCoinResp
-	21, // 23: clawMachine.ClawMachineService.AdjustPlayerDiamond:output_type -> clawMachine.AdjustPlayerDiamondResp
-	5,  // 24: clawMachine.ClawMachineService.CreateClawMachine:output_type -> clawMachine.CreateClawMachineResp
-	12, // 25: clawMachine.ClawMachineService.GetClawMachineInfo:output_type -> clawMachine.GetClawMachineInfoResp
-	8,  // 26: clawMachine.ClawMachineService.StartClawGame:output_type -> clawMachine.StartClawGameResp
-	23, // 27: clawMachine.ClawMachineService.AddTouchedItemRecord:output_type -> clawMachine.AddTouchedItemRecordResp
-	15, // 28: clawMachine.ClawMachineService.CreateClawItems:output_type -> clawMachine.CreateClawItemsResp
-	20, // [20:29] is the sub-list for method output_type
-	11, // [11:20] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	1,  // 7: clawMachine.ListClawMachinesResp.machines:type_name -> clawMachine.ClawMachine
+	15, // 8: clawMachine.CreateClawItemsReq.clawItems:type_name -> clawMachine.CreateItemReq
+	0,  // 9: clawMachine.CreateClawItemsResp.clawItems:type_name -> clawMachine.Item
+	2,  // 10: clawMachine.CreateClawPlayerReq.player:type_name -> clawMachine.ClawPlayer
+	2,  // 11: clawMachine.CreateClawPlayerResp.player:type_name -> clawMachine.ClawPlayer
+	26, // 12: clawMachine.CreatePromotionReq.promotion:type_name -> clawMachine.Promotion
+	26, // 13: clawMachine.CreatePromotionResp.promotion:type_name -> clawMachine.Promotion
+	26, // 14: clawMachine.GetActivePromotionsResp.promotions:type_name -> clawMachine.Promotion
+	31, // 15: clawMachine.CreateVouchersResp.vouchers:type_name -> clawMachine.Voucher
+	18, // 16: clawMachine.ClawMachineService.CreateClawPlayer:input_type -> clawMachine.CreateClawPlayerReq
+	9,  // 17: clawMachine.ClawMachineService.GetClawPlayerInfo:input_type -> clawMachine.GetClawPlayerInfoReq
+	20, // 18: clawMachine.ClawMachineService.AdjustPlayerCoin:input_type -> clawMachine.AdjustPlayerCoinReq
+	22, // 19: clawMachine.ClawMachineService.AdjustPlayerDiamond:input_type -> clawMachine.AdjustPlayerDiamondReq
+	24, // 20: clawMachine.ClawMachineService.GetEntitlements:input_type -> clawMachine.GetEntitlementsReq
+	4,  // 21: clawMachine.ClawMachineService.CreateClawMachine:input_type -> clawMachine.CreateClawMachineReq
+	11, // 22: clawMachine.ClawMachineService.GetClawMachineInfo:input_type -> clawMachine.GetClawMachineInfoReq
+	13, // 23: clawMachine.ClawMachineService.ListClawMachines:input_type -> clawMachine.ListClawMachinesReq
+	6,  // 24: clawMachine.ClawMachineService.StartClawGame:input_type -> clawMachine.StartClawGameReq
+	36, // 25: clawMachine.ClawMachineService.AddTouchedItemRecord:input_type -> clawMachine.AddTouchedItemRecordReq
+	16, // 26: clawMachine.ClawMachineService.CreateClawItems:input_type -> clawMachine.CreateClawItemsReq
+	27, // 27: clawMachine.ClawMachineService.CreatePromotion:input_type -> clawMachine.CreatePromotionReq
+	29, // 28: clawMachine.ClawMachineService.GetActivePromotions:input_type -> clawMachine.GetActivePromotionsReq
+	32, // 29: clawMachine.ClawMachineService.CreateVouchers:input_type -> clawMachine.CreateVouchersReq
+	34, // 30: clawMachine.ClawMachineService.RedeemVoucher:input_type -> clawMachine.RedeemVoucherReq
+	19, // 31: clawMachine.ClawMachineService.CreateClawPlayer:output_type -> clawMachine.CreateClawPlayerResp
+	10, // 32: clawMachine.ClawMachineService.GetClawPlayerInfo:output_type -> clawMachine.GetClawPlayerInfoResp
+	21, // 33: clawMachine.ClawMachineService.AdjustPlayerCoin:output_type -> clawMachine.AdjustPlayerCoinResp
+	23, // 34: clawMachine.ClawMachineService.AdjustPlayerDiamond:output_type -> clawMachine.AdjustPlayerDiamondResp
+	25, // 35: clawMachine.ClawMachineService.GetEntitlements:output_type -> clawMachine.GetEntitlementsResp
+	5,  // 36: clawMachine.ClawMachineService.CreateClawMachine:output_type -> clawMachine.CreateClawMachineResp
+	12, // 37: clawMachine.ClawMachineService.GetClawMachineInfo:output_type -> clawMachine.GetClawMachineInfoResp
+	14, // 38: clawMachine.ClawMachineService.ListClawMachines:output_type -> clawMachine.ListClawMachinesResp
+	8,  // 39: clawMachine.ClawMachineService.StartClawGame:output_type -> clawMachine.StartClawGameResp
+	37, // 40: clawMachine.ClawMachineService.AddTouchedItemRecord:output_type -> clawMachine.AddTouchedItemRecordResp
+	17, // 41: clawMachine.ClawMachineService.CreateClawItems:output_type -> clawMachine.CreateClawItemsResp
+	28, // 42: clawMachine.ClawMachineService.CreatePromotion:output_type -> clawMachine.CreatePromotionResp
+	30, // 43: clawMachine.ClawMachineService.GetActivePromotions:output_type -> clawMachine.GetActivePromotionsResp
+	33, // 44: clawMachine.ClawMachineService.CreateVouchers:output_type -> clawMachine.CreateVouchersResp
+	35, // 45: clawMachine.ClawMachineService.RedeemVoucher:output_type -> clawMachine.RedeemVoucherResp
+	31, // [31:46] is the sub-list for method output_type
+	16, // [16:31] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_clawMachine_clawMachine_proto_init() }
@@ -1515,15 +2700,15 @@ func file_clawMachine_clawMachine_proto_init() {
 		return
 	}
 	file_clawMachine_clawMachine_proto_msgTypes[7].OneofWrappers = []any{}
-	file_clawMachine_clawMachine_proto_msgTypes[22].OneofWrappers = []any{}
-	file_clawMachine_clawMachine_proto_msgTypes[23].OneofWrappers = []any{}
+	file_clawMachine_clawMachine_proto_msgTypes[36].OneofWrappers = []any{}
+	file_clawMachine_clawMachine_proto_msgTypes[37].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_clawMachine_clawMachine_proto_rawDesc), len(file_clawMachine_clawMachine_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   24,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v6.33.0
+// - protoc             (unknown)
 // source: clawMachine/clawMachine.proto
 
 package clawMachine
@@ -23,30 +23,55 @@ const (
 	ClawMachineService_GetClawPlayerInfo_FullMethodName    = "/clawMachine.ClawMachineService/GetClawPlayerInfo"
 	ClawMachineService_AdjustPlayerCoin_FullMethodName     = "/clawMachine.ClawMachineService/AdjustPlayerCoin"
 	ClawMachineService_AdjustPlayerDiamond_FullMethodName  = "/clawMachine.ClawMachineService/AdjustPlayerDiamond"
+	ClawMachineService_GetEntitlements_FullMethodName      = "/clawMachine.ClawMachineService/GetEntitlements"
 	ClawMachineService_CreateClawMachine_FullMethodName    = "/clawMachine.ClawMachineService/CreateClawMachine"
 	ClawMachineService_GetClawMachineInfo_FullMethodName   = "/clawMachine.ClawMachineService/GetClawMachineInfo"
+	ClawMachineService_ListClawMachines_FullMethodName     = "/clawMachine.ClawMachineService/ListClawMachines"
 	ClawMachineService_StartClawGame_FullMethodName        = "/clawMachine.ClawMachineService/StartClawGame"
 	ClawMachineService_AddTouchedItemRecord_FullMethodName = "/clawMachine.ClawMachineService/AddTouchedItemRecord"
 	ClawMachineService_CreateClawItems_FullMethodName      = "/clawMachine.ClawMachineService/CreateClawItems"
+	ClawMachineService_CreatePromotion_FullMethodName      = "/clawMachine.ClawMachineService/CreatePromotion"
+	ClawMachineService_GetActivePromotions_FullMethodName  = "/clawMachine.ClawMachineService/GetActivePromotions"
+	ClawMachineService_CreateVouchers_FullMethodName       = "/clawMachine.ClawMachineService/CreateVouchers"
+	ClawMachineService_RedeemVoucher_FullMethodName        = "/clawMachine.ClawMachineService/RedeemVoucher"
 )
 
 // ClawMachineServiceClient is the client API for ClawMachineService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Methods with a google.api.http option are served over REST by the
+// api-service through the generated gateway, on the routes they declare.
+// CreateClawPlayer, CreatePromotion and CreateVouchers have none: their
+// routes take flat bodies with RFC 3339 times, which the hand-written
+// handlers convert.
 type ClawMachineServiceClient interface {
 	// player
 	CreateClawPlayer(ctx context.Context, in *CreateClawPlayerReq, opts ...grpc.CallOption) (*CreateClawPlayerResp, error)
 	GetClawPlayerInfo(ctx context.Context, in *GetClawPlayerInfoReq, opts ...grpc.CallOption) (*GetClawPlayerInfoResp, error)
 	AdjustPlayerCoin(ctx context.Context, in *AdjustPlayerCoinReq, opts ...grpc.CallOption) (*AdjustPlayerCoinResp, error)
 	AdjustPlayerDiamond(ctx context.Context, in *AdjustPlayerDiamondReq, opts ...grpc.CallOption) (*AdjustPlayerDiamondResp, error)
+	GetEntitlements(ctx context.Context, in *GetEntitlementsReq, opts ...grpc.CallOption) (*GetEntitlementsResp, error)
 	// machine
 	CreateClawMachine(ctx context.Context, in *CreateClawMachineReq, opts ...grpc.CallOption) (*CreateClawMachineResp, error)
+	// machineID 0 returns every machine; use ListClawMachines for the lobby
 	GetClawMachineInfo(ctx context.Context, in *GetClawMachineInfoReq, opts ...grpc.CallOption) (*GetClawMachineInfoResp, error)
-	// game
+	ListClawMachines(ctx context.Context, in *ListClawMachinesReq, opts ...grpc.CallOption) (*ListClawMachinesResp, error)
+	// Deprecated: Do not use.
+	// game: kept for existing clients, both fail with DEPRECATED. Games are
+	// played over the runtime protocol, where the server simulates the claw
+	// and settles the result.
 	StartClawGame(ctx context.Context, in *StartClawGameReq, opts ...grpc.CallOption) (*StartClawGameResp, error)
+	// Deprecated: Do not use.
 	AddTouchedItemRecord(ctx context.Context, in *AddTouchedItemRecordReq, opts ...grpc.CallOption) (*AddTouchedItemRecordResp, error)
 	// items
 	CreateClawItems(ctx context.Context, in *CreateClawItemsReq, opts ...grpc.CallOption) (*CreateClawItemsResp, error)
+	// promotions
+	CreatePromotion(ctx context.Context, in *CreatePromotionReq, opts ...grpc.CallOption) (*CreatePromotionResp, error)
+	GetActivePromotions(ctx context.Context, in *GetActivePromotionsReq, opts ...grpc.CallOption) (*GetActivePromotionsResp, error)
+	// vouchers
+	CreateVouchers(ctx context.Context, in *CreateVouchersReq, opts ...grpc.CallOption) (*CreateVouchersResp, error)
+	RedeemVoucher(ctx context.Context, in *RedeemVoucherReq, opts ...grpc.CallOption) (*RedeemVoucherResp, error)
 }
 
 type clawMachineServiceClient struct {
@@ -97,6 +122,16 @@ func (c *clawMachineServiceClient) AdjustPlayerDiamond(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *clawMachineServiceClient) GetEntitlements(ctx context.Context, in *GetEntitlementsReq, opts ...grpc.CallOption) (*GetEntitlementsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEntitlementsResp)
+	err := c.cc.Invoke(ctx, ClawMachineService_GetEntitlements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *clawMachineServiceClient) CreateClawMachine(ctx context.Context, in *CreateClawMachineReq, opts ...grpc.CallOption) (*CreateClawMachineResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateClawMachineResp)
@@ -117,6 +152,17 @@ func (c *clawMachineServiceClient) GetClawMachineInfo(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *clawMachineServiceClient) ListClawMachines(ctx context.Context, in *ListClawMachinesReq, opts ...grpc.CallOption) (*ListClawMachinesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClawMachinesResp)
+	err := c.cc.Invoke(ctx, ClawMachineService_ListClawMachines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Deprecated: Do not use.
 func (c *clawMachineServiceClient) StartClawGame(ctx context.Context, in *StartClawGameReq, opts ...grpc.CallOption) (*StartClawGameResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StartClawGameResp)
@@ -127,6 +173,7 @@ func (c *clawMachineServiceClient) StartClawGame(ctx context.Context, in *StartC
 	return out, nil
 }
 
+// Deprecated: Do not use.
 func (c *clawMachineServiceClient) AddTouchedItemRecord(ctx context.Context, in *AddTouchedItemRecordReq, opts ...grpc.CallOption) (*AddTouchedItemRecordResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddTouchedItemRecordResp)
@@ -147,23 +194,82 @@ func (c *clawMachineServiceClient) CreateClawItems(ctx context.Context, in *Crea
 	return out, nil
 }
 
+func (c *clawMachineServiceClient) CreatePromotion(ctx context.Context, in *CreatePromotionReq, opts ...grpc.CallOption) (*CreatePromotionResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePromotionResp)
+	err := c.cc.Invoke(ctx, ClawMachineService_CreatePromotion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clawMachineServiceClient) GetActivePromotions(ctx context.Context, in *GetActivePromotionsReq, opts ...grpc.CallOption) (*GetActivePromotionsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetActivePromotionsResp)
+	err := c.cc.Invoke(ctx, ClawMachineService_GetActivePromotions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clawMachineServiceClient) CreateVouchers(ctx context.Context, in *CreateVouchersReq, opts ...grpc.CallOption) (*CreateVouchersResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVouchersResp)
+	err := c.cc.Invoke(ctx, ClawMachineService_CreateVouchers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clawMachineServiceClient) RedeemVoucher(ctx context.Context, in *RedeemVoucherReq, opts ...grpc.CallOption) (*RedeemVoucherResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeemVoucherResp)
+	err := c.cc.Invoke(ctx, ClawMachineService_RedeemVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClawMachineServiceServer is the server API for ClawMachineService service.
 // All implementations must embed UnimplementedClawMachineServiceServer
 // for forward compatibility.
+//
+// Methods with a google.api.http option are served over REST by the
+// api-service through the generated gateway, on the routes they declare.
+// CreateClawPlayer, CreatePromotion and CreateVouchers have none: their
+// routes take flat bodies with RFC 3339 times, which the hand-written
+// handlers convert.
 type ClawMachineServiceServer interface {
 	// player
 	CreateClawPlayer(context.Context, *CreateClawPlayerReq) (*CreateClawPlayerResp, error)
 	GetClawPlayerInfo(context.Context, *GetClawPlayerInfoReq) (*GetClawPlayerInfoResp, error)
 	AdjustPlayerCoin(context.Context, *AdjustPlayerCoinReq) (*AdjustPlayerCoinResp, error)
 	AdjustPlayerDiamond(context.Context, *AdjustPlayerDiamondReq) (*AdjustPlayerDiamondResp, error)
+	GetEntitlements(context.Context, *GetEntitlementsReq) (*GetEntitlementsResp, error)
 	// machine
 	CreateClawMachine(context.Context, *CreateClawMachineReq) (*CreateClawMachineResp, error)
+	// machineID 0 returns every machine; use ListClawMachines for the lobby
 	GetClawMachineInfo(context.Context, *GetClawMachineInfoReq) (*GetClawMachineInfoResp, error)
-	// game
+	ListClawMachines(context.Context, *ListClawMachinesReq) (*ListClawMachinesResp, error)
+	// Deprecated: Do not use.
+	// game: kept for existing clients, both fail with DEPRECATED. Games are
+	// played over the runtime protocol, where the server simulates the claw
+	// and settles the result.
 	StartClawGame(context.Context, *StartClawGameReq) (*StartClawGameResp, error)
+	// Deprecated: Do not use.
 	AddTouchedItemRecord(context.Context, *AddTouchedItemRecordReq) (*AddTouchedItemRecordResp, error)
 	// items
 	CreateClawItems(context.Context, *CreateClawItemsReq) (*CreateClawItemsResp, error)
+	// promotions
+	CreatePromotion(context.Context, *CreatePromotionReq) (*CreatePromotionResp, error)
+	GetActivePromotions(context.Context, *GetActivePromotionsReq) (*GetActivePromotionsResp, error)
+	// vouchers
+	CreateVouchers(context.Context, *CreateVouchersReq) (*CreateVouchersResp, error)
+	RedeemVoucher(context.Context, *RedeemVoucherReq) (*RedeemVoucherResp, error)
 	mustEmbedUnimplementedClawMachineServiceServer()
 }
 
@@ -186,12 +292,18 @@ func (UnimplementedClawMachineServiceServer) AdjustPlayerCoin(context.Context, *
 func (UnimplementedClawMachineServiceServer) AdjustPlayerDiamond(context.Context, *AdjustPlayerDiamondReq) (*AdjustPlayerDiamondResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AdjustPlayerDiamond not implemented")
 }
+func (UnimplementedClawMachineServiceServer) GetEntitlements(context.Context, *GetEntitlementsReq) (*GetEntitlementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntitlements not implemented")
+}
 func (UnimplementedClawMachineServiceServer) CreateClawMachine(context.Context, *CreateClawMachineReq) (*CreateClawMachineResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateClawMachine not implemented")
 }
 func (UnimplementedClawMachineServiceServer) GetClawMachineInfo(context.Context, *GetClawMachineInfoReq) (*GetClawMachineInfoResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetClawMachineInfo not implemented")
 }
+func (UnimplementedClawMachineServiceServer) ListClawMachines(context.Context, *ListClawMachinesReq) (*ListClawMachinesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClawMachines not implemented")
+}
 func (UnimplementedClawMachineServiceServer) StartClawGame(context.Context, *StartClawGameReq) (*StartClawGameResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartClawGame not implemented")
 }
@@ -201,6 +313,18 @@ func (UnimplementedClawMachineServiceServer) AddTouchedItemRecord(context.Contex
 func (UnimplementedClawMachineServiceServer) CreateClawItems(context.Context, *CreateClawItemsReq) (*CreateClawItemsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateClawItems not implemented")
 }
+func (UnimplementedClawMachineServiceServer) CreatePromotion(context.Context, *CreatePromotionReq) (*CreatePromotionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromotion not implemented")
+}
+func (UnimplementedClawMachineServiceServer) GetActivePromotions(context.Context, *GetActivePromotionsReq) (*GetActivePromotionsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActivePromotions not implemented")
+}
+func (UnimplementedClawMachineServiceServer) CreateVouchers(context.Context, *CreateVouchersReq) (*CreateVouchersResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVouchers not implemented")
+}
+func (UnimplementedClawMachineServiceServer) RedeemVoucher(context.Context, *RedeemVoucherReq) (*RedeemVoucherResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemVoucher not implemented")
+}
 func (UnimplementedClawMachineServiceServer) mustEmbedUnimplementedClawMachineServiceServer() {}
 func (UnimplementedClawMachineServiceServer) testEmbeddedByValue()                            {}
 
@@ -294,6 +418,24 @@ func _ClawMachineService_AdjustPlayerDiamond_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClawMachineService_GetEntitlements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntitlementsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClawMachineServiceServer).GetEntitlements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClawMachineService_GetEntitlements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClawMachineServiceServer).GetEntitlements(ctx, req.(*GetEntitlementsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ClawMachineService_CreateClawMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateClawMachineReq)
 	if err := dec(in); err != nil {
@@ -330,6 +472,24 @@ func _ClawMachineService_GetClawMachineInfo_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClawMachineService_ListClawMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClawMachinesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClawMachineServiceServer).ListClawMachines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClawMachineService_ListClawMachines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClawMachineServiceServer).ListClawMachines(ctx, req.(*ListClawMachinesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ClawMachineService_StartClawGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartClawGameReq)
 	if err := dec(in); err != nil {
@@ -384,6 +544,78 @@ func _ClawMachineService_CreateClawItems_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClawMachineService_CreatePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePromotionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClawMachineServiceServer).CreatePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClawMachineService_CreatePromotion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClawMachineServiceServer).CreatePromotion(ctx, req.(*CreatePromotionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClawMachineService_GetActivePromotions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActivePromotionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClawMachineServiceServer).GetActivePromotions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClawMachineService_GetActivePromotions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClawMachineServiceServer).GetActivePromotions(ctx, req.(*GetActivePromotionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClawMachineService_CreateVouchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVouchersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClawMachineServiceServer).CreateVouchers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClawMachineService_CreateVouchers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClawMachineServiceServer).CreateVouchers(ctx, req.(*CreateVouchersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClawMachineService_RedeemVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemVoucherReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClawMachineServiceServer).RedeemVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClawMachineService_RedeemVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClawMachineServiceServer).RedeemVoucher(ctx, req.(*RedeemVoucherReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClawMachineService_ServiceDesc is the grpc.ServiceDesc for ClawMachineService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -407,6 +639,10 @@ var ClawMachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AdjustPlayerDiamond",
 			Handler:    _ClawMachineService_AdjustPlayerDiamond_Handler,
 		},
+		{
+			MethodName: "GetEntitlements",
+			Handler:    _ClawMachineService_GetEntitlements_Handler,
+		},
 		{
 			MethodName: "CreateClawMachine",
 			Handler:    _ClawMachineService_CreateClawMachine_Handler,
@@ -415,6 +651,10 @@ var ClawMachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetClawMachineInfo",
 			Handler:    _ClawMachineService_GetClawMachineInfo_Handler,
 		},
+		{
+			MethodName: "ListClawMachines",
+			Handler:    _ClawMachineService_ListClawMachines_Handler,
+		},
 		{
 			MethodName: "StartClawGame",
 			Handler:    _ClawMachineService_StartClawGame_Handler,
@@ -427,6 +667,22 @@ var ClawMachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateClawItems",
 			Handler:    _ClawMachineService_CreateClawItems_Handler,
 		},
+		{
+			MethodName: "CreatePromotion",
+			Handler:    _ClawMachineService_CreatePromotion_Handler,
+		},
+		{
+			MethodName: "GetActivePromotions",
+			Handler:    _ClawMachineService_GetActivePromotions_Handler,
+		},
+		{
+			MethodName: "CreateVouchers",
+			Handler:    _ClawMachineService_CreateVouchers_Handler,
+		},
+		{
+			MethodName: "RedeemVoucher",
+			Handler:    _ClawMachineService_RedeemVoucher_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "clawMachine/clawMachine.proto",
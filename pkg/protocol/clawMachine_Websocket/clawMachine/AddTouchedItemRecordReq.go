@@ -6,6 +6,38 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 )
 
+type AddTouchedItemRecordReqT struct {
+	GameId uint64 `json:"game_id"`
+	ItemId uint64 `json:"item_id"`
+	Catched bool `json:"catched"`
+}
+
+func (t *AddTouchedItemRecordReqT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	AddTouchedItemRecordReqStart(builder)
+	AddTouchedItemRecordReqAddGameId(builder, t.GameId)
+	AddTouchedItemRecordReqAddItemId(builder, t.ItemId)
+	AddTouchedItemRecordReqAddCatched(builder, t.Catched)
+	return AddTouchedItemRecordReqEnd(builder)
+}
+
+func (rcv *AddTouchedItemRecordReq) UnPackTo(t *AddTouchedItemRecordReqT) {
+	t.GameId = rcv.GameId()
+	t.ItemId = rcv.ItemId()
+	t.Catched = rcv.Catched()
+}
+
+func (rcv *AddTouchedItemRecordReq) UnPack() *AddTouchedItemRecordReqT {
+	if rcv == nil {
+		return nil
+	}
+	t := &AddTouchedItemRecordReqT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
 type AddTouchedItemRecordReq struct {
 	_tab flatbuffers.Table
 }
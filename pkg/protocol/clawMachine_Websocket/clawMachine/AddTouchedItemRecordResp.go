@@ -6,6 +6,38 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 )
 
+type AddTouchedItemRecordRespT struct {
+	GameId uint64 `json:"game_id"`
+	ItemId uint64 `json:"item_id"`
+	Catched bool `json:"catched"`
+}
+
+func (t *AddTouchedItemRecordRespT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	AddTouchedItemRecordRespStart(builder)
+	AddTouchedItemRecordRespAddGameId(builder, t.GameId)
+	AddTouchedItemRecordRespAddItemId(builder, t.ItemId)
+	AddTouchedItemRecordRespAddCatched(builder, t.Catched)
+	return AddTouchedItemRecordRespEnd(builder)
+}
+
+func (rcv *AddTouchedItemRecordResp) UnPackTo(t *AddTouchedItemRecordRespT) {
+	t.GameId = rcv.GameId()
+	t.ItemId = rcv.ItemId()
+	t.Catched = rcv.Catched()
+}
+
+func (rcv *AddTouchedItemRecordResp) UnPack() *AddTouchedItemRecordRespT {
+	if rcv == nil {
+		return nil
+	}
+	t := &AddTouchedItemRecordRespT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
 type AddTouchedItemRecordResp struct {
 	_tab flatbuffers.Table
 }
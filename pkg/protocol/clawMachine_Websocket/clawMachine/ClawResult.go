@@ -6,6 +6,35 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 )
 
+type ClawResultT struct {
+	ItemId uint64 `json:"item_id"`
+	Catched bool `json:"catched"`
+}
+
+func (t *ClawResultT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	ClawResultStart(builder)
+	ClawResultAddItemId(builder, t.ItemId)
+	ClawResultAddCatched(builder, t.Catched)
+	return ClawResultEnd(builder)
+}
+
+func (rcv *ClawResult) UnPackTo(t *ClawResultT) {
+	t.ItemId = rcv.ItemId()
+	t.Catched = rcv.Catched()
+}
+
+func (rcv *ClawResult) UnPack() *ClawResultT {
+	if rcv == nil {
+		return nil
+	}
+	t := &ClawResultT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
 type ClawResult struct {
 	_tab flatbuffers.Table
 }
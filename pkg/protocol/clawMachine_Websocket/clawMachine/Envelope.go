@@ -6,6 +6,45 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 )
 
+type EnvelopeT struct {
+	Type MessageType `json:"type"`
+	Payload []byte `json:"payload"`
+	RequestId uint64 `json:"request_id"`
+	Kind EnvelopeKind `json:"kind"`
+}
+
+func (t *EnvelopeT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	payloadOffset := flatbuffers.UOffsetT(0)
+	if t.Payload != nil {
+		payloadOffset = builder.CreateByteString(t.Payload)
+	}
+	EnvelopeStart(builder)
+	EnvelopeAddType(builder, t.Type)
+	EnvelopeAddPayload(builder, payloadOffset)
+	EnvelopeAddRequestId(builder, t.RequestId)
+	EnvelopeAddKind(builder, t.Kind)
+	return EnvelopeEnd(builder)
+}
+
+func (rcv *Envelope) UnPackTo(t *EnvelopeT) {
+	t.Type = rcv.Type()
+	t.Payload = rcv.PayloadBytes()
+	t.RequestId = rcv.RequestId()
+	t.Kind = rcv.Kind()
+}
+
+func (rcv *Envelope) UnPack() *EnvelopeT {
+	if rcv == nil {
+		return nil
+	}
+	t := &EnvelopeT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
 type Envelope struct {
 	_tab flatbuffers.Table
 }
@@ -87,8 +126,32 @@ func (rcv *Envelope) MutatePayload(j int, n byte) bool {
 	return false
 }
 
+func (rcv *Envelope) RequestId() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Envelope) MutateRequestId(n uint64) bool {
+	return rcv._tab.MutateUint64Slot(8, n)
+}
+
+func (rcv *Envelope) Kind() EnvelopeKind {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(10))
+	if o != 0 {
+		return EnvelopeKind(rcv._tab.GetInt8(o + rcv._tab.Pos))
+	}
+	return 0
+}
+
+func (rcv *Envelope) MutateKind(n EnvelopeKind) bool {
+	return rcv._tab.MutateInt8Slot(10, int8(n))
+}
+
 func EnvelopeStart(builder *flatbuffers.Builder) {
-	builder.StartObject(2)
+	builder.StartObject(4)
 }
 func EnvelopeAddType(builder *flatbuffers.Builder, type_ MessageType) {
 	builder.PrependInt8Slot(0, int8(type_), 0)
@@ -99,6 +162,12 @@ func EnvelopeAddPayload(builder *flatbuffers.Builder, payload flatbuffers.UOffse
 func EnvelopeStartPayloadVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
 	return builder.StartVector(1, numElems, 1)
 }
+func EnvelopeAddRequestId(builder *flatbuffers.Builder, requestId uint64) {
+	builder.PrependUint64Slot(2, requestId, 0)
+}
+func EnvelopeAddKind(builder *flatbuffers.Builder, kind EnvelopeKind) {
+	builder.PrependInt8Slot(3, int8(kind), 0)
+}
 func EnvelopeEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -6,6 +6,49 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 )
 
+type ErrorRespT struct {
+	Code int32 `json:"code"`
+	Message string `json:"message"`
+	RetryAfterMs int64 `json:"retry_after_ms"`
+	ErrorCode string `json:"error_code"`
+}
+
+func (t *ErrorRespT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	messageOffset := flatbuffers.UOffsetT(0)
+	if t.Message != "" {
+		messageOffset = builder.CreateString(t.Message)
+	}
+	errorCodeOffset := flatbuffers.UOffsetT(0)
+	if t.ErrorCode != "" {
+		errorCodeOffset = builder.CreateString(t.ErrorCode)
+	}
+	ErrorRespStart(builder)
+	ErrorRespAddCode(builder, t.Code)
+	ErrorRespAddMessage(builder, messageOffset)
+	ErrorRespAddRetryAfterMs(builder, t.RetryAfterMs)
+	ErrorRespAddErrorCode(builder, errorCodeOffset)
+	return ErrorRespEnd(builder)
+}
+
+func (rcv *ErrorResp) UnPackTo(t *ErrorRespT) {
+	t.Code = rcv.Code()
+	t.Message = string(rcv.Message())
+	t.RetryAfterMs = rcv.RetryAfterMs()
+	t.ErrorCode = string(rcv.ErrorCode())
+}
+
+func (rcv *ErrorResp) UnPack() *ErrorRespT {
+	if rcv == nil {
+		return nil
+	}
+	t := &ErrorRespT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
 type ErrorResp struct {
 	_tab flatbuffers.Table
 }
@@ -61,8 +104,28 @@ func (rcv *ErrorResp) Message() []byte {
 	return nil
 }
 
+func (rcv *ErrorResp) RetryAfterMs() int64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.GetInt64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *ErrorResp) MutateRetryAfterMs(n int64) bool {
+	return rcv._tab.MutateInt64Slot(8, n)
+}
+
+func (rcv *ErrorResp) ErrorCode() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(10))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
 func ErrorRespStart(builder *flatbuffers.Builder) {
-	builder.StartObject(2)
+	builder.StartObject(4)
 }
 func ErrorRespAddCode(builder *flatbuffers.Builder, code int32) {
 	builder.PrependInt32Slot(0, code, 0)
@@ -70,6 +133,12 @@ func ErrorRespAddCode(builder *flatbuffers.Builder, code int32) {
 func ErrorRespAddMessage(builder *flatbuffers.Builder, message flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(message), 0)
 }
+func ErrorRespAddRetryAfterMs(builder *flatbuffers.Builder, retryAfterMs int64) {
+	builder.PrependInt64Slot(2, retryAfterMs, 0)
+}
+func ErrorRespAddErrorCode(builder *flatbuffers.Builder, errorCode flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(3, flatbuffers.UOffsetT(errorCode), 0)
+}
 func ErrorRespEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -6,6 +6,32 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 )
 
+type GetPlayerInfoWsReqT struct {
+	PlayerId uint64 `json:"player_id"`
+}
+
+func (t *GetPlayerInfoWsReqT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	GetPlayerInfoWsReqStart(builder)
+	GetPlayerInfoWsReqAddPlayerId(builder, t.PlayerId)
+	return GetPlayerInfoWsReqEnd(builder)
+}
+
+func (rcv *GetPlayerInfoWsReq) UnPackTo(t *GetPlayerInfoWsReqT) {
+	t.PlayerId = rcv.PlayerId()
+}
+
+func (rcv *GetPlayerInfoWsReq) UnPack() *GetPlayerInfoWsReqT {
+	if rcv == nil {
+		return nil
+	}
+	t := &GetPlayerInfoWsReqT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
 type GetPlayerInfoWsReq struct {
 	_tab flatbuffers.Table
 }
@@ -6,6 +6,45 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 )
 
+type GetPlayerInfoWsRespT struct {
+	PlayerId uint64 `json:"player_id"`
+	Username string `json:"username"`
+	Coin int64 `json:"coin"`
+	Diamond int64 `json:"diamond"`
+}
+
+func (t *GetPlayerInfoWsRespT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	usernameOffset := flatbuffers.UOffsetT(0)
+	if t.Username != "" {
+		usernameOffset = builder.CreateString(t.Username)
+	}
+	GetPlayerInfoWsRespStart(builder)
+	GetPlayerInfoWsRespAddPlayerId(builder, t.PlayerId)
+	GetPlayerInfoWsRespAddUsername(builder, usernameOffset)
+	GetPlayerInfoWsRespAddCoin(builder, t.Coin)
+	GetPlayerInfoWsRespAddDiamond(builder, t.Diamond)
+	return GetPlayerInfoWsRespEnd(builder)
+}
+
+func (rcv *GetPlayerInfoWsResp) UnPackTo(t *GetPlayerInfoWsRespT) {
+	t.PlayerId = rcv.PlayerId()
+	t.Username = string(rcv.Username())
+	t.Coin = rcv.Coin()
+	t.Diamond = rcv.Diamond()
+}
+
+func (rcv *GetPlayerInfoWsResp) UnPack() *GetPlayerInfoWsRespT {
+	if rcv == nil {
+		return nil
+	}
+	t := &GetPlayerInfoWsRespT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
 type GetPlayerInfoWsResp struct {
 	_tab flatbuffers.Table
 }
@@ -13,6 +13,20 @@ const (
 	MessageTypeAddTouchedItemRecordResp MessageType = 3
 	MessageTypeGetPlayerInfoWsReq       MessageType = 4
 	MessageTypeGetPlayerInfoWsResp      MessageType = 5
+	MessageTypeRedeemVoucherReq         MessageType = 6
+	MessageTypeRedeemVoucherResp        MessageType = 7
+	MessageTypeSubscribeReq             MessageType = 8
+	MessageTypeUnsubscribeReq           MessageType = 9
+	MessageTypeSubscribeResp            MessageType = 10
+	MessageTypeBalanceChangedPush       MessageType = 11
+	MessageTypeNotificationPush         MessageType = 12
+	MessageTypeSessionResumePush        MessageType = 13
+	MessageTypeGetMachineInfoWsReq      MessageType = 14
+	MessageTypeGetMachineInfoWsResp     MessageType = 15
+	MessageTypeHelloReq                 MessageType = 16
+	MessageTypeWelcomeResp              MessageType = 17
+	MessageTypeClawInputReq             MessageType = 18
+	MessageTypeClawStateResp            MessageType = 19
 	MessageTypeErrorResp                MessageType = 100
 )
 
@@ -23,6 +37,20 @@ var EnumNamesMessageType = map[MessageType]string{
 	MessageTypeAddTouchedItemRecordResp: "AddTouchedItemRecordResp",
 	MessageTypeGetPlayerInfoWsReq:       "GetPlayerInfoWsReq",
 	MessageTypeGetPlayerInfoWsResp:      "GetPlayerInfoWsResp",
+	MessageTypeRedeemVoucherReq:         "RedeemVoucherReq",
+	MessageTypeRedeemVoucherResp:        "RedeemVoucherResp",
+	MessageTypeSubscribeReq:             "SubscribeReq",
+	MessageTypeUnsubscribeReq:           "UnsubscribeReq",
+	MessageTypeSubscribeResp:            "SubscribeResp",
+	MessageTypeBalanceChangedPush:       "BalanceChangedPush",
+	MessageTypeNotificationPush:         "NotificationPush",
+	MessageTypeSessionResumePush:        "SessionResumePush",
+	MessageTypeGetMachineInfoWsReq:      "GetMachineInfoWsReq",
+	MessageTypeGetMachineInfoWsResp:     "GetMachineInfoWsResp",
+	MessageTypeHelloReq:                 "HelloReq",
+	MessageTypeWelcomeResp:              "WelcomeResp",
+	MessageTypeClawInputReq:             "ClawInputReq",
+	MessageTypeClawStateResp:            "ClawStateResp",
 	MessageTypeErrorResp:                "ErrorResp",
 }
 
@@ -33,6 +61,20 @@ var EnumValuesMessageType = map[string]MessageType{
 	"AddTouchedItemRecordResp": MessageTypeAddTouchedItemRecordResp,
 	"GetPlayerInfoWsReq":       MessageTypeGetPlayerInfoWsReq,
 	"GetPlayerInfoWsResp":      MessageTypeGetPlayerInfoWsResp,
+	"RedeemVoucherReq":         MessageTypeRedeemVoucherReq,
+	"RedeemVoucherResp":        MessageTypeRedeemVoucherResp,
+	"SubscribeReq":             MessageTypeSubscribeReq,
+	"UnsubscribeReq":           MessageTypeUnsubscribeReq,
+	"SubscribeResp":            MessageTypeSubscribeResp,
+	"BalanceChangedPush":       MessageTypeBalanceChangedPush,
+	"NotificationPush":         MessageTypeNotificationPush,
+	"SessionResumePush":        MessageTypeSessionResumePush,
+	"GetMachineInfoWsReq":      MessageTypeGetMachineInfoWsReq,
+	"GetMachineInfoWsResp":     MessageTypeGetMachineInfoWsResp,
+	"HelloReq":                 MessageTypeHelloReq,
+	"WelcomeResp":              MessageTypeWelcomeResp,
+	"ClawInputReq":             MessageTypeClawInputReq,
+	"ClawStateResp":            MessageTypeClawStateResp,
 	"ErrorResp":                MessageTypeErrorResp,
 }
 
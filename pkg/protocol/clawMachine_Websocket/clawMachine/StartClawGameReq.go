@@ -6,6 +6,35 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 )
 
+type StartClawGameReqT struct {
+	PlayerId uint64 `json:"player_id"`
+	MachineId uint64 `json:"machine_id"`
+}
+
+func (t *StartClawGameReqT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	StartClawGameReqStart(builder)
+	StartClawGameReqAddPlayerId(builder, t.PlayerId)
+	StartClawGameReqAddMachineId(builder, t.MachineId)
+	return StartClawGameReqEnd(builder)
+}
+
+func (rcv *StartClawGameReq) UnPackTo(t *StartClawGameReqT) {
+	t.PlayerId = rcv.PlayerId()
+	t.MachineId = rcv.MachineId()
+}
+
+func (rcv *StartClawGameReq) UnPack() *StartClawGameReqT {
+	if rcv == nil {
+		return nil
+	}
+	t := &StartClawGameReqT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
 type StartClawGameReq struct {
 	_tab flatbuffers.Table
 }
@@ -6,6 +6,49 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 )
 
+type StartClawGameRespT struct {
+	GameId uint64 `json:"game_id"`
+	FreePlay bool `json:"free_play"`
+	PricePaid int64 `json:"price_paid"`
+	PromotionId uint64 `json:"promotion_id"`
+	Board *ClawBoardT `json:"board"`
+	Claw *ClawStateRespT `json:"claw"`
+}
+
+func (t *StartClawGameRespT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	boardOffset := t.Board.Pack(builder)
+	clawOffset := t.Claw.Pack(builder)
+	StartClawGameRespStart(builder)
+	StartClawGameRespAddGameId(builder, t.GameId)
+	StartClawGameRespAddFreePlay(builder, t.FreePlay)
+	StartClawGameRespAddPricePaid(builder, t.PricePaid)
+	StartClawGameRespAddPromotionId(builder, t.PromotionId)
+	StartClawGameRespAddBoard(builder, boardOffset)
+	StartClawGameRespAddClaw(builder, clawOffset)
+	return StartClawGameRespEnd(builder)
+}
+
+func (rcv *StartClawGameResp) UnPackTo(t *StartClawGameRespT) {
+	t.GameId = rcv.GameId()
+	t.FreePlay = rcv.FreePlay()
+	t.PricePaid = rcv.PricePaid()
+	t.PromotionId = rcv.PromotionId()
+	t.Board = rcv.Board(nil).UnPack()
+	t.Claw = rcv.Claw(nil).UnPack()
+}
+
+func (rcv *StartClawGameResp) UnPack() *StartClawGameRespT {
+	if rcv == nil {
+		return nil
+	}
+	t := &StartClawGameRespT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
 type StartClawGameResp struct {
 	_tab flatbuffers.Table
 }
@@ -53,37 +96,88 @@ func (rcv *StartClawGameResp) MutateGameId(n uint64) bool {
 	return rcv._tab.MutateUint64Slot(4, n)
 }
 
-func (rcv *StartClawGameResp) Results(obj *ClawResult, j int) bool {
-	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+func (rcv *StartClawGameResp) FreePlay() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
 	if o != 0 {
-		x := rcv._tab.Vector(o)
-		x += flatbuffers.UOffsetT(j) * 4
-		x = rcv._tab.Indirect(x)
-		obj.Init(rcv._tab.Bytes, x)
-		return true
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
 	}
 	return false
 }
 
-func (rcv *StartClawGameResp) ResultsLength() int {
-	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+func (rcv *StartClawGameResp) MutateFreePlay(n bool) bool {
+	return rcv._tab.MutateBoolSlot(8, n)
+}
+
+func (rcv *StartClawGameResp) PricePaid() int64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(10))
+	if o != 0 {
+		return rcv._tab.GetInt64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *StartClawGameResp) MutatePricePaid(n int64) bool {
+	return rcv._tab.MutateInt64Slot(10, n)
+}
+
+func (rcv *StartClawGameResp) PromotionId() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(12))
 	if o != 0 {
-		return rcv._tab.VectorLen(o)
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
 	}
 	return 0
 }
 
+func (rcv *StartClawGameResp) MutatePromotionId(n uint64) bool {
+	return rcv._tab.MutateUint64Slot(12, n)
+}
+
+func (rcv *StartClawGameResp) Board(obj *ClawBoard) *ClawBoard {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		x := rcv._tab.Indirect(o + rcv._tab.Pos)
+		if obj == nil {
+			obj = new(ClawBoard)
+		}
+		obj.Init(rcv._tab.Bytes, x)
+		return obj
+	}
+	return nil
+}
+
+func (rcv *StartClawGameResp) Claw(obj *ClawStateResp) *ClawStateResp {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		x := rcv._tab.Indirect(o + rcv._tab.Pos)
+		if obj == nil {
+			obj = new(ClawStateResp)
+		}
+		obj.Init(rcv._tab.Bytes, x)
+		return obj
+	}
+	return nil
+}
+
 func StartClawGameRespStart(builder *flatbuffers.Builder) {
-	builder.StartObject(2)
+	builder.StartObject(7)
 }
 func StartClawGameRespAddGameId(builder *flatbuffers.Builder, gameId uint64) {
 	builder.PrependUint64Slot(0, gameId, 0)
 }
-func StartClawGameRespAddResults(builder *flatbuffers.Builder, results flatbuffers.UOffsetT) {
-	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(results), 0)
+func StartClawGameRespAddFreePlay(builder *flatbuffers.Builder, freePlay bool) {
+	builder.PrependBoolSlot(2, freePlay, false)
+}
+func StartClawGameRespAddPricePaid(builder *flatbuffers.Builder, pricePaid int64) {
+	builder.PrependInt64Slot(3, pricePaid, 0)
+}
+func StartClawGameRespAddPromotionId(builder *flatbuffers.Builder, promotionId uint64) {
+	builder.PrependUint64Slot(4, promotionId, 0)
+}
+func StartClawGameRespAddBoard(builder *flatbuffers.Builder, board flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(5, flatbuffers.UOffsetT(board), 0)
 }
-func StartClawGameRespStartResultsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
-	return builder.StartVector(4, numElems, 4)
+func StartClawGameRespAddClaw(builder *flatbuffers.Builder, claw flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(6, flatbuffers.UOffsetT(claw), 0)
 }
 func StartClawGameRespEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
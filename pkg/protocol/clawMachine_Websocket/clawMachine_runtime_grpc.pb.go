@@ -19,10 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ClawMachineRuntimeService_StartClawGameWs_FullMethodName        = "/clawMachine.runtime.ClawMachineRuntimeService/StartClawGameWs"
-	ClawMachineRuntimeService_AddTouchedItemRecordWs_FullMethodName = "/clawMachine.runtime.ClawMachineRuntimeService/AddTouchedItemRecordWs"
-	ClawMachineRuntimeService_GetPlayerInfoWs_FullMethodName        = "/clawMachine.runtime.ClawMachineRuntimeService/GetPlayerInfoWs"
-	ClawMachineRuntimeService_GetMachineInfoWs_FullMethodName       = "/clawMachine.runtime.ClawMachineRuntimeService/GetMachineInfoWs"
+	ClawMachineRuntimeService_StartClawGameWs_FullMethodName  = "/clawMachine.runtime.ClawMachineRuntimeService/StartClawGameWs"
+	ClawMachineRuntimeService_ClawInputWs_FullMethodName      = "/clawMachine.runtime.ClawMachineRuntimeService/ClawInputWs"
+	ClawMachineRuntimeService_GetPlayerInfoWs_FullMethodName  = "/clawMachine.runtime.ClawMachineRuntimeService/GetPlayerInfoWs"
+	ClawMachineRuntimeService_GetMachineInfoWs_FullMethodName = "/clawMachine.runtime.ClawMachineRuntimeService/GetMachineInfoWs"
+	ClawMachineRuntimeService_RedeemVoucherWs_FullMethodName  = "/clawMachine.runtime.ClawMachineRuntimeService/RedeemVoucherWs"
 )
 
 // ClawMachineRuntimeServiceClient is the client API for ClawMachineRuntimeService service.
@@ -30,9 +31,10 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ClawMachineRuntimeServiceClient interface {
 	StartClawGameWs(ctx context.Context, in *RuntimeRequest, opts ...grpc.CallOption) (*RuntimeResponse, error)
-	AddTouchedItemRecordWs(ctx context.Context, in *RuntimeRequest, opts ...grpc.CallOption) (*RuntimeResponse, error)
+	ClawInputWs(ctx context.Context, in *RuntimeRequest, opts ...grpc.CallOption) (*RuntimeResponse, error)
 	GetPlayerInfoWs(ctx context.Context, in *RuntimeRequest, opts ...grpc.CallOption) (*RuntimeResponse, error)
 	GetMachineInfoWs(ctx context.Context, in *RuntimeRequest, opts ...grpc.CallOption) (*RuntimeResponse, error)
+	RedeemVoucherWs(ctx context.Context, in *RuntimeRequest, opts ...grpc.CallOption) (*RuntimeResponse, error)
 }
 
 type clawMachineRuntimeServiceClient struct {
@@ -53,10 +55,10 @@ func (c *clawMachineRuntimeServiceClient) StartClawGameWs(ctx context.Context, i
 	return out, nil
 }
 
-func (c *clawMachineRuntimeServiceClient) AddTouchedItemRecordWs(ctx context.Context, in *RuntimeRequest, opts ...grpc.CallOption) (*RuntimeResponse, error) {
+func (c *clawMachineRuntimeServiceClient) ClawInputWs(ctx context.Context, in *RuntimeRequest, opts ...grpc.CallOption) (*RuntimeResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RuntimeResponse)
-	err := c.cc.Invoke(ctx, ClawMachineRuntimeService_AddTouchedItemRecordWs_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ClawMachineRuntimeService_ClawInputWs_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -83,14 +85,25 @@ func (c *clawMachineRuntimeServiceClient) GetMachineInfoWs(ctx context.Context,
 	return out, nil
 }
 
+func (c *clawMachineRuntimeServiceClient) RedeemVoucherWs(ctx context.Context, in *RuntimeRequest, opts ...grpc.CallOption) (*RuntimeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RuntimeResponse)
+	err := c.cc.Invoke(ctx, ClawMachineRuntimeService_RedeemVoucherWs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClawMachineRuntimeServiceServer is the server API for ClawMachineRuntimeService service.
 // All implementations must embed UnimplementedClawMachineRuntimeServiceServer
 // for forward compatibility.
 type ClawMachineRuntimeServiceServer interface {
 	StartClawGameWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error)
-	AddTouchedItemRecordWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error)
+	ClawInputWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error)
 	GetPlayerInfoWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error)
 	GetMachineInfoWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error)
+	RedeemVoucherWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error)
 	mustEmbedUnimplementedClawMachineRuntimeServiceServer()
 }
 
@@ -104,8 +117,8 @@ type UnimplementedClawMachineRuntimeServiceServer struct{}
 func (UnimplementedClawMachineRuntimeServiceServer) StartClawGameWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartClawGameWs not implemented")
 }
-func (UnimplementedClawMachineRuntimeServiceServer) AddTouchedItemRecordWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddTouchedItemRecordWs not implemented")
+func (UnimplementedClawMachineRuntimeServiceServer) ClawInputWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClawInputWs not implemented")
 }
 func (UnimplementedClawMachineRuntimeServiceServer) GetPlayerInfoWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerInfoWs not implemented")
@@ -113,6 +126,9 @@ func (UnimplementedClawMachineRuntimeServiceServer) GetPlayerInfoWs(context.Cont
 func (UnimplementedClawMachineRuntimeServiceServer) GetMachineInfoWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMachineInfoWs not implemented")
 }
+func (UnimplementedClawMachineRuntimeServiceServer) RedeemVoucherWs(context.Context, *RuntimeRequest) (*RuntimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemVoucherWs not implemented")
+}
 func (UnimplementedClawMachineRuntimeServiceServer) mustEmbedUnimplementedClawMachineRuntimeServiceServer() {
 }
 func (UnimplementedClawMachineRuntimeServiceServer) testEmbeddedByValue() {}
@@ -153,20 +169,20 @@ func _ClawMachineRuntimeService_StartClawGameWs_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ClawMachineRuntimeService_AddTouchedItemRecordWs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ClawMachineRuntimeService_ClawInputWs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RuntimeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClawMachineRuntimeServiceServer).AddTouchedItemRecordWs(ctx, in)
+		return srv.(ClawMachineRuntimeServiceServer).ClawInputWs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ClawMachineRuntimeService_AddTouchedItemRecordWs_FullMethodName,
+		FullMethod: ClawMachineRuntimeService_ClawInputWs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClawMachineRuntimeServiceServer).AddTouchedItemRecordWs(ctx, req.(*RuntimeRequest))
+		return srv.(ClawMachineRuntimeServiceServer).ClawInputWs(ctx, req.(*RuntimeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -207,6 +223,24 @@ func _ClawMachineRuntimeService_GetMachineInfoWs_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClawMachineRuntimeService_RedeemVoucherWs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuntimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClawMachineRuntimeServiceServer).RedeemVoucherWs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClawMachineRuntimeService_RedeemVoucherWs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClawMachineRuntimeServiceServer).RedeemVoucherWs(ctx, req.(*RuntimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClawMachineRuntimeService_ServiceDesc is the grpc.ServiceDesc for ClawMachineRuntimeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -219,8 +253,8 @@ var ClawMachineRuntimeService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ClawMachineRuntimeService_StartClawGameWs_Handler,
 		},
 		{
-			MethodName: "AddTouchedItemRecordWs",
-			Handler:    _ClawMachineRuntimeService_AddTouchedItemRecordWs_Handler,
+			MethodName: "ClawInputWs",
+			Handler:    _ClawMachineRuntimeService_ClawInputWs_Handler,
 		},
 		{
 			MethodName: "GetPlayerInfoWs",
@@ -230,6 +264,10 @@ var ClawMachineRuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMachineInfoWs",
 			Handler:    _ClawMachineRuntimeService_GetMachineInfoWs_Handler,
 		},
+		{
+			MethodName: "RedeemVoucherWs",
+			Handler:    _ClawMachineRuntimeService_RedeemVoucherWs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "clawMachine_Websocket/clawMachine_runtime.proto",
@@ -0,0 +1,242 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: gateway/gateway.proto
+
+package gateway
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PushToPlayerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID      int64                  `protobuf:"varint,1,opt,name=playerID,proto3" json:"playerID,omitempty"`
+	Envelope      []byte                 `protobuf:"bytes,2,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushToPlayerReq) Reset() {
+	*x = PushToPlayerReq{}
+	mi := &file_gateway_gateway_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushToPlayerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushToPlayerReq) ProtoMessage() {}
+
+func (x *PushToPlayerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_gateway_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushToPlayerReq.ProtoReflect.Descriptor instead.
+func (*PushToPlayerReq) Descriptor() ([]byte, []int) {
+	return file_gateway_gateway_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PushToPlayerReq) GetPlayerID() int64 {
+	if x != nil {
+		return x.PlayerID
+	}
+	return 0
+}
+
+func (x *PushToPlayerReq) GetEnvelope() []byte {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+type PushToRoomReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Room          string                 `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	Envelope      []byte                 `protobuf:"bytes,2,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushToRoomReq) Reset() {
+	*x = PushToRoomReq{}
+	mi := &file_gateway_gateway_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushToRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushToRoomReq) ProtoMessage() {}
+
+func (x *PushToRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_gateway_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushToRoomReq.ProtoReflect.Descriptor instead.
+func (*PushToRoomReq) Descriptor() ([]byte, []int) {
+	return file_gateway_gateway_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PushToRoomReq) GetRoom() string {
+	if x != nil {
+		return x.Room
+	}
+	return ""
+}
+
+func (x *PushToRoomReq) GetEnvelope() []byte {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+type PushResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivered     int32                  `protobuf:"varint,1,opt,name=delivered,proto3" json:"delivered,omitempty"` // number of connections the message was queued for
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushResp) Reset() {
+	*x = PushResp{}
+	mi := &file_gateway_gateway_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushResp) ProtoMessage() {}
+
+func (x *PushResp) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_gateway_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushResp.ProtoReflect.Descriptor instead.
+func (*PushResp) Descriptor() ([]byte, []int) {
+	return file_gateway_gateway_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PushResp) GetDelivered() int32 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+var File_gateway_gateway_proto protoreflect.FileDescriptor
+
+const file_gateway_gateway_proto_rawDesc = "" +
+	"\n" +
+	"\x15gateway/gateway.proto\x12\agateway\"I\n" +
+	"\x0fPushToPlayerReq\x12\x1a\n" +
+	"\bplayerID\x18\x01 \x01(\x03R\bplayerID\x12\x1a\n" +
+	"\benvelope\x18\x02 \x01(\fR\benvelope\"?\n" +
+	"\rPushToRoomReq\x12\x12\n" +
+	"\x04room\x18\x01 \x01(\tR\x04room\x12\x1a\n" +
+	"\benvelope\x18\x02 \x01(\fR\benvelope\"(\n" +
+	"\bPushResp\x12\x1c\n" +
+	"\tdelivered\x18\x01 \x01(\x05R\tdelivered2\x86\x01\n" +
+	"\x0eGatewayService\x12;\n" +
+	"\fPushToPlayer\x12\x18.gateway.PushToPlayerReq\x1a\x11.gateway.PushResp\x127\n" +
+	"\n" +
+	"PushToRoom\x12\x16.gateway.PushToRoomReq\x1a\x11.gateway.PushRespB4Z2github.com/Richard-inter/game/pkg/protocol/gatewayb\x06proto3"
+
+var (
+	file_gateway_gateway_proto_rawDescOnce sync.Once
+	file_gateway_gateway_proto_rawDescData []byte
+)
+
+func file_gateway_gateway_proto_rawDescGZIP() []byte {
+	file_gateway_gateway_proto_rawDescOnce.Do(func() {
+		file_gateway_gateway_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gateway_gateway_proto_rawDesc), len(file_gateway_gateway_proto_rawDesc)))
+	})
+	return file_gateway_gateway_proto_rawDescData
+}
+
+var file_gateway_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_gateway_gateway_proto_goTypes = []any{
+	(*PushToPlayerReq)(nil), // 0: gateway.PushToPlayerReq
+	(*PushToRoomReq)(nil),   // 1: gateway.PushToRoomReq
+	(*PushResp)(nil),        // 2: gateway.PushResp
+}
+var file_gateway_gateway_proto_depIdxs = []int32{
+	0, // 0: gateway.GatewayService.PushToPlayer:input_type -> gateway.PushToPlayerReq
+	1, // 1: gateway.GatewayService.PushToRoom:input_type -> gateway.PushToRoomReq
+	2, // 2: gateway.GatewayService.PushToPlayer:output_type -> gateway.PushResp
+	2, // 3: gateway.GatewayService.PushToRoom:output_type -> gateway.PushResp
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_gateway_gateway_proto_init() }
+func file_gateway_gateway_proto_init() {
+	if File_gateway_gateway_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gateway_gateway_proto_rawDesc), len(file_gateway_gateway_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gateway_gateway_proto_goTypes,
+		DependencyIndexes: file_gateway_gateway_proto_depIdxs,
+		MessageInfos:      file_gateway_gateway_proto_msgTypes,
+	}.Build()
+	File_gateway_gateway_proto = out.File
+	file_gateway_gateway_proto_goTypes = nil
+	file_gateway_gateway_proto_depIdxs = nil
+}
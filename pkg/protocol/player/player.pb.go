@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.10
-// 	protoc        v6.33.0
+// 	protoc        (unknown)
 // source: player/player.proto
 
 package player
@@ -249,6 +249,102 @@ func (x *GetPlayerInfoResp) GetPlayer() *Player {
 	return nil
 }
 
+type IssueTokenReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID      int64                  `protobuf:"varint,1,opt,name=playerID,proto3" json:"playerID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueTokenReq) Reset() {
+	*x = IssueTokenReq{}
+	mi := &file_player_player_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueTokenReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTokenReq) ProtoMessage() {}
+
+func (x *IssueTokenReq) ProtoReflect() protoreflect.Message {
+	mi := &file_player_player_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTokenReq.ProtoReflect.Descriptor instead.
+func (*IssueTokenReq) Descriptor() ([]byte, []int) {
+	return file_player_player_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *IssueTokenReq) GetPlayerID() int64 {
+	if x != nil {
+		return x.PlayerID
+	}
+	return 0
+}
+
+type IssueTokenResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`          // JWT for the gateways, sent as a bearer token
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"` // unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueTokenResp) Reset() {
+	*x = IssueTokenResp{}
+	mi := &file_player_player_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueTokenResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTokenResp) ProtoMessage() {}
+
+func (x *IssueTokenResp) ProtoReflect() protoreflect.Message {
+	mi := &file_player_player_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTokenResp.ProtoReflect.Descriptor instead.
+func (*IssueTokenResp) Descriptor() ([]byte, []int) {
+	return file_player_player_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IssueTokenResp) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *IssueTokenResp) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
 var File_player_player_proto protoreflect.FileDescriptor
 
 const file_player_player_proto_rawDesc = "" +
@@ -264,10 +360,17 @@ const file_player_player_proto_rawDesc = "" +
 	"\x10GetPlayerInfoReq\x12\x1a\n" +
 	"\bplayerID\x18\x01 \x01(\x03R\bplayerID\";\n" +
 	"\x11GetPlayerInfoResp\x12&\n" +
-	"\x06player\x18\x01 \x01(\v2\x0e.player.PlayerR\x06player2\x98\x01\n" +
+	"\x06player\x18\x01 \x01(\v2\x0e.player.PlayerR\x06player\"+\n" +
+	"\rIssueTokenReq\x12\x1a\n" +
+	"\bplayerID\x18\x01 \x01(\x03R\bplayerID\"D\n" +
+	"\x0eIssueTokenResp\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1c\n" +
+	"\texpiresAt\x18\x02 \x01(\x03R\texpiresAt2\xd5\x01\n" +
 	"\rPlayerService\x12A\n" +
 	"\fCreatePlayer\x12\x17.player.CreatePlayerReq\x1a\x18.player.CreatePlayerResp\x12D\n" +
-	"\rGetPlayerInfo\x12\x18.player.GetPlayerInfoReq\x1a\x19.player.GetPlayerInfoRespB3Z1github.com/Richard-inter/game/pkg/protocol/playerb\x06proto3"
+	"\rGetPlayerInfo\x12\x18.player.GetPlayerInfoReq\x1a\x19.player.GetPlayerInfoResp\x12;\n" +
+	"\n" +
+	"IssueToken\x12\x15.player.IssueTokenReq\x1a\x16.player.IssueTokenRespB3Z1github.com/Richard-inter/game/pkg/protocol/playerb\x06proto3"
 
 var (
 	file_player_player_proto_rawDescOnce sync.Once
@@ -281,23 +384,27 @@ func file_player_player_proto_rawDescGZIP() []byte {
 	return file_player_player_proto_rawDescData
 }
 
-var file_player_player_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_player_player_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_player_player_proto_goTypes = []any{
 	(*Player)(nil),            // 0: player.Player
 	(*CreatePlayerReq)(nil),   // 1: player.CreatePlayerReq
 	(*CreatePlayerResp)(nil),  // 2: player.CreatePlayerResp
 	(*GetPlayerInfoReq)(nil),  // 3: player.GetPlayerInfoReq
 	(*GetPlayerInfoResp)(nil), // 4: player.GetPlayerInfoResp
+	(*IssueTokenReq)(nil),     // 5: player.IssueTokenReq
+	(*IssueTokenResp)(nil),    // 6: player.IssueTokenResp
 }
 var file_player_player_proto_depIdxs = []int32{
 	0, // 0: player.CreatePlayerResp.player:type_name -> player.Player
 	0, // 1: player.GetPlayerInfoResp.player:type_name -> player.Player
 	1, // 2: player.PlayerService.CreatePlayer:input_type -> player.CreatePlayerReq
 	3, // 3: player.PlayerService.GetPlayerInfo:input_type -> player.GetPlayerInfoReq
-	2, // 4: player.PlayerService.CreatePlayer:output_type -> player.CreatePlayerResp
-	4, // 5: player.PlayerService.GetPlayerInfo:output_type -> player.GetPlayerInfoResp
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
+	5, // 4: player.PlayerService.IssueToken:input_type -> player.IssueTokenReq
+	2, // 5: player.PlayerService.CreatePlayer:output_type -> player.CreatePlayerResp
+	4, // 6: player.PlayerService.GetPlayerInfo:output_type -> player.GetPlayerInfoResp
+	6, // 7: player.PlayerService.IssueToken:output_type -> player.IssueTokenResp
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
 	2, // [2:2] is the sub-list for extension extendee
 	0, // [0:2] is the sub-list for field type_name
@@ -314,7 +421,7 @@ func file_player_player_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_player_player_proto_rawDesc), len(file_player_player_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v6.33.0
+// - protoc             (unknown)
 // source: player/player.proto
 
 package player
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	PlayerService_CreatePlayer_FullMethodName  = "/player.PlayerService/CreatePlayer"
 	PlayerService_GetPlayerInfo_FullMethodName = "/player.PlayerService/GetPlayerInfo"
+	PlayerService_IssueToken_FullMethodName    = "/player.PlayerService/IssueToken"
 )
 
 // PlayerServiceClient is the client API for PlayerService service.
@@ -29,6 +30,9 @@ const (
 type PlayerServiceClient interface {
 	CreatePlayer(ctx context.Context, in *CreatePlayerReq, opts ...grpc.CallOption) (*CreatePlayerResp, error)
 	GetPlayerInfo(ctx context.Context, in *GetPlayerInfoReq, opts ...grpc.CallOption) (*GetPlayerInfoResp, error)
+	// IssueToken signs a gateway token for a player the caller has already
+	// authenticated. Only callers holding the service token may use it.
+	IssueToken(ctx context.Context, in *IssueTokenReq, opts ...grpc.CallOption) (*IssueTokenResp, error)
 }
 
 type playerServiceClient struct {
@@ -59,12 +63,25 @@ func (c *playerServiceClient) GetPlayerInfo(ctx context.Context, in *GetPlayerIn
 	return out, nil
 }
 
+func (c *playerServiceClient) IssueToken(ctx context.Context, in *IssueTokenReq, opts ...grpc.CallOption) (*IssueTokenResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueTokenResp)
+	err := c.cc.Invoke(ctx, PlayerService_IssueToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlayerServiceServer is the server API for PlayerService service.
 // All implementations must embed UnimplementedPlayerServiceServer
 // for forward compatibility.
 type PlayerServiceServer interface {
 	CreatePlayer(context.Context, *CreatePlayerReq) (*CreatePlayerResp, error)
 	GetPlayerInfo(context.Context, *GetPlayerInfoReq) (*GetPlayerInfoResp, error)
+	// IssueToken signs a gateway token for a player the caller has already
+	// authenticated. Only callers holding the service token may use it.
+	IssueToken(context.Context, *IssueTokenReq) (*IssueTokenResp, error)
 	mustEmbedUnimplementedPlayerServiceServer()
 }
 
@@ -81,6 +98,9 @@ func (UnimplementedPlayerServiceServer) CreatePlayer(context.Context, *CreatePla
 func (UnimplementedPlayerServiceServer) GetPlayerInfo(context.Context, *GetPlayerInfoReq) (*GetPlayerInfoResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerInfo not implemented")
 }
+func (UnimplementedPlayerServiceServer) IssueToken(context.Context, *IssueTokenReq) (*IssueTokenResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueToken not implemented")
+}
 func (UnimplementedPlayerServiceServer) mustEmbedUnimplementedPlayerServiceServer() {}
 func (UnimplementedPlayerServiceServer) testEmbeddedByValue()                       {}
 
@@ -138,6 +158,24 @@ func _PlayerService_GetPlayerInfo_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlayerService_IssueToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).IssueToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_IssueToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).IssueToken(ctx, req.(*IssueTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PlayerService_ServiceDesc is the grpc.ServiceDesc for PlayerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +191,10 @@ var PlayerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPlayerInfo",
 			Handler:    _PlayerService_GetPlayerInfo_Handler,
 		},
+		{
+			MethodName: "IssueToken",
+			Handler:    _PlayerService_IssueToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "player/player.proto",